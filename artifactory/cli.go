@@ -1,12 +1,15 @@
 package artifactory
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
 
+	"github.com/jfrog/build-info-go/entities"
 	"github.com/jfrog/gofrog/version"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transferinstall"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/transferplugininstall"
@@ -24,6 +27,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transfer"
 	transferconfigcore "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transferconfig"
 	transferfilescore "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transferfiles"
+	artCmdUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 
 	transferconfigmergecore "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transferconfigmerge"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/usersmanagement"
@@ -35,28 +39,58 @@ import (
 	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/builddiff"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/buildsbom"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/buildshow"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/checksumplan"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/cleanup"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/diff"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/incremental"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/listing"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/propsmanifest"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/provenance"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/remotearchive"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/resume"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/searchoutput"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/speclint"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/stat"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/streamupload"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/syncdir"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/undo"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/upstream"
+	commandsutils "github.com/jfrog/jfrog-cli/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/verifydownload"
 	"github.com/jfrog/jfrog-cli/buildtools"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/accesstokencreate"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/archiveget"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/archivels"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildadddependencies"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildaddgit"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildaddupstream"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildappend"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildclean"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildcollectenv"
+	builddiffdocs "github.com/jfrog/jfrog-cli/docs/artifactory/builddiff"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/builddiscard"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/builddockercreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpromote"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpublish"
+	buildsbomdocs "github.com/jfrog/jfrog-cli/docs/artifactory/buildsbom"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildscan"
+	buildshowdocs "github.com/jfrog/jfrog-cli/docs/artifactory/buildshow"
+	cleanupdocs "github.com/jfrog/jfrog-cli/docs/artifactory/cleanup"
 	copydocs "github.com/jfrog/jfrog-cli/docs/artifactory/copy"
 	curldocs "github.com/jfrog/jfrog-cli/docs/artifactory/curl"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/delete"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/deleteprops"
+	diffdocs "github.com/jfrog/jfrog-cli/docs/artifactory/diff"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/dockerpromote"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/dockerpull"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/dockerpush"
 	dotnetdocs "github.com/jfrog/jfrog-cli/docs/artifactory/dotnet"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/dotnetconfig"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/download"
+	dudocs "github.com/jfrog/jfrog-cli/docs/artifactory/du"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/gitlfsclean"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/gocommand"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/goconfig"
@@ -66,6 +100,7 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/groupaddusers"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/groupcreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/groupdelete"
+	lsdocs "github.com/jfrog/jfrog-cli/docs/artifactory/ls"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/move"
 	mvndoc "github.com/jfrog/jfrog-cli/docs/artifactory/mvn"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/mvnconfig"
@@ -93,12 +128,19 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repodelete"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repotemplate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repoupdate"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/restore"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/search"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/setprops"
+	speclintdocs "github.com/jfrog/jfrog-cli/docs/artifactory/speclint"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/specrender"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/specvalidate"
+	statdocs "github.com/jfrog/jfrog-cli/docs/artifactory/stat"
+	syncdocs "github.com/jfrog/jfrog-cli/docs/artifactory/sync"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/transferconfig"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/transferconfigmerge"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/transferfiles"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/transfersettings"
+	treedocs "github.com/jfrog/jfrog-cli/docs/artifactory/tree"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/upload"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/usercreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/userscreate"
@@ -106,8 +148,11 @@ import (
 	yarndocs "github.com/jfrog/jfrog-cli/docs/artifactory/yarn"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/yarnconfig"
 	"github.com/jfrog/jfrog-cli/docs/common"
+	"github.com/jfrog/jfrog-cli/schema"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/downloadcache"
 	"github.com/jfrog/jfrog-cli/utils/progressbar"
+	"github.com/jfrog/jfrog-cli/utils/spectemplate"
 	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
@@ -174,6 +219,133 @@ func GetCommands() []cli.Command {
 			BashComplete: corecommon.CreateBashCompletionFunc(),
 			Action:       deleteCmd,
 		},
+		{
+			Name:         "cleanup",
+			Flags:        cliutils.GetCommandFlags(cliutils.Cleanup),
+			Usage:        cleanupdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt cleanup", cleanupdocs.GetDescription(), cleanupdocs.Usage),
+			UsageText:    cleanupdocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(cleanupdocs.EnvVar),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       cleanupCmd,
+		},
+		{
+			Name:         "stat",
+			Flags:        cliutils.GetCommandFlags(cliutils.Stat),
+			Aliases:      []string{"info"},
+			Usage:        statdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt stat", statdocs.GetDescription(), statdocs.Usage),
+			UsageText:    statdocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       statCmd,
+		},
+		{
+			Name:         "ls",
+			Flags:        cliutils.GetCommandFlags(cliutils.Ls),
+			Usage:        lsdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt ls", lsdocs.GetDescription(), lsdocs.Usage),
+			UsageText:    lsdocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       lsCmd,
+		},
+		{
+			Name:         "tree",
+			Flags:        cliutils.GetCommandFlags(cliutils.Tree),
+			Usage:        treedocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt tree", treedocs.GetDescription(), treedocs.Usage),
+			UsageText:    treedocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       treeCmd,
+		},
+		{
+			Name:         "du",
+			Flags:        cliutils.GetCommandFlags(cliutils.Du),
+			Usage:        dudocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt du", dudocs.GetDescription(), dudocs.Usage),
+			UsageText:    dudocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       duCmd,
+		},
+		{
+			Name:  "archive",
+			Usage: "Commands for the entries of zip archives in Artifactory.",
+			Subcommands: []cli.Command{
+				{
+					Name:         "ls",
+					Flags:        cliutils.GetCommandFlags(cliutils.ArchiveLs),
+					Usage:        archivels.GetDescription(),
+					HelpName:     corecommon.CreateUsage("rt archive ls", archivels.GetDescription(), archivels.Usage),
+					UsageText:    archivels.GetArguments(),
+					ArgsUsage:    common.CreateEnvVars(),
+					BashComplete: corecommon.CreateBashCompletionFunc(),
+					Action:       archiveLsCmd,
+				},
+				{
+					Name:         "get",
+					Flags:        cliutils.GetCommandFlags(cliutils.ArchiveGet),
+					Usage:        archiveget.GetDescription(),
+					HelpName:     corecommon.CreateUsage("rt archive get", archiveget.GetDescription(), archiveget.Usage),
+					UsageText:    archiveget.GetArguments(),
+					ArgsUsage:    common.CreateEnvVars(),
+					BashComplete: corecommon.CreateBashCompletionFunc(),
+					Action:       archiveGetCmd,
+				},
+			},
+		},
+		{
+			Name:  "spec",
+			Usage: "File Spec commands.",
+			Subcommands: []cli.Command{
+				{
+					Name:         "render",
+					Flags:        cliutils.GetCommandFlags(cliutils.SpecRender),
+					Usage:        specrender.GetDescription(),
+					HelpName:     corecommon.CreateUsage("rt spec render", specrender.GetDescription(), specrender.Usage),
+					UsageText:    specrender.GetArguments(),
+					ArgsUsage:    common.CreateEnvVars(),
+					BashComplete: corecommon.CreateBashCompletionFunc(),
+					Action:       specRenderCmd,
+				},
+				{
+					Name:         "validate",
+					Flags:        cliutils.GetCommandFlags(cliutils.SpecValidate),
+					Usage:        specvalidate.GetDescription(),
+					HelpName:     corecommon.CreateUsage("rt spec validate", specvalidate.GetDescription(), specvalidate.Usage),
+					UsageText:    specvalidate.GetArguments(),
+					ArgsUsage:    common.CreateEnvVars(),
+					BashComplete: corecommon.CreateBashCompletionFunc(),
+					Action: func(c *cli.Context) error {
+						return specLintCmd(c, false)
+					},
+				},
+				{
+					Name:         "lint",
+					Flags:        cliutils.GetCommandFlags(cliutils.SpecLint),
+					Usage:        speclintdocs.GetDescription(),
+					HelpName:     corecommon.CreateUsage("rt spec lint", speclintdocs.GetDescription(), speclintdocs.Usage),
+					UsageText:    speclintdocs.GetArguments(),
+					ArgsUsage:    common.CreateEnvVars(),
+					BashComplete: corecommon.CreateBashCompletionFunc(),
+					Action: func(c *cli.Context) error {
+						return specLintCmd(c, true)
+					},
+				},
+			},
+		},
+		{
+			Name:         "restore",
+			Flags:        cliutils.GetCommandFlags(cliutils.Restore),
+			Usage:        restore.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt restore", restore.GetDescription(), restore.Usage),
+			UsageText:    restore.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(restore.EnvVar),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       restoreCmd,
+		},
 		{
 			Name:         "search",
 			Flags:        cliutils.GetCommandFlags(cliutils.Search),
@@ -185,9 +357,29 @@ func GetCommands() []cli.Command {
 			BashComplete: corecommon.CreateBashCompletionFunc(),
 			Action:       searchCmd,
 		},
+		{
+			Name:         "sync",
+			Flags:        cliutils.GetCommandFlags(cliutils.RtSync),
+			Usage:        syncdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt sync", syncdocs.GetDescription(), syncdocs.Usage),
+			UsageText:    syncdocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(syncdocs.EnvVar...),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       syncCmd,
+		},
+		{
+			Name:         "diff",
+			Flags:        cliutils.GetCommandFlags(cliutils.Diff),
+			Usage:        diffdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt diff", diffdocs.GetDescription(), diffdocs.Usage),
+			UsageText:    diffdocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       diffCmd,
+		},
 		{
 			Name:         "set-props",
-			Flags:        cliutils.GetCommandFlags(cliutils.Properties),
+			Flags:        cliutils.GetCommandFlags(cliutils.SetProps),
 			Aliases:      []string{"sp"},
 			Usage:        setprops.GetDescription(),
 			HelpName:     corecommon.CreateUsage("rt set-props", setprops.GetDescription(), setprops.Usage),
@@ -218,6 +410,36 @@ func GetCommands() []cli.Command {
 			BashComplete: corecommon.CreateBashCompletionFunc(),
 			Action:       buildPublishCmd,
 		},
+		{
+			Name:         "build-show",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildShow),
+			Usage:        buildshowdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt build-show", buildshowdocs.GetDescription(), buildshowdocs.Usage),
+			UsageText:    buildshowdocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       buildShowCmd,
+		},
+		{
+			Name:         "build-diff",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildDiff),
+			Usage:        builddiffdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt build-diff", builddiffdocs.GetDescription(), builddiffdocs.Usage),
+			UsageText:    builddiffdocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       buildDiffCmd,
+		},
+		{
+			Name:         "build-sbom",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildSbom),
+			Usage:        buildsbomdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt build-sbom", buildsbomdocs.GetDescription(), buildsbomdocs.Usage),
+			UsageText:    buildsbomdocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       buildSbomCmd,
+		},
 		{
 			Name:         "build-collect-env",
 			Aliases:      []string{"bce"},
@@ -240,6 +462,17 @@ func GetCommands() []cli.Command {
 			BashComplete: corecommon.CreateBashCompletionFunc(),
 			Action:       buildAppendCmd,
 		},
+		{
+			Name:         "build-add-upstream",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildAddUpstream),
+			Aliases:      []string{"bau"},
+			Usage:        buildaddupstream.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt build-add-upstream", buildaddupstream.GetDescription(), buildaddupstream.Usage),
+			UsageText:    buildaddupstream.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       buildAddUpstreamCmd,
+		},
 		{
 			Name:         "build-add-dependencies",
 			Flags:        cliutils.GetCommandFlags(cliutils.BuildAddDependencies),
@@ -1229,6 +1462,29 @@ func downloadCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	bandwidthLimit, err := progressbar.GetBandwidthLimit(c.String("limit-rate"))
+	if err != nil {
+		return err
+	}
+	verifier, err := createDownloadVerifier(c, downloadSpec)
+	if err != nil {
+		return err
+	}
+	incrementalSync, downloadSpec, err := prepareIncrementalDownload(c, serverDetails, downloadSpec, retries, retryWaitTime)
+	if err != nil {
+		return err
+	}
+	if c.Bool("resume") {
+		if err = validateResumeOptions(c, downloadSpec, buildConfiguration); err != nil {
+			return err
+		}
+		resumableDownloadCommand := resume.NewResumableDownloadCommand()
+		resumableDownloadCommand.SetConfiguration(configuration).SetSpec(downloadSpec).SetServerDetails(serverDetails).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+		err = progressbar.ExecWithRateLimitedProgress(resumableDownloadCommand, bandwidthLimit)
+		result := resumableDownloadCommand.Result()
+		defer cliutils.CleanupResult(result, &err)
+		return cliutils.PrintCommandSummary(result, c.Bool("detailed-summary"), false, cliutils.IsFailNoOp(c), err)
+	}
 	downloadCommand := generic.NewDownloadCommand()
 	downloadCommand.SetConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(downloadSpec).SetServerDetails(serverDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(c.Bool("detailed-summary")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
 
@@ -1236,18 +1492,120 @@ func downloadCmd(c *cli.Context) error {
 		"You can avoid this confirmation message by adding --quiet to the command.", false) {
 		return nil
 	}
+	var commandWithProgress progressbar.CommandWithProgress = downloadCommand
+	var downloadCache *downloadcache.Cache
+	var copiedPaths map[string]bool
+	if verifier != nil {
+		// The cached files are copied directly to their local paths, so the cache is not used when the files are verified.
+		commandWithProgress = verifydownload.NewVerifiedDownloadCommand(downloadCommand).SetVerifier(verifier).SetManifestName(c.String("verify-manifest")).SetVerificationRetries(retries, retryWaitTime)
+	} else {
+		downloadCache, copiedPaths = copyFromDownloadCache(c, serverDetails, downloadSpec, retries, retryWaitTime)
+	}
+	if downloadCache != nil {
+		// The downloaded files are added to the cache according to the transfer details of the download.
+		downloadCommand.SetDetailedSummary(true)
+	}
 	// This error is being checked later on because we need to generate summary report before return.
-	err = progressbar.ExecWithProgress(downloadCommand)
+	err = progressbar.ExecWithRateLimitedProgress(commandWithProgress, bandwidthLimit)
 	result := downloadCommand.Result()
 	defer cliutils.CleanupResult(result, &err)
+	summaryReader := result.Reader()
+	if downloadCache != nil && summaryReader != nil {
+		if e := downloadCache.AddDownloadedFiles(summaryReader, copiedPaths); e != nil {
+			log.Warn("Failed updating the download cache:", e.Error())
+		}
+		if !c.Bool("detailed-summary") {
+			summaryReader = nil
+		}
+	}
+	if incrementalSync != nil && err == nil && result.FailCount() == 0 && !c.Bool("dry-run") {
+		err = incrementalSync.Save()
+	}
 	basicSummary, err := cliutils.CreateSummaryReportString(result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c), err)
 	if err != nil {
 		return err
 	}
-	err = cliutils.PrintDetailedSummaryReport(basicSummary, result.Reader(), false, err)
+	err = cliutils.PrintDetailedSummaryReport(basicSummary, summaryReader, false, err)
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c))
 }
 
+// If an incremental download was requested, returns its sync and a spec which only matches the files created or modified
+// since the last successful download. With sync-deletes, all the matching files are needed, so the spec is returned as is.
+func prepareIncrementalDownload(c *cli.Context, serverDetails *coreConfig.ServerDetails, downloadSpec *spec.SpecFiles, retries, retryWaitTime int) (*incremental.Sync, *spec.SpecFiles, error) {
+	if !c.Bool("incremental") {
+		return nil, downloadSpec, nil
+	}
+	if c.Bool("resume") {
+		return nil, nil, errorutils.CheckErrorf("the --incremental option cannot be used together with the --resume option")
+	}
+	incrementalSync, err := incremental.NewSync(serverDetails, downloadSpec, retries, retryWaitTime)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.IsSet("sync-deletes") {
+		log.Info("The --sync-deletes option is used. Comparing all the matching files.")
+		return incrementalSync, downloadSpec, nil
+	}
+	incrementalSpec, err := incrementalSync.IncrementalSpec(downloadSpec)
+	return incrementalSync, incrementalSpec, err
+}
+
+// Returns the verifier of the downloaded files, or nil if the files shouldn't be verified.
+func createDownloadVerifier(c *cli.Context, downloadSpec *spec.SpecFiles) (verifydownload.Verifier, error) {
+	if !c.IsSet("verify-key") {
+		if c.IsSet("verify-manifest") {
+			return nil, errorutils.CheckErrorf("the --verify-manifest option requires the --verify-key option")
+		}
+		return nil, nil
+	}
+	for _, flag := range []string{"resume", "sync-deletes", "gpg-key"} {
+		if c.IsSet(flag) {
+			return nil, errorutils.CheckErrorf("the --%s option cannot be used together with the --verify-key option", flag)
+		}
+	}
+	for _, file := range downloadSpec.Files {
+		if file.Explode == "true" || file.Bundle != "" {
+			return nil, errorutils.CheckErrorf("the explode and bundle options cannot be used together with the --verify-key option. To verify release bundles, use the --gpg-key option")
+		}
+	}
+	return verifydownload.LoadVerifier(c.String("verify-key"))
+}
+
+// If the download cache is enabled, copies the cached files matching the spec to their local paths, before the download.
+// The files are searched only if the cache isn't empty. Returns the cache and the local paths of the copied files,
+// or a nil cache if it shouldn't be used. Failing to use the cache doesn't fail the download.
+func copyFromDownloadCache(c *cli.Context, serverDetails *coreConfig.ServerDetails, downloadSpec *spec.SpecFiles, retries, retryWaitTime int) (*downloadcache.Cache, map[string]bool) {
+	if c.Bool("dry-run") {
+		return nil, nil
+	}
+	downloadCache, err := downloadcache.GetDownloadCache()
+	if err != nil || downloadCache == nil {
+		if err != nil {
+			log.Warn("Skipping the download cache:", err.Error())
+		}
+		return nil, nil
+	}
+	empty, err := downloadCache.IsEmpty()
+	if err != nil {
+		log.Warn("Skipping the download cache:", err.Error())
+		return nil, nil
+	}
+	if empty {
+		return downloadCache, nil
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, retries, retryWaitTime, false)
+	if err != nil {
+		log.Warn("Skipping the download cache:", err.Error())
+		return nil, nil
+	}
+	copiedPaths, err := downloadCache.CopyCachedFiles(servicesManager, downloadSpec)
+	if err != nil {
+		log.Warn("Skipping the download cache:", err.Error())
+		return nil, nil
+	}
+	return downloadCache, copiedPaths
+}
+
 func uploadCmd(c *cli.Context) (err error) {
 	if c.NArg() > 0 && c.IsSet("spec") {
 		return cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
@@ -1265,6 +1623,9 @@ func uploadCmd(c *cli.Context) (err error) {
 	if err != nil {
 		return
 	}
+	if streamupload.IsStreamingSpec(uploadSpec) {
+		return streamUploadCmd(c, uploadSpec)
+	}
 	err = spec.ValidateSpec(uploadSpec.Files, true, false)
 	if err != nil {
 		return
@@ -1286,26 +1647,190 @@ func uploadCmd(c *cli.Context) (err error) {
 	if err != nil {
 		return
 	}
-	uploadCmd := generic.NewUploadCommand()
-	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	bandwidthLimit, err := progressbar.GetBandwidthLimit(c.String("limit-rate"))
 	if err != nil {
 		return
 	}
+	serversDetails, err := cliutils.CreateArtifactoryDetailsForServers(c)
+	if err != nil {
+		return
+	}
+	if len(serversDetails) > 1 {
+		return uploadToServersCmd(c, uploadSpec, configuration, buildConfiguration, serversDetails, retries, retryWaitTime)
+	}
+	uploadCmd := generic.NewUploadCommand()
+	rtDetails := serversDetails[0]
 	printDeploymentView, detailedSummary := log.IsStdErrTerminal(), c.Bool("detailed-summary")
+	if c.Bool("resume") {
+		if err = validateResumeOptions(c, uploadSpec, buildConfiguration); err != nil {
+			return
+		}
+		resumableUploadCommand := resume.NewResumableUploadCommand()
+		resumableUploadCommand.SetUploadConfiguration(configuration).SetSpec(uploadSpec).SetServerDetails(rtDetails).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+		err = progressbar.ExecWithRateLimitedProgress(resumableUploadCommand, bandwidthLimit)
+		result := resumableUploadCommand.Result()
+		defer cliutils.CleanupResult(result, &err)
+		err = cliutils.PrintCommandSummary(result, detailedSummary, printDeploymentView, cliutils.IsFailNoOp(c), err)
+		return
+	}
 	uploadCmd.SetUploadConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(uploadSpec).SetServerDetails(rtDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(detailedSummary || printDeploymentView).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
 
 	if uploadCmd.ShouldPrompt() && !coreutils.AskYesNo("Sync-deletes may delete some artifacts in Artifactory. Are you sure you want to continue?\n"+
 		"You can avoid this confirmation message by adding --quiet to the command.", false) {
 		return nil
 	}
+	var commandWithProgress progressbar.CommandWithProgress = uploadCmd
+	if c.Bool("checksum-plan") {
+		commandWithProgress = checksumplan.NewPlannedUploadCommand(uploadCmd).SetPlanRetries(retries, retryWaitTime)
+	}
 	// This error is being checked later on because we need to generate summary report before return.
-	err = progressbar.ExecWithProgress(uploadCmd)
+	err = progressbar.ExecWithRateLimitedProgress(commandWithProgress, bandwidthLimit)
 	result := uploadCmd.Result()
 	defer cliutils.CleanupResult(result, &err)
 	err = cliutils.PrintCommandSummary(uploadCmd.Result(), detailedSummary, printDeploymentView, cliutils.IsFailNoOp(c), err)
 	return
 }
 
+// Uploads the files to several servers concurrently, and prints the summary of each server.
+func uploadToServersCmd(c *cli.Context, uploadSpec *spec.SpecFiles, configuration *utils.UploadConfiguration, buildConfiguration *utils.BuildConfiguration,
+	serversDetails []*coreConfig.ServerDetails, retries, retryWaitTime int) (err error) {
+	if err = validateServersOptions(c, "resume", "limit-rate", "checksum-plan"); err != nil {
+		return
+	}
+	toCollect, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return
+	}
+	if toCollect {
+		return errorutils.CheckErrorf("build-info collection cannot be used when uploading to several servers")
+	}
+	if c.String("sync-deletes") != "" && !cliutils.GetQuietValue(c) && !coreutils.AskYesNo("Sync-deletes may delete some artifacts in all the servers. Are you sure you want to continue?\n"+
+		"You can avoid this confirmation message by adding --quiet to the command.", false) {
+		return nil
+	}
+	detailedSummary := c.Bool("detailed-summary")
+	// The commands are created in advance, each with its own copy of the spec, since the upload command changes its spec.
+	uploadCmds := make(map[*coreConfig.ServerDetails]*generic.UploadCommand, len(serversDetails))
+	for _, serverDetails := range serversDetails {
+		uploadCmd := generic.NewUploadCommand()
+		uploadCmd.SetUploadConfiguration(configuration).SetSpec(cliutils.CloneSpec(uploadSpec)).SetServerDetails(serverDetails).SetDryRun(c.Bool("dry-run")).
+			SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(true).SetDetailedSummary(detailedSummary).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+		uploadCmds[serverDetails] = uploadCmd
+		defer cliutils.CleanupResult(uploadCmd.Result(), &err)
+	}
+	serverResults := cliutils.RunOnServers(serversDetails, func(serverDetails *coreConfig.ServerDetails) (*artCmdUtils.Result, error) {
+		uploadCmd := uploadCmds[serverDetails]
+		return uploadCmd.Result(), commands.Exec(uploadCmd)
+	})
+	return cliutils.PrintServersSummary(serverResults, detailedSummary, cliutils.IsFailNoOp(c))
+}
+
+// Options which aren't supported when a command runs against several servers.
+func validateServersOptions(c *cli.Context, flags ...string) error {
+	for _, flag := range flags {
+		if c.IsSet(flag) {
+			return errorutils.CheckErrorf("the --%s option cannot be used when the --server-id option includes several servers", flag)
+		}
+	}
+	return nil
+}
+
+// Uploads the standard input, or the files packed as tar.gz or tar.zst archives, without creating temporary files.
+func streamUploadCmd(c *cli.Context, uploadSpec *spec.SpecFiles) (err error) {
+	if err = streamupload.ValidateSpec(uploadSpec); err != nil {
+		return
+	}
+	if err = validateStreamUploadOptions(c, uploadSpec); err != nil {
+		return
+	}
+	configuration, err := createUploadConfiguration(c)
+	if err != nil {
+		return
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return
+	}
+	bandwidthLimit, err := progressbar.GetBandwidthLimit(c.String("limit-rate"))
+	if err != nil {
+		return
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return
+	}
+	streamUploadCommand := streamupload.NewStreamUploadCommand()
+	streamUploadCommand.SetSpec(uploadSpec).SetServerDetails(rtDetails).SetThreads(configuration.Threads).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	err = progressbar.ExecWithRateLimitedProgress(streamUploadCommand, bandwidthLimit)
+	result := streamUploadCommand.Result()
+	defer cliutils.CleanupResult(result, &err)
+	err = cliutils.PrintCommandSummary(result, c.Bool("detailed-summary"), log.IsStdErrTerminal(), cliutils.IsFailNoOp(c), err)
+	return
+}
+
+// The streamed content can't be read twice, so options which require a dry run, a retry of the whole command
+// or the full list of uploaded files at the end of the command are not supported.
+// Archives are retried by packing them again, but the standard input can't be retried.
+func validateStreamUploadOptions(c *cli.Context, uploadSpec *spec.SpecFiles) error {
+	for _, flag := range []string{"dry-run", "sync-deletes", "deb", "resume", "checksum-plan"} {
+		if c.IsSet(flag) {
+			return errorutils.CheckErrorf("the --%s option cannot be used when uploading the standard input or %s and %s archives", flag, streamupload.ArchiveTarGz, streamupload.ArchiveTarZst)
+		}
+	}
+	for _, file := range uploadSpec.Files {
+		if file.Pattern != streamupload.StdinSource {
+			continue
+		}
+		for _, flag := range []string{"retries", "retry-wait-time"} {
+			if c.IsSet(flag) {
+				return errorutils.CheckErrorf("the --%s option cannot be used when uploading the standard input, since the standard input can't be read again", flag)
+			}
+		}
+	}
+	if len(cliutils.GetServerIds(c)) > 1 {
+		return errorutils.CheckErrorf("the standard input and %s and %s archives cannot be uploaded to several servers", streamupload.ArchiveTarGz, streamupload.ArchiveTarZst)
+	}
+	buildConfiguration, err := cliutils.CreateBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	toCollect, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if toCollect {
+		return errorutils.CheckErrorf("build-info collection cannot be used when uploading the standard input or %s and %s archives", streamupload.ArchiveTarGz, streamupload.ArchiveTarZst)
+	}
+	return nil
+}
+
+// The resumable upload and download don't support options which transform the transferred files,
+// or which require the full list of transferred files at the end of the command.
+func validateResumeOptions(c *cli.Context, transferSpec *spec.SpecFiles, buildConfiguration *utils.BuildConfiguration) error {
+	for _, flag := range []string{"dry-run", "sync-deletes", "deb", "checksum-plan"} {
+		if c.IsSet(flag) {
+			return errorutils.CheckErrorf("the --%s option cannot be used together with the --resume option", flag)
+		}
+	}
+	toCollect, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if toCollect {
+		return errorutils.CheckErrorf("build-info collection cannot be used together with the --resume option")
+	}
+	for _, file := range transferSpec.Files {
+		if file.Archive != "" || file.Explode == "true" || file.Symlinks == "true" || file.IncludeDirs == "true" {
+			return errorutils.CheckErrorf("the archive, explode, symlinks and include-dirs options cannot be used together with the --resume option")
+		}
+	}
+	return nil
+}
+
 func prepareCopyMoveCommand(c *cli.Context) (*spec.SpecFiles, error) {
 	if c.NArg() > 0 && c.IsSet("spec") {
 		return nil, cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
@@ -1354,7 +1879,8 @@ func moveCmd(c *cli.Context) error {
 		return err
 	}
 	moveCmd.SetThreads(threads).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(moveSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
-	err = commands.Exec(moveCmd)
+	undoableMoveCmd := undo.NewUndoableMoveCommand(moveCmd).SetBackupRepo(c.String("backup-to")).SetBackupRetries(retries, retryWaitTime)
+	err = commands.Exec(undoableMoveCmd)
 	result := moveCmd.Result()
 	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c), err)
 }
@@ -1425,12 +1951,10 @@ func deleteCmd(c *cli.Context) error {
 		return err
 	}
 
-	deleteCommand := generic.NewDeleteCommand()
-	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	serversDetails, err := cliutils.CreateArtifactoryDetailsForServers(c)
 	if err != nil {
 		return err
 	}
-
 	threads, err := cliutils.GetThreadsCount(c)
 	if err != nil {
 		return err
@@ -1443,90 +1967,444 @@ func deleteCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	deleteCommand.SetThreads(threads).SetQuiet(cliutils.GetQuietValue(c)).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(deleteSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
-	err = commands.Exec(deleteCommand)
+	if len(serversDetails) > 1 {
+		return deleteOnServersCmd(c, deleteSpec, serversDetails, threads, retries, retryWaitTime)
+	}
+	deleteCommand := generic.NewDeleteCommand()
+	deleteCommand.SetThreads(threads).SetQuiet(cliutils.GetQuietValue(c)).SetDryRun(c.Bool("dry-run")).SetServerDetails(serversDetails[0]).SetSpec(deleteSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	undoableDeleteCommand := undo.NewUndoableDeleteCommand(deleteCommand).SetBackupRepo(c.String("backup-to")).SetBackupRetries(retries, retryWaitTime)
+	err = commands.Exec(undoableDeleteCommand)
 	result := deleteCommand.Result()
 	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c), err)
 }
 
-func prepareSearchCommand(c *cli.Context) (*spec.SpecFiles, error) {
-	if c.NArg() > 0 && c.IsSet("spec") {
-		return nil, cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
-	}
-	if !(c.NArg() == 1 || (c.NArg() == 0 && (c.IsSet("spec") || c.IsSet("build") || c.IsSet("bundle")))) {
-		return nil, cliutils.WrongNumberOfArgumentsHandler(c)
+// Deletes the files from several servers concurrently, and prints the summary of each server.
+// The deletion is confirmed once for all the servers.
+func deleteOnServersCmd(c *cli.Context, deleteSpec *spec.SpecFiles, serversDetails []*coreConfig.ServerDetails, threads, retries, retryWaitTime int) error {
+	if !cliutils.GetQuietValue(c) && !c.Bool("dry-run") && !coreutils.AskYesNo(fmt.Sprintf("Are you sure you want to delete the matching files from the servers %s?", strings.Join(cliutils.GetServerIds(c), ", ")), false) {
+		return nil
 	}
+	serverResults := cliutils.RunOnServers(serversDetails, func(serverDetails *coreConfig.ServerDetails) (*artCmdUtils.Result, error) {
+		deleteCommand := generic.NewDeleteCommand()
+		deleteCommand.SetThreads(threads).SetQuiet(true).SetDryRun(c.Bool("dry-run")).SetServerDetails(serverDetails).SetSpec(cliutils.CloneSpec(deleteSpec)).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+		undoableDeleteCommand := undo.NewUndoableDeleteCommand(deleteCommand).SetBackupRepo(c.String("backup-to")).SetBackupRetries(retries, retryWaitTime)
+		return deleteCommand.Result(), commands.Exec(undoableDeleteCommand)
+	})
+	return cliutils.PrintServersSummary(serverResults, false, cliutils.IsFailNoOp(c))
+}
 
-	var searchSpec *spec.SpecFiles
-	var err error
-	if c.IsSet("spec") {
-		searchSpec, err = cliutils.GetSpec(c, false)
-	} else {
-		searchSpec, err = createDefaultSearchSpec(c)
+func specRenderCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
 	}
+	content, expanded, err := spectemplate.Render(c.Args().Get(0), coreutils.SpecVarsStringToMap(c.String("spec-vars")))
 	if err != nil {
-		return nil, err
+		return err
 	}
-	err = spec.ValidateSpec(searchSpec.Files, false, true)
-	if err != nil {
-		return nil, err
+	var indented bytes.Buffer
+	if err = json.Indent(&indented, content, "", "  "); err != nil {
+		return errorutils.CheckErrorf("the rendered File Spec is not a valid JSON: %s\n%s", err.Error(), string(content))
 	}
-	return searchSpec, err
+	log.Output(indented.String())
+	if expanded {
+		return schema.ValidateFileSpec(content)
+	}
+	return nil
 }
 
-func searchCmd(c *cli.Context) (err error) {
-	searchSpec, err := prepareSearchCommand(c)
+func archiveLsCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	format, err := commandsutils.GetFormat(c.String("format"))
 	if err != nil {
-		return
+		return err
 	}
-	artDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
 	if err != nil {
-		return
+		return err
 	}
 	retries, err := getRetries(c)
 	if err != nil {
-		return
+		return err
 	}
 	retryWaitTime, err := getRetryWaitTime(c)
 	if err != nil {
-		return
+		return err
 	}
-	searchCmd := generic.NewSearchCommand()
-	searchCmd.SetServerDetails(artDetails).SetSpec(searchSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
-	err = commands.Exec(searchCmd)
+	archivePath, entriesPattern := remotearchive.SplitEntryPath(c.Args().Get(0))
+	archiveLsCommand := remotearchive.NewArchiveListCommand().SetServerDetails(rtDetails).SetArchivePath(archivePath).
+		SetEntriesPattern(entriesPattern).SetFormat(format).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	return commands.Exec(archiveLsCommand)
+}
+
+func statCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	format, err := commandsutils.GetFormat(c.String("format"))
 	if err != nil {
-		return
+		return err
 	}
-	reader := searchCmd.Result().Reader()
-	defer func() {
-		e := reader.Close()
-		if err == nil {
-			err = e
-		}
-	}()
-	length, err := reader.Length()
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
 	if err != nil {
 		return err
 	}
-	err = cliutils.GetCliError(err, length, 0, cliutils.IsFailNoOp(c))
+	retries, err := getRetries(c)
 	if err != nil {
 		return err
 	}
-	if !c.Bool("count") {
-		return utils.PrintSearchResults(reader)
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
 	}
-	log.Output(length)
-	return nil
+	statCommand := stat.NewStatCommand().SetServerDetails(rtDetails).SetArtifactPath(c.Args().Get(0)).SetFormat(format).
+		SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	return commands.Exec(statCommand)
 }
 
-func preparePropsCmd(c *cli.Context) (*generic.PropsCommand, error) {
-	if c.NArg() > 1 && c.IsSet("spec") {
-		return nil, cliutils.PrintHelpAndReturnError("Only the 'artifact properties' argument should be sent when the spec option is used.", c)
+func lsCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
 	}
-	if !(c.NArg() == 2 || (c.NArg() == 1 && (c.IsSet("spec") || c.IsSet("build") || c.IsSet("bundle")))) {
+	sortField, err := listing.GetSortField(c.String("sort"))
+	if err != nil {
+		return err
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	listCommand := listing.NewListCommand().SetServerDetails(rtDetails).SetPath(c.Args().Get(0)).SetLong(c.Bool("long")).
+		SetRecursive(c.Bool("recursive")).SetSortField(sortField).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	return commands.Exec(listCommand)
+}
+
+func treeCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	depth, err := cliutils.GetIntFlagValue(c, "depth", 0)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	treeCommand := listing.NewTreeCommand().SetServerDetails(rtDetails).SetPath(c.Args().Get(0)).SetDepth(depth).
+		SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	return commands.Exec(treeCommand)
+}
+
+func duCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	depth, err := cliutils.GetIntFlagValue(c, "depth", 1)
+	if err != nil {
+		return err
+	}
+	format, err := commandsutils.GetFormat(c.String("format"), commandsutils.Table, commandsutils.Json, commandsutils.Csv)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	duCommand := listing.NewDiskUsageCommand().SetServerDetails(rtDetails).SetPath(c.Args().Get(0)).SetDepth(depth).SetFormat(format).
+		SetSnapshotPath(c.String("snapshot")).SetComparePath(c.String("compare")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	return commands.Exec(duCommand)
+}
+
+func archiveGetCmd(c *cli.Context) error {
+	if c.NArg() != 1 && c.NArg() != 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	archivePath, entry := remotearchive.SplitEntryPath(c.Args().Get(0))
+	if entry == "" {
+		return cliutils.PrintHelpAndReturnError("The entry to extract must be specified after the archive path, in the following format: <archive path>!<entry>.", c)
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	archiveGetCommand := remotearchive.NewArchiveGetCommand().SetServerDetails(rtDetails).SetArchivePath(archivePath).SetEntry(entry).
+		SetTarget(c.Args().Get(1)).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	return commands.Exec(archiveGetCommand)
+}
+
+func specLintCmd(c *cli.Context, lint bool) error {
+	if c.NArg() == 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	format, err := commandsutils.GetFormat(c.String("format"))
+	if err != nil {
+		return err
+	}
+	specLintCommand := speclint.NewSpecLintCommand().SetSpecPaths(c.Args()).SetSpecVars(coreutils.SpecVarsStringToMap(c.String("spec-vars"))).
+		SetLint(lint).SetFormat(format)
+	return commands.Exec(specLintCommand)
+}
+
+func cleanupCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	if c.String("policy") == "" {
+		return cliutils.PrintHelpAndReturnError("The --policy option is mandatory.", c)
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	threads, err := cliutils.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	cleanupCommand := cleanup.NewCleanupCommand().SetServerDetails(rtDetails).SetPolicyPath(c.String("policy")).SetDryRun(c.Bool("dry-run")).
+		SetQuiet(cliutils.GetQuietValue(c)).SetThreads(threads).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	err = commands.Exec(cleanupCommand)
+	if c.Bool("dry-run") {
+		return err
+	}
+	result := cleanupCommand.Result()
+	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c), err)
+}
+
+func restoreCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	threads, err := cliutils.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	restoreCommand := undo.NewRestoreCommand().SetServerDetails(rtDetails).SetOperationId(c.Args().Get(0)).SetThreads(threads).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	err = commands.Exec(restoreCommand)
+	result := restoreCommand.Result()
+	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c), err)
+}
+
+func prepareSearchCommand(c *cli.Context) (*spec.SpecFiles, error) {
+	if c.NArg() > 0 && c.IsSet("spec") {
+		return nil, cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
+	}
+	if !(c.NArg() == 1 || (c.NArg() == 0 && (c.IsSet("spec") || c.IsSet("build") || c.IsSet("bundle")))) {
 		return nil, cliutils.WrongNumberOfArgumentsHandler(c)
 	}
 
+	var searchSpec *spec.SpecFiles
+	var err error
+	if c.IsSet("spec") {
+		searchSpec, err = cliutils.GetSpec(c, false)
+	} else {
+		searchSpec, err = createDefaultSearchSpec(c)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = spec.ValidateSpec(searchSpec.Files, false, true)
+	if err != nil {
+		return nil, err
+	}
+	return searchSpec, err
+}
+
+func searchCmd(c *cli.Context) (err error) {
+	searchSpec, err := prepareSearchCommand(c)
+	if err != nil {
+		return
+	}
+	format, err := commandsutils.GetFormat(c.String("format"), searchoutput.Formats...)
+	if err != nil {
+		return
+	}
+	fields, err := searchoutput.ParseFields(c.String("fields"))
+	if err != nil {
+		return
+	}
+	artDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return
+	}
+	searchCmd := generic.NewSearchCommand()
+	searchCmd.SetServerDetails(artDetails).SetSpec(searchSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	err = commands.Exec(searchCmd)
+	if err != nil {
+		return
+	}
+	reader := searchCmd.Result().Reader()
+	defer func() {
+		e := reader.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+	length, err := reader.Length()
+	if err != nil {
+		return err
+	}
+	err = cliutils.GetCliError(err, length, 0, cliutils.IsFailNoOp(c))
+	if err != nil {
+		return err
+	}
+	if c.Bool("count") {
+		log.Output(length)
+		return nil
+	}
+	if !c.IsSet("format") && !c.IsSet("fields") {
+		return utils.PrintSearchResults(reader)
+	}
+	return searchoutput.PrintSearchResults(reader, format, fields, os.Stdout)
+}
+
+func syncCmd(c *cli.Context) (err error) {
+	if c.NArg() != 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	policy, err := syncdir.GetPolicy(c.String("policy"))
+	if err != nil {
+		return
+	}
+	threads, err := cliutils.GetThreadsCount(c)
+	if err != nil {
+		return
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return
+	}
+	bandwidthLimit, err := progressbar.GetBandwidthLimit(c.String("limit-rate"))
+	if err != nil {
+		return
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return
+	}
+	syncCommand := syncdir.NewSyncCommand()
+	syncCommand.SetServerDetails(rtDetails).SetLocalDir(c.Args().Get(0)).SetRepoPath(c.Args().Get(1)).SetPolicy(policy).
+		SetThreads(threads).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime).SetDryRun(c.Bool("dry-run"))
+	// This error is being checked later on because we need to generate summary report before return.
+	err = progressbar.ExecWithRateLimitedProgress(syncCommand, bandwidthLimit)
+	result := syncCommand.Result()
+	defer cliutils.CleanupResult(result, &err)
+	// A dry run transfers no files, so it isn't a no-op.
+	err = cliutils.PrintCommandSummary(result, c.Bool("detailed-summary"), false, cliutils.IsFailNoOp(c) && !c.Bool("dry-run"), err)
+	return
+}
+
+func diffCmd(c *cli.Context) error {
+	source := diff.Side{Build: c.String("source-build")}
+	target := diff.Side{Build: c.String("target-build")}
+	// Each side is either a pattern argument, a build, or a build filtered by a pattern argument.
+	switch {
+	case c.NArg() == 2:
+		source.Pattern, target.Pattern = c.Args().Get(0), c.Args().Get(1)
+	case c.NArg() == 1 && source.Build != "" && target.Build == "":
+		target.Pattern = c.Args().Get(0)
+	case c.NArg() == 1 && source.Build == "" && target.Build != "":
+		source.Pattern = c.Args().Get(0)
+	case c.NArg() == 0 && source.Build != "" && target.Build != "":
+	default:
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	format, err := commandsutils.GetFormat(c.String("format"))
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	diffCommand := diff.NewDiffCommand()
+	diffCommand.SetServerDetails(rtDetails).SetSource(source).SetTarget(target).SetCompareProps(c.BoolT("compare-props")).
+		SetFormat(format).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	return commands.Exec(diffCommand)
+}
+
+func preparePropsCmd(c *cli.Context) (*generic.PropsCommand, []*coreConfig.ServerDetails, error) {
+	if c.NArg() > 1 && c.IsSet("spec") {
+		return nil, nil, cliutils.PrintHelpAndReturnError("Only the 'artifact properties' argument should be sent when the spec option is used.", c)
+	}
+	if !(c.NArg() == 2 || (c.NArg() == 1 && (c.IsSet("spec") || c.IsSet("build") || c.IsSet("bundle")))) {
+		return nil, nil, cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+
 	var propsSpec *spec.SpecFiles
 	var err error
 	var props string
@@ -1543,30 +2421,40 @@ func preparePropsCmd(c *cli.Context) (*generic.PropsCommand, error) {
 		}
 	}
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	err = spec.ValidateSpec(propsSpec.Files, false, true)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	command := generic.NewPropsCommand()
-	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	serversDetails, err := cliutils.CreateArtifactoryDetailsForServers(c)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	threads, err := cliutils.GetThreadsCount(c)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	cmd := command.SetProps(props)
-	cmd.SetThreads(threads).SetSpec(propsSpec).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails)
-	return cmd, nil
+	cmd.SetThreads(threads).SetSpec(propsSpec).SetDryRun(c.Bool("dry-run")).SetServerDetails(serversDetails[0])
+	return cmd, serversDetails, nil
+}
+
+// Creates a copy of the properties command for the server, with a result of its own.
+func copyPropsCmd(cmd *generic.PropsCommand, serverDetails *coreConfig.ServerDetails) *generic.PropsCommand {
+	serverCmd := generic.NewPropsCommand().SetProps(cmd.Props()).SetThreads(cmd.Threads())
+	serverCmd.SetSpec(cliutils.CloneSpec(cmd.Spec())).SetDryRun(cmd.DryRun()).SetServerDetails(serverDetails)
+	return serverCmd
 }
 
 func setPropsCmd(c *cli.Context) error {
-	cmd, err := preparePropsCmd(c)
+	if c.IsSet("manifest") {
+		return setPropsManifestCmd(c)
+	}
+	cmd, serversDetails, err := preparePropsCmd(c)
 	if err != nil {
 		return err
 	}
@@ -1578,6 +2466,13 @@ func setPropsCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if len(serversDetails) > 1 {
+		return cliutils.PrintServersSummary(cliutils.RunOnServers(serversDetails, func(serverDetails *coreConfig.ServerDetails) (*artCmdUtils.Result, error) {
+			propsCmd := generic.NewSetPropsCommand().SetPropsCommand(*copyPropsCmd(cmd, serverDetails))
+			propsCmd.SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+			return propsCmd.Result(), commands.Exec(propsCmd)
+		}), false, cliutils.IsFailNoOp(c))
+	}
 	propsCmd := generic.NewSetPropsCommand().SetPropsCommand(*cmd)
 	propsCmd.SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
 	err = commands.Exec(propsCmd)
@@ -1585,8 +2480,42 @@ func setPropsCmd(c *cli.Context) error {
 	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c), err)
 }
 
+func setPropsManifestCmd(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cliutils.PrintHelpAndReturnError("No arguments should be sent when the manifest option is used.", c)
+	}
+	if c.IsSet("spec") || c.IsSet("build") || c.IsSet("bundle") {
+		return cliutils.PrintHelpAndReturnError("The manifest option cannot be used together with the spec, build or bundle options.", c)
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	threads, err := cliutils.GetThreadsCount(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	manifestCmd := propsmanifest.NewSetPropsManifestCommand().SetServerDetails(rtDetails).SetManifestPath(c.String("manifest")).
+		SetThreads(threads).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	err = commands.Exec(manifestCmd)
+	if results := manifestCmd.Results(); len(results) > 0 {
+		if e := coreutils.PrintTable(results, "Manifest Rows", "", false); e != nil {
+			return e
+		}
+	}
+	return printBriefSummaryAndGetError(manifestCmd.SuccessCount(), manifestCmd.FailCount(), cliutils.IsFailNoOp(c), err)
+}
+
 func deletePropsCmd(c *cli.Context) error {
-	cmd, err := preparePropsCmd(c)
+	cmd, serversDetails, err := preparePropsCmd(c)
 	if err != nil {
 		return err
 	}
@@ -1598,6 +2527,9 @@ func deletePropsCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if len(serversDetails) > 1 {
+		return errorutils.CheckErrorf("the delete-props command cannot run against several servers")
+	}
 	propsCmd := generic.NewDeletePropsCommand().DeletePropsCommand(*cmd)
 	propsCmd.SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
 	err = commands.Exec(propsCmd)
@@ -1618,9 +2550,64 @@ func buildPublishCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	var sbomFormat buildsbom.Format
+	if c.IsSet("sbom") {
+		if buildInfoConfiguration.DryRun {
+			return cliutils.PrintHelpAndReturnError("The --sbom option is not supported when --dry-run is set to true.", c)
+		}
+		if sbomFormat, err = buildsbom.GetFormat(c.String("sbom")); err != nil {
+			return err
+		}
+	}
+	if (c.IsSet("provenance-key") || c.IsSet("fulcio-url") || c.IsSet("rekor-url")) && !c.Bool("provenance") {
+		return cliutils.PrintHelpAndReturnError("The --provenance-key, --fulcio-url and --rekor-url options are supported only when --provenance is set to true.", c)
+	}
+	if c.Bool("provenance") && buildInfoConfiguration.DryRun {
+		return cliutils.PrintHelpAndReturnError("The --provenance option is not supported when --dry-run is set to true.", c)
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	var provenanceCmd *provenance.ProvenanceCommand
+	if c.Bool("provenance") {
+		provenanceCmd = provenance.NewProvenanceCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetKeyPath(c.String("provenance-key")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+		if c.IsSet("fulcio-url") {
+			provenanceCmd.SetFulcioUrl(c.String("fulcio-url"))
+		}
+		if c.IsSet("rekor-url") {
+			provenanceCmd.SetRekorUrl(c.String("rekor-url"))
+		}
+		// The signer is created before the build is published, to fail before anything is deployed if the key or the OIDC token are missing.
+		if err = provenanceCmd.CreateSigner(); err != nil {
+			return err
+		}
+	}
+	// The SBOM and the provenance are created from the build-info as it's published, since the local partials of the build are deleted once it's published.
+	var publishedBuildInfo *entities.BuildInfo
+	if sbomFormat != "" || provenanceCmd != nil {
+		if publishedBuildInfo, err = buildshow.CreateBuildInfo(buildConfiguration, buildInfoConfiguration); err != nil {
+			return err
+		}
+		publishedBuildInfo.Principal = rtDetails.User
+	}
 	buildPublishCmd := buildinfo.NewBuildPublishCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(buildInfoConfiguration).SetDetailedSummary(c.Bool("detailed-summary"))
 
 	err = commands.Exec(buildPublishCmd)
+	if err == nil && publishedBuildInfo != nil {
+		// The build number is set by the publish command if the build is configured by the build config file.
+		publishedBuildInfo.Number, err = buildConfiguration.GetBuildNumber()
+	}
+	if err == nil && sbomFormat != "" {
+		err = commands.Exec(buildsbom.NewBuildSbomCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetBuildInfo(publishedBuildInfo).SetFormat(sbomFormat).SetDeploy(true).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime))
+	}
+	if err == nil && provenanceCmd != nil {
+		err = commands.Exec(provenanceCmd.SetBuildInfo(publishedBuildInfo))
+	}
 	if buildPublishCmd.IsDetailedSummary() {
 		if summary := buildPublishCmd.GetSummary(); summary != nil {
 			return cliutils.PrintBuildInfoSummaryReport(summary.IsSucceeded(), summary.GetSha256(), err)
@@ -1646,6 +2633,29 @@ func buildAppendCmd(c *cli.Context) error {
 	return commands.Exec(buildAppendCmd)
 }
 
+func buildAddUpstreamCmd(c *cli.Context) error {
+	if c.NArg() > 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	if !c.IsSet("upstream-build") {
+		return cliutils.PrintHelpAndReturnError("The --upstream-build option is mandatory.", c)
+	}
+	upstreamBuilds, err := upstream.ParseBuilds(c.String("upstream-build"))
+	if err != nil {
+		return err
+	}
+	buildConfiguration := cliutils.CreateBuildConfiguration(c)
+	if err := buildConfiguration.ValidateBuildParams(); err != nil {
+		return err
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildAddUpstreamCmd := upstream.NewBuildAddUpstreamCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetUpstreamBuilds(upstreamBuilds)
+	return commands.Exec(buildAddUpstreamCmd)
+}
+
 func buildAddDependenciesCmd(c *cli.Context) error {
 	if c.NArg() > 2 && c.IsSet("spec") {
 		return cliutils.PrintHelpAndReturnError("Only path or spec is allowed, not both.", c)
@@ -1688,6 +2698,85 @@ func buildAddDependenciesCmd(c *cli.Context) error {
 	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c), err)
 }
 
+func buildShowCmd(c *cli.Context) error {
+	if c.NArg() > 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	buildConfiguration := cliutils.CreateBuildConfiguration(c)
+	if err := buildConfiguration.ValidateBuildParams(); err != nil {
+		return err
+	}
+	format, err := commandsutils.GetFormat(c.String("format"))
+	if err != nil {
+		return err
+	}
+	buildShowCmd := buildshow.NewBuildShowCommand().SetBuildConfiguration(buildConfiguration).SetConfig(createBuildInfoConfiguration(c)).SetFormat(format)
+	return commands.Exec(buildShowCmd)
+}
+
+func buildDiffCmd(c *cli.Context) error {
+	// The second build has the same name as the first one, unless its name is provided.
+	var source, target builddiff.Build
+	switch c.NArg() {
+	case 3:
+		source = builddiff.Build{Name: c.Args().Get(0), Number: c.Args().Get(1)}
+		target = builddiff.Build{Name: c.Args().Get(0), Number: c.Args().Get(2)}
+	case 4:
+		source = builddiff.Build{Name: c.Args().Get(0), Number: c.Args().Get(1)}
+		target = builddiff.Build{Name: c.Args().Get(2), Number: c.Args().Get(3)}
+	default:
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	format, err := commandsutils.GetFormat(c.String("format"))
+	if err != nil {
+		return err
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	buildDiffCommand := builddiff.NewBuildDiffCommand().SetServerDetails(rtDetails).SetSource(source).SetTarget(target).
+		SetProject(cliutils.GetProject(c)).SetFormat(format).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	return commands.Exec(buildDiffCommand)
+}
+
+func buildSbomCmd(c *cli.Context) error {
+	if c.NArg() > 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	buildConfiguration := cliutils.CreateBuildConfiguration(c)
+	if err := buildConfiguration.ValidateBuildParams(); err != nil {
+		return err
+	}
+	format, err := buildsbom.GetFormat(c.String("format"))
+	if err != nil {
+		return err
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	buildSbomCommand := buildsbom.NewBuildSbomCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetFormat(format).
+		SetOutputPath(c.String("output")).SetDeploy(c.Bool("deploy")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	return commands.Exec(buildSbomCommand)
+}
+
 func buildCollectEnvCmd(c *cli.Context) error {
 	if c.NArg() > 2 {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
@@ -1774,6 +2863,10 @@ func buildPromoteCmd(c *cli.Context) error {
 	if err := buildConfiguration.ValidateBuildParams(); err != nil {
 		return err
 	}
+	if c.Bool("include-upstream") {
+		buildPromoteChainCmd := upstream.NewBuildPromoteChainCommand().SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetPromotionParams(configuration).SetBuildConfiguration(buildConfiguration)
+		return commands.Exec(buildPromoteChainCmd)
+	}
 	buildPromotionCmd := buildinfo.NewBuildPromotionCommand().SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetPromotionParams(configuration).SetBuildConfiguration(buildConfiguration)
 	return commands.Exec(buildPromotionCmd)
 }
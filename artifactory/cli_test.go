@@ -94,7 +94,7 @@ func TestPreparePropsCmd(t *testing.T) {
 	for _, test := range testRuns {
 		t.Run(test.name, func(t *testing.T) {
 			context, buffer := tests.CreateContext(t, test.flags, test.args)
-			propsCommand, err := preparePropsCmd(context)
+			propsCommand, _, err := preparePropsCmd(context)
 			var actualSpec *spec.SpecFiles
 			if propsCommand != nil {
 				actualSpec = propsCommand.Spec()
@@ -0,0 +1,154 @@
+package builddiff
+
+import (
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/diff"
+	commandsutils "github.com/jfrog/jfrog-cli/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A published build. The number may also be LATEST, for the latest build with the name.
+type Build struct {
+	Name   string
+	Number string
+}
+
+func (b Build) String() string {
+	return b.Name + "/" + b.Number
+}
+
+// Compares the build-infos of two published builds, and lists the modules, artifacts, dependencies,
+// environment variables and VCS revisions which changed between them.
+type BuildDiffCommand struct {
+	serverDetails      *config.ServerDetails
+	source             Build
+	target             Build
+	project            string
+	format             commandsutils.Format
+	retries            int
+	retryWaitMilliSecs int
+	buildDiff          *BuildDiff
+}
+
+func NewBuildDiffCommand() *BuildDiffCommand {
+	return &BuildDiffCommand{format: commandsutils.Table}
+}
+
+func (bdc *BuildDiffCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildDiffCommand {
+	bdc.serverDetails = serverDetails
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetSource(source Build) *BuildDiffCommand {
+	bdc.source = source
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetTarget(target Build) *BuildDiffCommand {
+	bdc.target = target
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetProject(project string) *BuildDiffCommand {
+	bdc.project = project
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetFormat(format commandsutils.Format) *BuildDiffCommand {
+	bdc.format = format
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetRetries(retries int) *BuildDiffCommand {
+	bdc.retries = retries
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *BuildDiffCommand {
+	bdc.retryWaitMilliSecs = retryWaitMilliSecs
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) BuildDiff() *BuildDiff {
+	return bdc.buildDiff
+}
+
+func (bdc *BuildDiffCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bdc.serverDetails, nil
+}
+
+func (bdc *BuildDiffCommand) CommandName() string {
+	return "rt_build_diff"
+}
+
+func (bdc *BuildDiffCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(bdc.serverDetails, bdc.retries, bdc.retryWaitMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	source, err := bdc.getBuildInfo(servicesManager, bdc.source)
+	if err != nil {
+		return err
+	}
+	target, err := bdc.getBuildInfo(servicesManager, bdc.target)
+	if err != nil {
+		return err
+	}
+	bdc.buildDiff = compareBuilds(source, target)
+	return commandsutils.Print(bdc.format, bdc.buildDiff, func() error {
+		return coreutils.PrintTable(bdc.buildDiff.rows(), "Differences between build "+bdc.buildDiff.Source+" and build "+bdc.buildDiff.Target,
+			"No differences were found", false)
+	})
+}
+
+func (bdc *BuildDiffCommand) getBuildInfo(servicesManager artifactory.ArtifactoryServicesManager, build Build) (*buildinfo.BuildInfo, error) {
+	log.Info("Fetching the build info of", build.String()+"...")
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: build.Name, BuildNumber: build.Number, ProjectKey: bdc.project})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckErrorf("build %s was not found in Artifactory", build)
+	}
+	return &publishedBuildInfo.BuildInfo, nil
+}
+
+// A row of the table output. The changes of each module are grouped under the module's row.
+type row struct {
+	Module string      `col-name:"Module"`
+	Kind   string      `col-name:"Kind"`
+	Name   string      `col-name:"Name"`
+	Status diff.Status `col-name:"Status"`
+	Source string      `col-name:"Source"`
+	Target string      `col-name:"Target"`
+}
+
+func (bd *BuildDiff) rows() []row {
+	var rows []row
+	for _, module := range bd.Modules {
+		rows = append(rows, row{Module: module.Id, Kind: "module", Status: module.Status, Source: module.Source, Target: module.Target})
+		for _, change := range module.Artifacts {
+			rows = append(rows, changeRow(module.Id, "artifact", change))
+		}
+		for _, change := range module.Dependencies {
+			rows = append(rows, changeRow(module.Id, "dependency", change))
+		}
+	}
+	for _, change := range bd.Env {
+		rows = append(rows, changeRow("", "env", change))
+	}
+	for _, change := range bd.Vcs {
+		rows = append(rows, changeRow("", "vcs", change))
+	}
+	return rows
+}
+
+func changeRow(module, kind string, change Change) row {
+	return row{Module: module, Kind: kind, Name: change.Name, Status: change.Status, Source: change.Source, Target: change.Target}
+}
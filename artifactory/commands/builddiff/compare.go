@@ -0,0 +1,284 @@
+package builddiff
+
+import (
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/gofrog/version"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/diff"
+)
+
+// A module or a dependency whose version changed between the builds. Modules and dependencies are matched by their IDs
+// without the versions.
+const VersionChanged diff.Status = "version-changed"
+
+// The differences between two build-infos. Unchanged modules and items are omitted.
+type BuildDiff struct {
+	Source  string       `json:"source"`
+	Target  string       `json:"target"`
+	Modules []ModuleDiff `json:"modules"`
+	Env     []Change     `json:"env,omitempty"`
+	Vcs     []Change     `json:"vcs,omitempty"`
+}
+
+// The differences in the artifacts and dependencies of a module.
+// All the artifacts and dependencies of an added or removed module are listed as added or removed.
+// Modules are matched by their IDs without the versions. The source and target values are the versions of a module
+// whose version changed.
+type ModuleDiff struct {
+	Id           string      `json:"id"`
+	Status       diff.Status `json:"status"`
+	Source       string      `json:"source,omitempty"`
+	Target       string      `json:"target,omitempty"`
+	Artifacts    []Change    `json:"artifacts,omitempty"`
+	Dependencies []Change    `json:"dependencies,omitempty"`
+}
+
+// A single item which was added, removed or changed.
+// The source and target values are the versions of dependencies whose version changed, the revisions of VCS entries,
+// the values of environment variables, and the SHA-1 checksums of any other artifact or dependency.
+type Change struct {
+	Name   string      `json:"name"`
+	Status diff.Status `json:"status"`
+	Source string      `json:"source,omitempty"`
+	Target string      `json:"target,omitempty"`
+}
+
+func compareBuilds(source, target *buildinfo.BuildInfo) *BuildDiff {
+	buildDiff := &BuildDiff{
+		Source:  source.Name + "/" + source.Number,
+		Target:  target.Name + "/" + target.Number,
+		Modules: compareModules(source.Modules, target.Modules),
+		Env:     compareValues(envValues(source.Properties), envValues(target.Properties)),
+		Vcs:     compareValues(vcsRevisions(source.VcsList), vcsRevisions(target.VcsList)),
+	}
+	if buildDiff.Modules == nil {
+		buildDiff.Modules = []ModuleDiff{}
+	}
+	return buildDiff
+}
+
+// Compares the modules by their IDs without the versions, like the dependencies are compared, so that a new version
+// of a module is reported as a version change rather than as a removed and an added module.
+func compareModules(source, target []buildinfo.Module) []ModuleDiff {
+	sourceVersions, targetVersions := mapModuleVersions(source), mapModuleVersions(target)
+	var moduleDiffs []ModuleDiff
+	for name, sourceModules := range sourceVersions {
+		targetModules := targetVersions[name]
+		var removed, added []string
+		for version, sourceModule := range sourceModules {
+			targetModule, exists := targetModules[version]
+			if !exists {
+				removed = append(removed, version)
+				continue
+			}
+			moduleDiff := compareModule(joinDependencyId(name, version), diff.Changed, sourceModule, targetModule)
+			if len(moduleDiff.Artifacts) > 0 || len(moduleDiff.Dependencies) > 0 {
+				moduleDiffs = append(moduleDiffs, moduleDiff)
+			}
+		}
+		for version := range targetModules {
+			if _, exists := sourceModules[version]; !exists {
+				added = append(added, version)
+			}
+		}
+		sortVersions(removed)
+		sortVersions(added)
+		for len(removed) > 0 && len(added) > 0 {
+			moduleDiff := compareModule(name, VersionChanged, sourceModules[removed[0]], targetModules[added[0]])
+			moduleDiff.Source, moduleDiff.Target = removed[0], added[0]
+			moduleDiffs = append(moduleDiffs, moduleDiff)
+			removed, added = removed[1:], added[1:]
+		}
+		for _, version := range removed {
+			moduleDiffs = append(moduleDiffs, compareModule(joinDependencyId(name, version), diff.Removed, sourceModules[version], &buildinfo.Module{}))
+		}
+		for _, version := range added {
+			moduleDiffs = append(moduleDiffs, compareModule(joinDependencyId(name, version), diff.Added, &buildinfo.Module{}, targetModules[version]))
+		}
+	}
+	for name, targetModules := range targetVersions {
+		if _, exists := sourceVersions[name]; exists {
+			continue
+		}
+		for version, targetModule := range targetModules {
+			moduleDiffs = append(moduleDiffs, compareModule(joinDependencyId(name, version), diff.Added, &buildinfo.Module{}, targetModule))
+		}
+	}
+	sort.Slice(moduleDiffs, func(i, j int) bool {
+		if moduleDiffs[i].Id != moduleDiffs[j].Id {
+			return moduleDiffs[i].Id < moduleDiffs[j].Id
+		}
+		return moduleDiffs[i].Source < moduleDiffs[j].Source
+	})
+	return moduleDiffs
+}
+
+func compareModule(id string, status diff.Status, source, target *buildinfo.Module) ModuleDiff {
+	return ModuleDiff{
+		Id:           id,
+		Status:       status,
+		Artifacts:    compareValues(artifactChecksums(source.Artifacts), artifactChecksums(target.Artifacts)),
+		Dependencies: compareDependencies(source.Dependencies, target.Dependencies),
+	}
+}
+
+// Maps the modules by their IDs without the versions, and then by their versions.
+func mapModuleVersions(modules []buildinfo.Module) map[string]map[string]*buildinfo.Module {
+	versions := make(map[string]map[string]*buildinfo.Module)
+	for i := range modules {
+		name, version := splitDependencyId(modules[i].Id)
+		if versions[name] == nil {
+			versions[name] = make(map[string]*buildinfo.Module)
+		}
+		versions[name][version] = &modules[i]
+	}
+	return versions
+}
+
+// Compares values mapped by their names. A value which exists on both sides but differs is changed.
+func compareValues(source, target map[string]string) []Change {
+	var changes []Change
+	for name, sourceValue := range source {
+		targetValue, exists := target[name]
+		switch {
+		case !exists:
+			changes = append(changes, Change{Name: name, Status: diff.Removed, Source: sourceValue})
+		case sourceValue != targetValue:
+			changes = append(changes, Change{Name: name, Status: diff.Changed, Source: sourceValue, Target: targetValue})
+		}
+	}
+	for name, targetValue := range target {
+		if _, exists := source[name]; !exists {
+			changes = append(changes, Change{Name: name, Status: diff.Added, Target: targetValue})
+		}
+	}
+	sortChanges(changes)
+	return changes
+}
+
+// Compares the dependencies by their IDs without the versions, so that a new version of a dependency is reported
+// as a version change rather than as a removed and an added dependency.
+// If several versions of the same dependency were removed and added, they are paired in ascending version order.
+func compareDependencies(source, target []buildinfo.Dependency) []Change {
+	sourceVersions, targetVersions := mapDependencyVersions(source), mapDependencyVersions(target)
+	var changes []Change
+	for name, sourceChecksums := range sourceVersions {
+		targetChecksums := targetVersions[name]
+		var removed, added []string
+		for version, sourceChecksum := range sourceChecksums {
+			targetChecksum, exists := targetChecksums[version]
+			switch {
+			case !exists:
+				removed = append(removed, version)
+			case sourceChecksum != targetChecksum:
+				changes = append(changes, Change{Name: joinDependencyId(name, version), Status: diff.Changed, Source: sourceChecksum, Target: targetChecksum})
+			}
+		}
+		for version := range targetChecksums {
+			if _, exists := sourceChecksums[version]; !exists {
+				added = append(added, version)
+			}
+		}
+		sortVersions(removed)
+		sortVersions(added)
+		for len(removed) > 0 && len(added) > 0 {
+			changes = append(changes, Change{Name: name, Status: VersionChanged, Source: removed[0], Target: added[0]})
+			removed, added = removed[1:], added[1:]
+		}
+		for _, version := range removed {
+			changes = append(changes, Change{Name: joinDependencyId(name, version), Status: diff.Removed, Source: sourceChecksums[version]})
+		}
+		for _, version := range added {
+			changes = append(changes, Change{Name: joinDependencyId(name, version), Status: diff.Added, Target: targetChecksums[version]})
+		}
+	}
+	for name, targetChecksums := range targetVersions {
+		if _, exists := sourceVersions[name]; exists {
+			continue
+		}
+		for version, targetChecksum := range targetChecksums {
+			changes = append(changes, Change{Name: joinDependencyId(name, version), Status: diff.Added, Target: targetChecksum})
+		}
+	}
+	sortChanges(changes)
+	return changes
+}
+
+// Maps the SHA-1 checksums of the dependencies by their IDs without the versions, and then by their versions.
+func mapDependencyVersions(dependencies []buildinfo.Dependency) map[string]map[string]string {
+	versions := make(map[string]map[string]string)
+	for _, dependency := range dependencies {
+		name, version := splitDependencyId(dependency.Id)
+		if versions[name] == nil {
+			versions[name] = make(map[string]string)
+		}
+		versions[name][version] = dependency.Sha1
+	}
+	return versions
+}
+
+// Splits a dependency ID to its name and version. The version follows the last colon,
+// like in 'org.slf4j:slf4j-api:2.0.7', 'lodash:4.17.21' and 'github.com/jfrog/gofrog:v1.3.0'.
+// IDs without a colon, such as the file names of generic dependencies, have no version.
+func splitDependencyId(id string) (name, version string) {
+	separatorIndex := strings.LastIndex(id, ":")
+	if separatorIndex < 0 {
+		return id, ""
+	}
+	return id[:separatorIndex], id[separatorIndex+1:]
+}
+
+// Sorts the versions in ascending order. Their numeric parts are compared as numbers, so that 1.9 precedes 1.10.
+func sortVersions(versions []string) {
+	sort.Slice(versions, func(i, j int) bool {
+		if compare := version.NewVersion(versions[i]).Compare(versions[j]); compare != 0 {
+			return compare > 0
+		}
+		return versions[i] < versions[j]
+	})
+}
+
+func joinDependencyId(name, version string) string {
+	if version == "" {
+		return name
+	}
+	return name + ":" + version
+}
+
+// Maps the SHA-1 checksums of the artifacts by their names, like 'rt diff' compares build artifacts,
+// since artifacts are usually deployed to paths containing the build number or version.
+func artifactChecksums(artifacts []buildinfo.Artifact) map[string]string {
+	checksums := make(map[string]string, len(artifacts))
+	for _, artifact := range artifacts {
+		name := artifact.Name
+		if name == "" {
+			name = artifact.Path
+		}
+		checksums[name] = artifact.Sha1
+	}
+	return checksums
+}
+
+func envValues(properties buildinfo.Env) map[string]string {
+	values := make(map[string]string, len(properties))
+	for key, value := range properties {
+		values[strings.TrimPrefix(key, buildinfo.BuildInfoEnvPrefix)] = value
+	}
+	return values
+}
+
+func vcsRevisions(vcsList []buildinfo.Vcs) map[string]string {
+	revisions := make(map[string]string, len(vcsList))
+	for _, vcs := range vcsList {
+		revisions[vcs.Url] = vcs.Revision
+	}
+	return revisions
+}
+
+func sortChanges(changes []Change) {
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+}
@@ -0,0 +1,122 @@
+package builddiff
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/diff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareBuilds(t *testing.T) {
+	source := &buildinfo.BuildInfo{
+		Name:       "app",
+		Number:     "1",
+		Properties: buildinfo.Env{"buildInfo.env.STAGE": "test", "buildInfo.env.OLD": "1"},
+		VcsList:    []buildinfo.Vcs{{Url: "https://github.com/org/app.git", Revision: "abc"}},
+		Modules: []buildinfo.Module{
+			{
+				Id:        "app",
+				Artifacts: []buildinfo.Artifact{{Name: "app.jar", Path: "libs/1/app.jar", Checksum: buildinfo.Checksum{Sha1: "1"}}},
+				Dependencies: []buildinfo.Dependency{
+					{Id: "org.slf4j:slf4j-api:2.0.6", Checksum: buildinfo.Checksum{Sha1: "2"}},
+					{Id: "lodash:4.17.21", Checksum: buildinfo.Checksum{Sha1: "3"}},
+					{Id: "removed:1.0", Checksum: buildinfo.Checksum{Sha1: "4"}},
+					{Id: "same:1.0", Checksum: buildinfo.Checksum{Sha1: "5"}},
+				},
+			},
+			{Id: "removed-module", Artifacts: []buildinfo.Artifact{{Name: "old.jar", Checksum: buildinfo.Checksum{Sha1: "6"}}}},
+			{Id: "same-module", Dependencies: []buildinfo.Dependency{{Id: "same:1.0", Checksum: buildinfo.Checksum{Sha1: "5"}}}},
+		},
+	}
+	target := &buildinfo.BuildInfo{
+		Name:       "app",
+		Number:     "2",
+		Properties: buildinfo.Env{"buildInfo.env.STAGE": "prod", "buildInfo.env.NEW": "2"},
+		VcsList:    []buildinfo.Vcs{{Url: "https://github.com/org/app.git", Revision: "def"}},
+		Modules: []buildinfo.Module{
+			{
+				Id:        "app",
+				Artifacts: []buildinfo.Artifact{{Name: "app.jar", Path: "libs/2/app.jar", Checksum: buildinfo.Checksum{Sha1: "7"}}},
+				Dependencies: []buildinfo.Dependency{
+					{Id: "org.slf4j:slf4j-api:2.0.7", Checksum: buildinfo.Checksum{Sha1: "8"}},
+					{Id: "lodash:4.17.21", Checksum: buildinfo.Checksum{Sha1: "9"}},
+					{Id: "added.zip", Checksum: buildinfo.Checksum{Sha1: "10"}},
+					{Id: "same:1.0", Checksum: buildinfo.Checksum{Sha1: "5"}},
+				},
+			},
+			{Id: "added-module", Artifacts: []buildinfo.Artifact{{Name: "new.jar", Checksum: buildinfo.Checksum{Sha1: "11"}}}},
+			{Id: "same-module", Dependencies: []buildinfo.Dependency{{Id: "same:1.0", Checksum: buildinfo.Checksum{Sha1: "5"}}}},
+		},
+	}
+
+	buildDiff := compareBuilds(source, target)
+	assert.Equal(t, "app/1", buildDiff.Source)
+	assert.Equal(t, "app/2", buildDiff.Target)
+	assert.Equal(t, []ModuleDiff{
+		{Id: "added-module", Status: diff.Added, Artifacts: []Change{{Name: "new.jar", Status: diff.Added, Target: "11"}}},
+		{
+			Id:        "app",
+			Status:    diff.Changed,
+			Artifacts: []Change{{Name: "app.jar", Status: diff.Changed, Source: "1", Target: "7"}},
+			Dependencies: []Change{
+				{Name: "added.zip", Status: diff.Added, Target: "10"},
+				{Name: "lodash:4.17.21", Status: diff.Changed, Source: "3", Target: "9"},
+				{Name: "org.slf4j:slf4j-api", Status: VersionChanged, Source: "2.0.6", Target: "2.0.7"},
+				{Name: "removed:1.0", Status: diff.Removed, Source: "4"},
+			},
+		},
+		{Id: "removed-module", Status: diff.Removed, Artifacts: []Change{{Name: "old.jar", Status: diff.Removed, Source: "6"}}},
+	}, buildDiff.Modules)
+	assert.Equal(t, []Change{
+		{Name: "NEW", Status: diff.Added, Target: "2"},
+		{Name: "OLD", Status: diff.Removed, Source: "1"},
+		{Name: "STAGE", Status: diff.Changed, Source: "test", Target: "prod"},
+	}, buildDiff.Env)
+	assert.Equal(t, []Change{{Name: "https://github.com/org/app.git", Status: diff.Changed, Source: "abc", Target: "def"}}, buildDiff.Vcs)
+
+	rows := buildDiff.rows()
+	assert.Len(t, rows, 14)
+	assert.Equal(t, row{Module: "app", Kind: "dependency", Name: "org.slf4j:slf4j-api", Status: VersionChanged, Source: "2.0.6", Target: "2.0.7"}, rows[6])
+
+	assert.Equal(t, &BuildDiff{Source: "app/1", Target: "app/1", Modules: []ModuleDiff{}}, compareBuilds(source, source))
+}
+
+func TestCompareDependenciesSeveralVersions(t *testing.T) {
+	source := []buildinfo.Dependency{{Id: "lib:1.0", Checksum: buildinfo.Checksum{Sha1: "1"}}, {Id: "lib:2.0", Checksum: buildinfo.Checksum{Sha1: "2"}}}
+	target := []buildinfo.Dependency{{Id: "lib:1.1", Checksum: buildinfo.Checksum{Sha1: "3"}}}
+	assert.Equal(t, []Change{
+		{Name: "lib", Status: VersionChanged, Source: "1.0", Target: "1.1"},
+		{Name: "lib:2.0", Status: diff.Removed, Source: "2"},
+	}, compareDependencies(source, target))
+}
+
+func TestCompareDependenciesVersionOrder(t *testing.T) {
+	source := []buildinfo.Dependency{{Id: "lib:1.9", Checksum: buildinfo.Checksum{Sha1: "1"}}, {Id: "lib:1.10", Checksum: buildinfo.Checksum{Sha1: "2"}}}
+	target := []buildinfo.Dependency{{Id: "lib:1.11", Checksum: buildinfo.Checksum{Sha1: "3"}}}
+	assert.Equal(t, []Change{
+		{Name: "lib", Status: VersionChanged, Source: "1.9", Target: "1.11"},
+		{Name: "lib:1.10", Status: diff.Removed, Source: "2"},
+	}, compareDependencies(source, target))
+}
+
+func TestCompareModulesVersionChanged(t *testing.T) {
+	source := []buildinfo.Module{
+		{Id: "org:app:1.9", Artifacts: []buildinfo.Artifact{{Name: "app-1.9.jar", Checksum: buildinfo.Checksum{Sha1: "1"}}}},
+		{Id: "org:same:1.0", Dependencies: []buildinfo.Dependency{{Id: "lib:1.0", Checksum: buildinfo.Checksum{Sha1: "2"}}}},
+	}
+	target := []buildinfo.Module{
+		{Id: "org:app:1.10", Artifacts: []buildinfo.Artifact{{Name: "app-1.10.jar", Checksum: buildinfo.Checksum{Sha1: "3"}}}},
+		{Id: "org:same:1.0", Dependencies: []buildinfo.Dependency{{Id: "lib:1.0", Checksum: buildinfo.Checksum{Sha1: "2"}}}},
+	}
+	assert.Equal(t, []ModuleDiff{{
+		Id:     "org:app",
+		Status: VersionChanged,
+		Source: "1.9",
+		Target: "1.10",
+		Artifacts: []Change{
+			{Name: "app-1.10.jar", Status: diff.Added, Target: "3"},
+			{Name: "app-1.9.jar", Status: diff.Removed, Source: "1"},
+		},
+	}}, compareModules(source, target))
+}
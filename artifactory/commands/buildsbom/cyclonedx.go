@@ -0,0 +1,166 @@
+package buildsbom
+
+import (
+	"strconv"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+)
+
+const cycloneDxSpecVersion = "1.5"
+
+// A CycloneDX 1.5 document, with the fields which can be populated from a build-info.
+// See https://cyclonedx.org/docs/1.5/json
+type cycloneDxBom struct {
+	BomFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	SerialNumber string                `json:"serialNumber"`
+	Version      int                   `json:"version"`
+	Metadata     cycloneDxMetadata     `json:"metadata"`
+	Components   []cycloneDxComponent  `json:"components"`
+	Dependencies []cycloneDxDependency `json:"dependencies"`
+}
+
+type cycloneDxMetadata struct {
+	Timestamp string             `json:"timestamp"`
+	Tools     cycloneDxTools     `json:"tools"`
+	Component cycloneDxComponent `json:"component"`
+}
+
+type cycloneDxTools struct {
+	Components []cycloneDxComponent `json:"components"`
+}
+
+type cycloneDxComponent struct {
+	Type       string               `json:"type"`
+	BomRef     string               `json:"bom-ref,omitempty"`
+	Group      string               `json:"group,omitempty"`
+	Name       string               `json:"name"`
+	Version    string               `json:"version,omitempty"`
+	Scope      string               `json:"scope,omitempty"`
+	Hashes     []cycloneDxHash      `json:"hashes,omitempty"`
+	Purl       string               `json:"purl,omitempty"`
+	Properties []cycloneDxProperty  `json:"properties,omitempty"`
+	Components []cycloneDxComponent `json:"components,omitempty"`
+}
+
+type cycloneDxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cycloneDxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cycloneDxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// Converts the build-info to a CycloneDX document. The build is the document's main component, and each module is
+// an application component, containing its artifacts as file components. The dependencies are library components,
+// and the modules' dependency graphs are built from the requestedBy chains of the dependencies.
+func newCycloneDxBom(buildInfo *buildinfo.BuildInfo, serialNumber string, timestamp time.Time) *cycloneDxBom {
+	graph := newDependencyGraph(buildInfo)
+	bom := &cycloneDxBom{
+		BomFormat:    "CycloneDX",
+		SpecVersion:  cycloneDxSpecVersion,
+		SerialNumber: "urn:uuid:" + serialNumber,
+		Version:      1,
+		Metadata: cycloneDxMetadata{
+			Timestamp: timestamp.UTC().Format(time.RFC3339),
+			Tools: cycloneDxTools{Components: []cycloneDxComponent{
+				{Type: "application", Group: "jfrog", Name: coreutils.GetCliUserAgentName(), Version: coreutils.GetCliUserAgentVersion()},
+			}},
+			Component: cycloneDxComponent{Type: "application", BomRef: graph.buildRef, Name: buildInfo.Name, Version: buildInfo.Number},
+		},
+		Components:   []cycloneDxComponent{},
+		Dependencies: []cycloneDxDependency{},
+	}
+	for _, module := range graph.modules {
+		component := cycloneDxComponent{Type: "application", BomRef: module.ref, Name: module.Id, Properties: []cycloneDxProperty{{Name: "jfrog:module:type", Value: string(module.Type)}}}
+		artifactRefs := createArtifactRefs(module)
+		for i, artifact := range module.Artifacts {
+			component.Components = append(component.Components, cycloneDxComponent{
+				Type:   "file",
+				BomRef: artifactRefs[i],
+				Name:   artifactName(artifact),
+				Hashes: cycloneDxHashes(artifact.Checksum),
+			})
+		}
+		bom.Components = append(bom.Components, component)
+	}
+	for _, dependency := range graph.dependencies {
+		component := cycloneDxComponent{
+			Type:    "library",
+			BomRef:  dependency.ref,
+			Group:   dependency.group,
+			Name:    dependency.name,
+			Version: dependency.version,
+			Hashes:  cycloneDxHashes(dependency.Checksum),
+			Purl:    dependency.purl,
+		}
+		if isOptionalScope(dependency.Scopes) {
+			component.Scope = "optional"
+		}
+		bom.Components = append(bom.Components, component)
+	}
+	for _, ref := range graph.refs() {
+		bom.Dependencies = append(bom.Dependencies, cycloneDxDependency{Ref: ref, DependsOn: graph.dependsOn(ref)})
+	}
+	return bom
+}
+
+// Returns the bom-refs of the module's artifacts, which should be unique in the document. Artifacts are referenced by
+// their paths under the module's ref, since artifacts of different types or classifiers may share their names.
+// Artifacts whose path is shared by another artifact of the module are told apart by their index in the module.
+func createArtifactRefs(module graphModule) []string {
+	refs := make([]string, len(module.Artifacts))
+	counts := make(map[string]int)
+	for i, artifact := range module.Artifacts {
+		artifactPath := artifact.Path
+		if artifactPath == "" {
+			artifactPath = artifactName(artifact)
+		}
+		refs[i] = module.ref + "/" + artifactPath
+		counts[refs[i]]++
+	}
+	for i := range refs {
+		if counts[refs[i]] > 1 {
+			refs[i] += "#" + strconv.Itoa(i+1)
+		}
+	}
+	return refs
+}
+
+func cycloneDxHashes(checksum buildinfo.Checksum) []cycloneDxHash {
+	var hashes []cycloneDxHash
+	if checksum.Sha1 != "" {
+		hashes = append(hashes, cycloneDxHash{Alg: "SHA-1", Content: checksum.Sha1})
+	}
+	if checksum.Md5 != "" {
+		hashes = append(hashes, cycloneDxHash{Alg: "MD5", Content: checksum.Md5})
+	}
+	if checksum.Sha256 != "" {
+		hashes = append(hashes, cycloneDxHash{Alg: "SHA-256", Content: checksum.Sha256})
+	}
+	return hashes
+}
+
+// Dependencies which are used only for testing or development aren't required at runtime.
+func isOptionalScope(scopes []string) bool {
+	if len(scopes) == 0 {
+		return false
+	}
+	for _, scope := range scopes {
+		switch scope {
+		case "test", "dev", "development", "provided":
+		default:
+			return false
+		}
+	}
+	return true
+}
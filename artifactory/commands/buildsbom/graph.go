@@ -0,0 +1,166 @@
+package buildsbom
+
+import (
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+)
+
+// The build's modules and dependencies, and the dependency relations between them, shared by both SBOM formats.
+// Every component is identified by a reference, which is unique in the document.
+type dependencyGraph struct {
+	buildRef string
+	modules  []graphModule
+	// The dependencies of all the modules. A dependency used by several modules appears once.
+	dependencies []graphDependency
+	edges        map[string]map[string]bool
+}
+
+type graphModule struct {
+	*buildinfo.Module
+	ref string
+}
+
+type graphDependency struct {
+	*buildinfo.Dependency
+	ref     string
+	group   string
+	name    string
+	version string
+	purl    string
+}
+
+// The build depends on its modules, and each module depends on its direct dependencies.
+// Transitive dependencies are attached to the dependency which requested them, according to the first element of each
+// of their requestedBy chains.
+func newDependencyGraph(buildInfo *buildinfo.BuildInfo) *dependencyGraph {
+	graph := &dependencyGraph{buildRef: "build:" + buildInfo.Name + "/" + buildInfo.Number, edges: make(map[string]map[string]bool)}
+	graph.addEdge(graph.buildRef, "")
+	dependencyRefs := make(map[string]bool)
+	for i := range buildInfo.Modules {
+		module := graphModule{Module: &buildInfo.Modules[i], ref: "module:" + buildInfo.Modules[i].Id}
+		graph.modules = append(graph.modules, module)
+		graph.addEdge(graph.buildRef, module.ref)
+		graph.addEdge(module.ref, "")
+
+		moduleDependencies := make(map[string]bool, len(module.Dependencies))
+		for _, dependency := range module.Dependencies {
+			moduleDependencies[dependency.Id] = true
+		}
+		for j := range module.Dependencies {
+			dependency := &module.Dependencies[j]
+			ref := dependencyRef(dependency.Id)
+			if !dependencyRefs[ref] {
+				dependencyRefs[ref] = true
+				group, name, version := parseDependencyId(dependency.Id, module.Type)
+				graph.dependencies = append(graph.dependencies, graphDependency{Dependency: dependency, ref: ref, group: group, name: name,
+					version: version, purl: createPurl(module.Type, group, name, version)})
+				graph.addEdge(ref, "")
+			}
+			if len(dependency.RequestedBy) == 0 {
+				graph.addEdge(module.ref, ref)
+			}
+			for _, chain := range dependency.RequestedBy {
+				if len(chain) == 0 || !moduleDependencies[chain[0]] {
+					graph.addEdge(module.ref, ref)
+					continue
+				}
+				graph.addEdge(dependencyRef(chain[0]), ref)
+			}
+		}
+	}
+	sort.Slice(graph.dependencies, func(i, j int) bool {
+		return graph.dependencies[i].ref < graph.dependencies[j].ref
+	})
+	return graph
+}
+
+func dependencyRef(id string) string {
+	return "dependency:" + id
+}
+
+// Adds the edge, or only the source reference if the target is empty.
+func (graph *dependencyGraph) addEdge(ref, dependsOn string) {
+	if graph.edges[ref] == nil {
+		graph.edges[ref] = make(map[string]bool)
+	}
+	if dependsOn != "" {
+		graph.edges[ref][dependsOn] = true
+	}
+}
+
+// Returns the references of all the components in the graph, sorted.
+func (graph *dependencyGraph) refs() []string {
+	refs := make([]string, 0, len(graph.edges))
+	for ref := range graph.edges {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// Returns the references of the components which the component depends on directly, sorted.
+func (graph *dependencyGraph) dependsOn(ref string) []string {
+	dependsOn := []string{}
+	for dependencyRef := range graph.edges[ref] {
+		dependsOn = append(dependsOn, dependencyRef)
+	}
+	sort.Strings(dependsOn)
+	return dependsOn
+}
+
+// Splits a dependency ID to its group, name and version. Maven and Gradle IDs have the form group:name:version,
+// and the IDs of other package managers have the form name:version. IDs without a colon, such as the file names of
+// generic dependencies, are names without a version.
+func parseDependencyId(id string, moduleType buildinfo.ModuleType) (group, name, version string) {
+	if moduleType == buildinfo.Maven || moduleType == buildinfo.Gradle {
+		if parts := strings.Split(id, ":"); len(parts) >= 3 {
+			return parts[0], parts[1], parts[len(parts)-1]
+		}
+	}
+	separatorIndex := strings.LastIndex(id, ":")
+	if separatorIndex < 0 {
+		return "", id, ""
+	}
+	return "", id[:separatorIndex], id[separatorIndex+1:]
+}
+
+// Returns the package URL of a dependency, by the package manager of its module.
+// See https://github.com/package-url/purl-spec
+func createPurl(moduleType buildinfo.ModuleType, group, name, version string) string {
+	if version == "" {
+		return ""
+	}
+	switch moduleType {
+	case buildinfo.Maven, buildinfo.Gradle:
+		if group == "" {
+			return ""
+		}
+		return "pkg:maven/" + group + "/" + name + "@" + version
+	case buildinfo.Npm:
+		return "pkg:npm/" + strings.Replace(name, "@", "%40", 1) + "@" + version
+	case buildinfo.Go:
+		return "pkg:golang/" + name + "@" + version
+	case buildinfo.Python:
+		return "pkg:pypi/" + strings.ToLower(name) + "@" + version
+	case buildinfo.Nuget:
+		return "pkg:nuget/" + name + "@" + version
+	default:
+		return ""
+	}
+}
+
+func artifactName(artifact buildinfo.Artifact) string {
+	if artifact.Name != "" {
+		return artifact.Name
+	}
+	return artifact.Path
+}
+
+// Returns the package URL of a dependency of a module of the given type, or an empty string if the package manager
+// of the module has no package URL type.
+func DependencyPurl(id string, moduleType buildinfo.ModuleType) string {
+	group, name, version := parseDependencyId(id, moduleType)
+	return createPurl(moduleType, group, name, version)
+}
@@ -0,0 +1,277 @@
+package buildsbom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type Format string
+
+const (
+	CycloneDx Format = "cyclonedx"
+	Spdx      Format = "spdx"
+)
+
+func GetFormat(format string) (Format, error) {
+	switch Format(format) {
+	case CycloneDx, Spdx:
+		return Format(format), nil
+	case "":
+		return CycloneDx, nil
+	default:
+		return "", errorutils.CheckErrorf("unsupported SBOM format '%s'. Possible values are: %s and %s", format, CycloneDx, Spdx)
+	}
+}
+
+// Returns the name of the SBOM file of the build, with the extension recommended by the format.
+func (f Format) fileName(buildName, buildNumber string) string {
+	extension := ".cdx.json"
+	if f == Spdx {
+		extension = ".spdx.json"
+	}
+	return strings.ReplaceAll(buildName+"-"+buildNumber, "/", "-") + extension
+}
+
+// Converts the build-info to an SBOM document in the given format.
+func CreateSbom(buildInfo *buildinfo.BuildInfo, format Format) ([]byte, error) {
+	var document interface{}
+	if format == Spdx {
+		document = newSpdxDocument(buildInfo, uuid.NewString(), time.Now())
+	} else {
+		document = newCycloneDxBom(buildInfo, uuid.NewString(), time.Now())
+	}
+	content, err := json.MarshalIndent(document, "", "  ")
+	return content, errorutils.CheckError(err)
+}
+
+// Creates the SBOM of a published build. The SBOM is saved to a file, deployed to Artifactory next to the build
+// artifacts, or printed if neither was requested.
+type BuildSbomCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *utils.BuildConfiguration
+	buildInfo          *buildinfo.BuildInfo
+	format             Format
+	outputPath         string
+	deploy             bool
+	retries            int
+	retryWaitMilliSecs int
+}
+
+func NewBuildSbomCommand() *BuildSbomCommand {
+	return &BuildSbomCommand{format: CycloneDx}
+}
+
+func (bsc *BuildSbomCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildSbomCommand {
+	bsc.serverDetails = serverDetails
+	return bsc
+}
+
+func (bsc *BuildSbomCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *BuildSbomCommand {
+	bsc.buildConfiguration = buildConfiguration
+	return bsc
+}
+
+// Creates the SBOM of this build-info, instead of the build-info published to Artifactory.
+func (bsc *BuildSbomCommand) SetBuildInfo(buildInfo *buildinfo.BuildInfo) *BuildSbomCommand {
+	bsc.buildInfo = buildInfo
+	return bsc
+}
+
+func (bsc *BuildSbomCommand) SetFormat(format Format) *BuildSbomCommand {
+	bsc.format = format
+	return bsc
+}
+
+// Saves the SBOM to this local file.
+func (bsc *BuildSbomCommand) SetOutputPath(outputPath string) *BuildSbomCommand {
+	bsc.outputPath = outputPath
+	return bsc
+}
+
+// Deploys the SBOM to the folder of the build artifacts in Artifactory.
+func (bsc *BuildSbomCommand) SetDeploy(deploy bool) *BuildSbomCommand {
+	bsc.deploy = deploy
+	return bsc
+}
+
+func (bsc *BuildSbomCommand) SetRetries(retries int) *BuildSbomCommand {
+	bsc.retries = retries
+	return bsc
+}
+
+func (bsc *BuildSbomCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *BuildSbomCommand {
+	bsc.retryWaitMilliSecs = retryWaitMilliSecs
+	return bsc
+}
+
+func (bsc *BuildSbomCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bsc.serverDetails, nil
+}
+
+func (bsc *BuildSbomCommand) CommandName() string {
+	return "rt_build_sbom"
+}
+
+func (bsc *BuildSbomCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(bsc.serverDetails, bsc.retries, bsc.retryWaitMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	buildName, err := bsc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bsc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	buildInfo := bsc.buildInfo
+	if buildInfo == nil {
+		if buildInfo, err = GetPublishedBuildInfo(servicesManager, buildName, buildNumber, bsc.buildConfiguration.GetProject()); err != nil {
+			return err
+		}
+	}
+	sbom, err := CreateSbom(buildInfo, bsc.format)
+	if err != nil {
+		return err
+	}
+	if bsc.outputPath != "" {
+		if err = os.WriteFile(bsc.outputPath, sbom, 0644); err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Info("Saved the SBOM of build", buildName+"/"+buildNumber, "to", bsc.outputPath)
+	}
+	if bsc.deploy {
+		props := servicesutils.NewProperties()
+		props.AddProperty("build.name", buildName)
+		props.AddProperty("build.number", buildNumber)
+		props.AddProperty("sbom.format", string(bsc.format))
+		if _, err = DeployNextToArtifacts(servicesManager, buildInfo, bsc.format.fileName(buildName, buildNumber), sbom, props); err != nil {
+			return err
+		}
+	}
+	if bsc.outputPath == "" && !bsc.deploy {
+		log.Output(string(sbom))
+	}
+	return nil
+}
+
+// Returns the build-info of the build, as published to Artifactory.
+func GetPublishedBuildInfo(servicesManager artifactory.ArtifactoryServicesManager, buildName, buildNumber, project string) (*buildinfo.BuildInfo, error) {
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: buildName, BuildNumber: buildNumber, ProjectKey: project})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckErrorf("build %s/%s was not found in Artifactory", buildName, buildNumber)
+	}
+	return &publishedBuildInfo.BuildInfo, nil
+}
+
+// Deploys the file content to the folder of the build artifacts in Artifactory, with the given properties.
+// If the artifacts were deployed to several folders, the file is deployed to their deepest common folder in the
+// repository of the first artifact. Returns the path of the deployed file, or an empty path if the build has no
+// artifacts in Artifactory, in which case the file isn't deployed.
+func DeployNextToArtifacts(servicesManager artifactory.ArtifactoryServicesManager, buildInfo *buildinfo.BuildInfo, fileName string, content []byte,
+	props *servicesutils.Properties) (target string, err error) {
+	folder, err := findArtifactsFolder(servicesManager, buildInfo)
+	if err != nil || folder == "" {
+		if err == nil {
+			log.Warn("No artifacts of build", buildInfo.Name+"/"+buildInfo.Number, "were found in Artifactory, so", fileName, "was not deployed.")
+		}
+		return "", err
+	}
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDir))
+	}()
+	localPath := filepath.Join(tempDir, fileName)
+	if err = os.WriteFile(localPath, content, 0600); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	uploadParams := services.NewUploadParams()
+	uploadParams.Pattern = localPath
+	uploadParams.Target = folder + "/" + fileName
+	uploadParams.TargetProps = props
+	uploadParams.Flat = true
+	totalUploaded, totalFailed, err := servicesManager.UploadFiles(uploadParams)
+	if err != nil {
+		return "", err
+	}
+	if totalUploaded != 1 || totalFailed > 0 {
+		return "", errorutils.CheckErrorf("failed deploying %s to %s", fileName, folder)
+	}
+	log.Info("Deployed", fileName, "to", folder)
+	return uploadParams.Target, nil
+}
+
+// Returns the deepest folder containing all the artifacts of the build in the repository of its first artifact,
+// in the following format: <repository name>/<repository path>. Returns an empty folder if the build has no artifacts.
+func findArtifactsFolder(servicesManager artifactory.ArtifactoryServicesManager, buildInfo *buildinfo.BuildInfo) (folder string, err error) {
+	aql := fmt.Sprintf(`items.find({"artifact.module.build.name":%q,"artifact.module.build.number":%q}).include("repo","path","name")`,
+		buildInfo.Name, buildInfo.Number)
+	log.Debug("Searching the artifacts of the build using AQL query:", aql)
+	stream, err := servicesManager.Aql(aql)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(stream.Close()))
+	}()
+	result := new(servicesutils.AqlSearchResult)
+	if err = json.NewDecoder(stream).Decode(result); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if len(result.Results) == 0 {
+		return "", nil
+	}
+	sort.Slice(result.Results, func(i, j int) bool {
+		return result.Results[i].GetItemRelativePath() < result.Results[j].GetItemRelativePath()
+	})
+	repo := result.Results[0].Repo
+	var common []string
+	for i, item := range result.Results {
+		if item.Repo != repo {
+			continue
+		}
+		segments := strings.Split(item.Path, "/")
+		if item.Path == "." {
+			segments = nil
+		}
+		if i == 0 {
+			common = segments
+			continue
+		}
+		common = commonPrefix(common, segments)
+	}
+	return path.Join(append([]string{repo}, common...)...), nil
+}
+
+func commonPrefix(first, second []string) []string {
+	length := 0
+	for length < len(first) && length < len(second) && first[length] == second[length] {
+		length++
+	}
+	return first[:length]
+}
@@ -0,0 +1,179 @@
+package buildsbom
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var timestamp = time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+
+func createBuildInfo() *buildinfo.BuildInfo {
+	return &buildinfo.BuildInfo{
+		Name:   "my-build",
+		Number: "7",
+		Modules: []buildinfo.Module{{
+			Type: buildinfo.Maven,
+			Id:   "org.example:app:1.0",
+			Artifacts: []buildinfo.Artifact{
+				{Name: "app-1.0.jar", Checksum: buildinfo.Checksum{Sha1: "a1", Md5: "a2", Sha256: "a3"}},
+			},
+			Dependencies: []buildinfo.Dependency{
+				{Id: "org.lib:core:2.0", Scopes: []string{"compile"}, Checksum: buildinfo.Checksum{Sha1: "c1"}},
+				{Id: "org.lib:util:1.1", Scopes: []string{"compile"}, RequestedBy: [][]string{{"org.lib:core:2.0", "org.example:app:1.0"}}},
+				{Id: "junit:junit:4.13", Scopes: []string{"test"}, RequestedBy: [][]string{{"org.example:app:1.0"}}},
+			},
+		}},
+	}
+}
+
+func TestGetFormat(t *testing.T) {
+	format, err := GetFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, CycloneDx, format)
+	format, err = GetFormat("spdx")
+	assert.NoError(t, err)
+	assert.Equal(t, Spdx, format)
+	_, err = GetFormat("swid")
+	assert.Error(t, err)
+	assert.Equal(t, "a-b-1.spdx.json", Spdx.fileName("a/b", "1"))
+}
+
+func TestParseDependencyId(t *testing.T) {
+	tests := []struct {
+		id                   string
+		moduleType           buildinfo.ModuleType
+		group, name, version string
+		purl                 string
+	}{
+		{"org.lib:core:2.0", buildinfo.Maven, "org.lib", "core", "2.0", "pkg:maven/org.lib/core@2.0"},
+		{"org.lib:core:jdk8:2.0", buildinfo.Gradle, "org.lib", "core", "2.0", "pkg:maven/org.lib/core@2.0"},
+		{"@scope/pkg:1.2.3", buildinfo.Npm, "", "@scope/pkg", "1.2.3", "pkg:npm/%40scope/pkg@1.2.3"},
+		{"github.com/a/b:v1.0.0", buildinfo.Go, "", "github.com/a/b", "v1.0.0", "pkg:golang/github.com/a/b@v1.0.0"},
+		{"Requests:2.31.0", buildinfo.Python, "", "Requests", "2.31.0", "pkg:pypi/requests@2.31.0"},
+		{"file.zip", buildinfo.Generic, "", "file.zip", "", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			group, name, version := parseDependencyId(test.id, test.moduleType)
+			assert.Equal(t, []string{test.group, test.name, test.version}, []string{group, name, version})
+			assert.Equal(t, test.purl, createPurl(test.moduleType, group, name, version))
+		})
+	}
+}
+
+func TestDependencyGraph(t *testing.T) {
+	graph := newDependencyGraph(createBuildInfo())
+	assert.Equal(t, []string{"build:my-build/7", "dependency:junit:junit:4.13", "dependency:org.lib:core:2.0", "dependency:org.lib:util:1.1",
+		"module:org.example:app:1.0"}, graph.refs())
+	assert.Equal(t, []string{"module:org.example:app:1.0"}, graph.dependsOn(graph.buildRef))
+	// The transitive dependency is attached to the dependency which requested it.
+	assert.Equal(t, []string{"dependency:junit:junit:4.13", "dependency:org.lib:core:2.0"}, graph.dependsOn("module:org.example:app:1.0"))
+	assert.Equal(t, []string{"dependency:org.lib:util:1.1"}, graph.dependsOn("dependency:org.lib:core:2.0"))
+	assert.Empty(t, graph.dependsOn("dependency:org.lib:util:1.1"))
+}
+
+func TestCycloneDx(t *testing.T) {
+	bom := newCycloneDxBom(createBuildInfo(), "1234", timestamp)
+	assert.Equal(t, "1.5", bom.SpecVersion)
+	assert.Equal(t, "urn:uuid:1234", bom.SerialNumber)
+	assert.Equal(t, "2023-08-01T10:00:00Z", bom.Metadata.Timestamp)
+	assert.Equal(t, "my-build", bom.Metadata.Component.Name)
+	require.Len(t, bom.Components, 4)
+
+	module := bom.Components[0]
+	assert.Equal(t, "application", module.Type)
+	require.Len(t, module.Components, 1)
+	assert.Equal(t, []cycloneDxHash{{"SHA-1", "a1"}, {"MD5", "a2"}, {"SHA-256", "a3"}}, module.Components[0].Hashes)
+
+	junit := bom.Components[1]
+	assert.Equal(t, cycloneDxComponent{Type: "library", BomRef: "dependency:junit:junit:4.13", Group: "junit", Name: "junit", Version: "4.13",
+		Scope: "optional", Purl: "pkg:maven/junit/junit@4.13"}, junit)
+	assert.Empty(t, bom.Components[2].Scope)
+	assert.Len(t, bom.Dependencies, 5)
+
+	// All the references in the dependencies section must be defined.
+	refs := map[string]bool{bom.Metadata.Component.BomRef: true}
+	for _, component := range bom.Components {
+		refs[component.BomRef] = true
+	}
+	for _, dependency := range bom.Dependencies {
+		assert.True(t, refs[dependency.Ref], dependency.Ref)
+		for _, dependsOn := range dependency.DependsOn {
+			assert.True(t, refs[dependsOn], dependsOn)
+		}
+	}
+}
+
+func TestSpdx(t *testing.T) {
+	document := newSpdxDocument(createBuildInfo(), "1234", timestamp)
+	assert.Equal(t, "SPDX-2.3", document.SpdxVersion)
+	assert.Equal(t, "https://jfrog.com/spdx/my-build/7-1234", document.DocumentNamespace)
+	require.Len(t, document.Packages, 6)
+	assert.Equal(t, "SPDXRef-Artifact-1-1", document.Packages[2].SpdxId)
+	assert.Equal(t, "org.lib:core", document.Packages[4].Name)
+	assert.Equal(t, []spdxChecksum{{"SHA1", "c1"}}, document.Packages[4].Checksums)
+	assert.Equal(t, "pkg:maven/org.lib/core@2.0", document.Packages[4].ExternalRefs[0].ReferenceLocator)
+	assert.Equal(t, []spdxRelationship{
+		{"SPDXRef-DOCUMENT", "DESCRIBES", "SPDXRef-Build"},
+		{"SPDXRef-Module-1", "GENERATES", "SPDXRef-Artifact-1-1"},
+		{"SPDXRef-Build", "CONTAINS", "SPDXRef-Module-1"},
+		{"SPDXRef-Dependency-2", "DEPENDS_ON", "SPDXRef-Dependency-3"},
+		{"SPDXRef-Module-1", "DEPENDS_ON", "SPDXRef-Dependency-1"},
+		{"SPDXRef-Module-1", "DEPENDS_ON", "SPDXRef-Dependency-2"},
+	}, document.Relationships)
+}
+
+func TestFindArtifactsFolder(t *testing.T) {
+	items := []servicesutils.ResultItem{
+		{Repo: "libs-release", Path: "org/example/app/1.0", Name: "app-1.0.jar"},
+		{Repo: "libs-release", Path: "org/example/app/1.0/docs", Name: "app-1.0-javadoc.jar"},
+		{Repo: "libs-release", Path: "org/example/api/1.0", Name: "api-1.0.jar"},
+		{Repo: "other", Path: "a", Name: "b"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/search/aql", r.URL.Path)
+		content, err := json.Marshal(servicesutils.AqlSearchResult{Results: items})
+		require.NoError(t, err)
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+	servicesManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, 0, false)
+	require.NoError(t, err)
+	folder, err := findArtifactsFolder(servicesManager, createBuildInfo())
+	assert.NoError(t, err)
+	assert.Equal(t, "libs-release/org/example", folder)
+}
+
+func TestDeployNextToArtifactsWithoutArtifacts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/search/aql", r.URL.Path)
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	}))
+	defer server.Close()
+	servicesManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, 0, false)
+	require.NoError(t, err)
+	target, err := DeployNextToArtifacts(servicesManager, createBuildInfo(), "my-build-7.cdx.json", []byte("{}"), servicesutils.NewProperties())
+	assert.NoError(t, err)
+	assert.Empty(t, target)
+}
+
+func TestCreateArtifactRefs(t *testing.T) {
+	module := graphModule{ref: "module:app", Module: &buildinfo.Module{Artifacts: []buildinfo.Artifact{
+		{Name: "app.jar", Path: "org/app/1.0/app.jar"},
+		{Name: "app.jar", Path: "org/app/1.0/sources/app.jar"},
+		{Name: "app.pom"},
+		{Name: "app.pom"},
+	}}}
+	assert.Equal(t, []string{"module:app/org/app/1.0/app.jar", "module:app/org/app/1.0/sources/app.jar", "module:app/app.pom#3", "module:app/app.pom#4"},
+		createArtifactRefs(module))
+}
@@ -0,0 +1,142 @@
+package buildsbom
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+)
+
+const (
+	spdxVersion     = "SPDX-2.3"
+	spdxDocumentId  = "SPDXRef-DOCUMENT"
+	spdxNoAssertion = "NOASSERTION"
+	// The namespace of the documents. A unique ID is appended to the namespace of each document.
+	spdxNamespace = "https://jfrog.com/spdx/"
+)
+
+// An SPDX 2.3 document, with the fields which can be populated from a build-info.
+// See https://spdx.github.io/spdx-spec/v2.3
+type spdxDocument struct {
+	SpdxVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SpdxId            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SpdxId                string            `json:"SPDXID"`
+	Name                  string            `json:"name"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	Checksums             []spdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs          []spdxExternalRef `json:"externalRefs,omitempty"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SpdxElementId      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSpdxElement string `json:"relatedSpdxElement"`
+}
+
+// Converts the build-info to an SPDX document. The document describes the build, which contains the modules.
+// Each module generates its artifacts and depends on its direct dependencies, and the transitive dependencies
+// are depended on by the dependencies which requested them.
+// Every component is a package, since build-infos don't include the files inside the artifacts and dependencies.
+func newSpdxDocument(buildInfo *buildinfo.BuildInfo, documentId string, timestamp time.Time) *spdxDocument {
+	graph := newDependencyGraph(buildInfo)
+	document := &spdxDocument{
+		SpdxVersion:       spdxVersion,
+		DataLicense:       "CC0-1.0",
+		SpdxId:            spdxDocumentId,
+		Name:              buildInfo.Name + "-" + buildInfo.Number,
+		DocumentNamespace: spdxNamespace + url.PathEscape(buildInfo.Name) + "/" + url.PathEscape(buildInfo.Number) + "-" + documentId,
+		CreationInfo: spdxCreationInfo{
+			Created:  timestamp.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: " + coreutils.GetCliUserAgentName() + "-" + coreutils.GetCliUserAgentVersion()},
+		},
+		Relationships: []spdxRelationship{},
+	}
+	// SPDX IDs may contain letters, numbers, dots and hyphens only, so the components are numbered instead.
+	spdxIds := map[string]string{graph.buildRef: "SPDXRef-Build"}
+	document.Packages = append(document.Packages, spdxPackage{SpdxId: spdxIds[graph.buildRef], Name: buildInfo.Name, VersionInfo: buildInfo.Number,
+		DownloadLocation: spdxNoAssertion, PrimaryPackagePurpose: "APPLICATION"})
+	document.relate(spdxDocumentId, "DESCRIBES", spdxIds[graph.buildRef])
+	for i, module := range graph.modules {
+		spdxIds[module.ref] = fmt.Sprintf("SPDXRef-Module-%d", i+1)
+		document.Packages = append(document.Packages, spdxPackage{SpdxId: spdxIds[module.ref], Name: module.Id, DownloadLocation: spdxNoAssertion,
+			PrimaryPackagePurpose: "APPLICATION"})
+		for j, artifact := range module.Artifacts {
+			artifactId := fmt.Sprintf("SPDXRef-Artifact-%d-%d", i+1, j+1)
+			document.Packages = append(document.Packages, spdxPackage{SpdxId: artifactId, Name: artifactName(artifact), DownloadLocation: spdxNoAssertion,
+				Checksums: spdxChecksums(artifact.Checksum), PrimaryPackagePurpose: "FILE"})
+			document.relate(spdxIds[module.ref], "GENERATES", artifactId)
+		}
+	}
+	for i, dependency := range graph.dependencies {
+		spdxIds[dependency.ref] = fmt.Sprintf("SPDXRef-Dependency-%d", i+1)
+		name := dependency.name
+		if dependency.group != "" {
+			name = dependency.group + ":" + name
+		}
+		dependencyPackage := spdxPackage{SpdxId: spdxIds[dependency.ref], Name: name, VersionInfo: dependency.version, DownloadLocation: spdxNoAssertion,
+			Checksums: spdxChecksums(dependency.Checksum), PrimaryPackagePurpose: "LIBRARY"}
+		if dependency.purl != "" {
+			dependencyPackage.ExternalRefs = []spdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: dependency.purl}}
+		}
+		document.Packages = append(document.Packages, dependencyPackage)
+	}
+	for _, ref := range graph.refs() {
+		relationshipType := "DEPENDS_ON"
+		if ref == graph.buildRef {
+			relationshipType = "CONTAINS"
+		}
+		for _, dependsOn := range graph.dependsOn(ref) {
+			document.relate(spdxIds[ref], relationshipType, spdxIds[dependsOn])
+		}
+	}
+	return document
+}
+
+func (document *spdxDocument) relate(spdxId, relationshipType, relatedSpdxId string) {
+	document.Relationships = append(document.Relationships, spdxRelationship{SpdxElementId: spdxId, RelationshipType: relationshipType,
+		RelatedSpdxElement: relatedSpdxId})
+}
+
+func spdxChecksums(checksum buildinfo.Checksum) []spdxChecksum {
+	var checksums []spdxChecksum
+	if checksum.Sha1 != "" {
+		checksums = append(checksums, spdxChecksum{Algorithm: "SHA1", ChecksumValue: checksum.Sha1})
+	}
+	if checksum.Md5 != "" {
+		checksums = append(checksums, spdxChecksum{Algorithm: "MD5", ChecksumValue: checksum.Md5})
+	}
+	if checksum.Sha256 != "" {
+		checksums = append(checksums, spdxChecksum{Algorithm: "SHA256", ChecksumValue: checksum.Sha256})
+	}
+	return checksums
+}
@@ -0,0 +1,166 @@
+package buildshow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	commandsutils "github.com/jfrog/jfrog-cli/artifactory/commands/utils"
+	biconf "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Prints the build-info accumulated locally by the build commands, before it is published.
+// The build-info is created from the local partials the same way 'rt build-publish' creates it, without deleting them.
+type BuildShowCommand struct {
+	buildConfiguration *utils.BuildConfiguration
+	config             *biconf.Configuration
+	format             commandsutils.Format
+	buildInfo          *buildinfo.BuildInfo
+}
+
+func NewBuildShowCommand() *BuildShowCommand {
+	return &BuildShowCommand{config: &biconf.Configuration{EnvInclude: "*"}, format: commandsutils.Table}
+}
+
+func (bsc *BuildShowCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *BuildShowCommand {
+	bsc.buildConfiguration = buildConfiguration
+	return bsc
+}
+
+// The build URL and the environment variables filters, as they would be sent to 'rt build-publish'.
+func (bsc *BuildShowCommand) SetConfig(config *biconf.Configuration) *BuildShowCommand {
+	bsc.config = config
+	return bsc
+}
+
+func (bsc *BuildShowCommand) SetFormat(format commandsutils.Format) *BuildShowCommand {
+	bsc.format = format
+	return bsc
+}
+
+func (bsc *BuildShowCommand) BuildInfo() *buildinfo.BuildInfo {
+	return bsc.buildInfo
+}
+
+func (bsc *BuildShowCommand) ServerDetails() (*config.ServerDetails, error) {
+	return config.GetDefaultServerConf()
+}
+
+func (bsc *BuildShowCommand) CommandName() string {
+	return "rt_build_show"
+}
+
+func (bsc *BuildShowCommand) Run() error {
+	buildInfo, err := CreateBuildInfo(bsc.buildConfiguration, bsc.config)
+	if err != nil {
+		return err
+	}
+	bsc.buildInfo = buildInfo
+	return commandsutils.Print(bsc.format, bsc.buildInfo, func() error {
+		log.Output(FormatBuildInfo(bsc.buildInfo))
+		return nil
+	})
+}
+
+// Creates the build-info from the local partials of the build, the same way 'rt build-publish' creates it before publishing.
+// Unlike 'rt build-publish', the partials are kept, and the principal isn't set, since no server is involved.
+func CreateBuildInfo(buildConfiguration *utils.BuildConfiguration, config *biconf.Configuration) (*buildinfo.BuildInfo, error) {
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return nil, err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return nil, err
+	}
+	// Fail before the build service creates the general details of a build that wasn't collected.
+	if _, err = utils.ReadBuildInfoGeneralDetails(buildName, buildNumber, buildConfiguration.GetProject()); err != nil {
+		return nil, err
+	}
+	build, err := utils.CreateBuildInfoService().GetOrCreateBuildWithProject(buildName, buildNumber, buildConfiguration.GetProject())
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	build.SetAgentName(coreutils.GetCliUserAgentName())
+	build.SetAgentVersion(coreutils.GetCliUserAgentVersion())
+	build.SetBuildAgentVersion(coreutils.GetClientAgentVersion())
+	build.SetBuildUrl(config.BuildUrl)
+
+	buildInfo, err := build.ToBuildInfo()
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	if err = buildInfo.IncludeEnv(strings.Split(config.EnvInclude, ";")...); errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	if err = buildInfo.ExcludeEnv(strings.Split(config.EnvExclude, ";")...); errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	return buildInfo, nil
+}
+
+// Formats the build-info as a readable summary of its general details, VCS, environment variables and modules.
+func FormatBuildInfo(buildInfo *buildinfo.BuildInfo) string {
+	var output strings.Builder
+	line := func(indent int, format string, args ...interface{}) {
+		output.WriteString(strings.Repeat("  ", indent) + fmt.Sprintf(format, args...) + "\n")
+	}
+	line(0, "Build: %s/%s", buildInfo.Name, buildInfo.Number)
+	line(0, "Started: %s", buildInfo.Started)
+	if buildInfo.BuildUrl != "" {
+		line(0, "Build URL: %s", buildInfo.BuildUrl)
+	}
+
+	line(0, "VCS (%d):", len(buildInfo.VcsList))
+	for _, vcs := range buildInfo.VcsList {
+		details := vcs.Url + " @ " + vcs.Revision
+		if vcs.Branch != "" {
+			details += " (" + vcs.Branch + ")"
+		}
+		line(1, "%s", details)
+		if vcs.Message != "" {
+			line(2, "%s", vcs.Message)
+		}
+	}
+
+	envKeys := make([]string, 0, len(buildInfo.Properties))
+	for key := range buildInfo.Properties {
+		envKeys = append(envKeys, key)
+	}
+	sort.Strings(envKeys)
+	line(0, "Environment variables (%d):", len(envKeys))
+	for _, key := range envKeys {
+		line(1, "%s=%s", strings.TrimPrefix(key, buildinfo.BuildInfoEnvPrefix), buildInfo.Properties[key])
+	}
+
+	line(0, "Modules (%d):", len(buildInfo.Modules))
+	for _, module := range buildInfo.Modules {
+		line(1, "%s [%s]", module.Id, module.Type)
+		line(2, "Artifacts (%d):", len(module.Artifacts))
+		for _, artifact := range module.Artifacts {
+			line(3, "%s  sha1:%s", artifactPath(artifact), artifact.Sha1)
+		}
+		line(2, "Dependencies (%d):", len(module.Dependencies))
+		for _, dependency := range module.Dependencies {
+			details := dependency.Id
+			if len(dependency.Scopes) > 0 {
+				details += " [" + strings.Join(dependency.Scopes, ",") + "]"
+			}
+			line(3, "%s  sha1:%s", details, dependency.Sha1)
+		}
+	}
+	return strings.TrimSuffix(output.String(), "\n")
+}
+
+func artifactPath(artifact buildinfo.Artifact) string {
+	if artifact.Path != "" {
+		return artifact.Path
+	}
+	return artifact.Name
+}
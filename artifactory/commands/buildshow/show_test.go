@@ -0,0 +1,77 @@
+package buildshow
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	biconf "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBuildInfo(t *testing.T) {
+	buildName, buildNumber := "build-show-test", strconv.FormatInt(time.Now().UnixNano(), 10)
+	t.Cleanup(func() {
+		assert.NoError(t, utils.RemoveBuildDir(buildName, buildNumber, ""))
+	})
+	require.NoError(t, utils.SaveBuildGeneralDetails(buildName, buildNumber, ""))
+	partials := []func(partial *buildinfo.Partial){
+		func(partial *buildinfo.Partial) {
+			partial.Env = buildinfo.Env{"buildInfo.env.STAGE": "test", "buildInfo.env.API_TOKEN": "secret"}
+		},
+		func(partial *buildinfo.Partial) {
+			partial.VcsList = []buildinfo.Vcs{{Url: "https://github.com/org/app.git", Revision: "abc123", Branch: "main"}}
+		},
+		func(partial *buildinfo.Partial) {
+			partial.ModuleId = "app"
+			partial.ModuleType = buildinfo.Generic
+			partial.Artifacts = []buildinfo.Artifact{{Name: "app.zip", Path: "libs/app.zip", Checksum: buildinfo.Checksum{Sha1: "111"}}}
+		},
+		func(partial *buildinfo.Partial) {
+			partial.ModuleId = "app"
+			partial.ModuleType = buildinfo.Generic
+			partial.Dependencies = []buildinfo.Dependency{{Id: "lib.jar", Scopes: []string{"compile"}, Checksum: buildinfo.Checksum{Sha1: "222"}}}
+		},
+	}
+	for _, populate := range partials {
+		require.NoError(t, utils.SavePartialBuildInfo(buildName, buildNumber, "", populate))
+	}
+
+	buildConfiguration := utils.NewBuildConfiguration(buildName, buildNumber, "", "")
+	config := &biconf.Configuration{BuildUrl: "https://ci/1", EnvInclude: "*", EnvExclude: "*token*"}
+	showCommand := NewBuildShowCommand().SetBuildConfiguration(buildConfiguration).SetConfig(config)
+	require.NoError(t, showCommand.Run())
+	buildInfo := showCommand.BuildInfo()
+	assert.Equal(t, buildinfo.Env{"buildInfo.env.STAGE": "test"}, buildInfo.Properties)
+	require.Len(t, buildInfo.Modules, 1)
+	assert.Len(t, buildInfo.Modules[0].Artifacts, 1)
+	assert.Len(t, buildInfo.Modules[0].Dependencies, 1)
+
+	output := FormatBuildInfo(buildInfo)
+	assert.Contains(t, output, "Build: "+buildName+"/"+buildNumber+"\n")
+	assert.Contains(t, output, "Build URL: https://ci/1\n")
+	assert.Contains(t, output, "VCS (1):\n  https://github.com/org/app.git @ abc123 (main)\n")
+	assert.Contains(t, output, "Environment variables (1):\n  STAGE=test\n")
+	assert.Contains(t, output, `Modules (1):
+  app [generic]
+    Artifacts (1):
+      libs/app.zip  sha1:111
+    Dependencies (1):
+      lib.jar [compile]  sha1:222`)
+
+	// Showing the build doesn't delete its partials.
+	_, err := CreateBuildInfo(buildConfiguration, config)
+	assert.NoError(t, err)
+}
+
+func TestCreateBuildInfoNotCollected(t *testing.T) {
+	buildName, buildNumber := "build-show-missing", strconv.FormatInt(time.Now().UnixNano(), 10)
+	t.Cleanup(func() {
+		assert.NoError(t, utils.RemoveBuildDir(buildName, buildNumber, ""))
+	})
+	_, err := CreateBuildInfo(utils.NewBuildConfiguration(buildName, buildNumber, "", ""), new(biconf.Configuration))
+	assert.ErrorContains(t, err, "there were no previous commands, which collected build-info")
+}
@@ -0,0 +1,327 @@
+package checksumplan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The number of checksums searched by a single AQL query.
+	aqlBatchSize  = 500
+	tasksCapacity = 10000
+	// The threshold of deploying files by checksum, which generic.UploadCommand reads when the upload starts.
+	minChecksumDeploySizeEnv     = "JFROG_CLI_MIN_CHECKSUM_DEPLOY_SIZE_KB"
+	defaultMinChecksumDeploySize = 10240
+)
+
+// Uploads files with generic.UploadCommand, after planning which of them can be deployed by checksum.
+// The plan calculates the SHA-256 checksums of the files and searches for them in Artifactory in batches,
+// so that the bytes saved by the upload are reported before it starts. The JFROG_CLI_MIN_CHECKSUM_DEPLOY_SIZE_KB
+// threshold of the upload is then lowered to the size of the smallest file which already exists in Artifactory,
+// so that every such file is deployed by checksum.
+type PlannedUploadCommand struct {
+	*generic.UploadCommand
+	retries            int
+	retryWaitMilliSecs int
+	plan               *Plan
+}
+
+// The projection of an upload, made before the upload starts.
+type Plan struct {
+	Files         int
+	Bytes         int64
+	ExistingFiles int
+	ExistingBytes int64
+	// The size of the smallest file which exists in Artifactory, or -1 if none of the files exist.
+	minExistingSize int64
+}
+
+// A file matching one of the spec files, which can be deployed by checksum.
+type plannedFile struct {
+	localPath string
+	sha256    string
+	size      int64
+}
+
+func NewPlannedUploadCommand(uploadCommand *generic.UploadCommand) *PlannedUploadCommand {
+	return &PlannedUploadCommand{UploadCommand: uploadCommand}
+}
+
+// The retries are used by the searches of the plan.
+func (puc *PlannedUploadCommand) SetPlanRetries(retries, retryWaitMilliSecs int) *PlannedUploadCommand {
+	puc.retries, puc.retryWaitMilliSecs = retries, retryWaitMilliSecs
+	return puc
+}
+
+func (puc *PlannedUploadCommand) Plan() *Plan {
+	return puc.plan
+}
+
+func (puc *PlannedUploadCommand) Run() (err error) {
+	files, err := collectFiles(puc.Spec(), puc.UploadConfiguration().Threads)
+	if err != nil {
+		return
+	}
+	existingChecksums, err := puc.searchChecksums(files)
+	if err != nil {
+		return
+	}
+	puc.plan = newPlan(files, existingChecksums)
+	puc.plan.log()
+	restoreThreshold, err := puc.plan.lowerMinChecksumDeploySize()
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, restoreThreshold())
+	}()
+	return puc.UploadCommand.Run()
+}
+
+// Creates the upload params by which the files of the spec file are collected.
+func createCollectParams(file *spec.File) (params services.UploadParams, err error) {
+	params = services.NewUploadParams()
+	if params.CommonParams, err = file.ToCommonParams(); err != nil {
+		return
+	}
+	params.Archive = file.Archive
+	params.TargetPathInArchive = file.TargetPathInArchive
+	if params.Recursive, err = file.IsRecursive(true); err != nil {
+		return
+	}
+	if params.Regexp, err = file.IsRegexp(false); err != nil {
+		return
+	}
+	if params.Ant, err = file.IsAnt(false); err != nil {
+		return
+	}
+	if params.IncludeDirs, err = file.IsIncludeDirs(false); err != nil {
+		return
+	}
+	if params.Flat, err = file.IsFlat(true); err != nil {
+		return
+	}
+	if params.ExplodeArchive, err = file.IsExplode(false); err != nil {
+		return
+	}
+	params.Symlink, err = file.IsSymlinks(false)
+	return
+}
+
+// Returns true if the files of the spec file can be deployed by checksum. Archives, exploded archives, directories and
+// preserved symlinks can't, so such spec files are left out of the plan.
+func isPlannable(params *services.UploadParams) bool {
+	return params.Archive == "" && !params.ExplodeArchive && !params.IncludeDirs && !params.Symlink
+}
+
+// Collects the files matching the spec files, and calculates their checksums.
+func collectFiles(uploadSpec *spec.SpecFiles, threads int) (files []plannedFile, err error) {
+	log.Info("Planning the upload...")
+	for i := range uploadSpec.Files {
+		var params services.UploadParams
+		if params, err = createCollectParams(uploadSpec.Get(i)); err != nil {
+			return
+		}
+		if !isPlannable(&params) {
+			continue
+		}
+		var artifacts []clientutils.Artifact
+		if err = services.CollectFilesForUpload(params, nil, nil, func(data services.UploadData) {
+			artifacts = append(artifacts, data.Artifact)
+		}); err != nil {
+			return
+		}
+		var specFiles []plannedFile
+		if specFiles, err = calcChecksums(artifacts, threads); err != nil {
+			return
+		}
+		files = append(files, specFiles...)
+	}
+	return
+}
+
+// Calculates the SHA-256 checksums of the files matching a spec file in parallel.
+func calcChecksums(artifacts []clientutils.Artifact, threads int) ([]plannedFile, error) {
+	files := make([]plannedFile, 0, len(artifacts))
+	var filesMutex sync.Mutex
+	producerConsumer := parallel.NewRunner(threads, tasksCapacity, true)
+	go func() {
+		defer producerConsumer.Done()
+		for _, artifact := range artifacts {
+			artifact := artifact
+			_, _ = producerConsumer.AddTask(func(int) error {
+				sha256, size, err := calcChecksum(artifact.LocalPath)
+				if err != nil {
+					return err
+				}
+				filesMutex.Lock()
+				files = append(files, plannedFile{localPath: artifact.LocalPath, sha256: sha256, size: size})
+				filesMutex.Unlock()
+				return nil
+			})
+		}
+	}()
+	producerConsumer.Run()
+	var errs []error
+	for _, err := range producerConsumer.Errors() {
+		errs = append(errs, err)
+	}
+	return files, errors.Join(errs...)
+}
+
+func calcChecksum(localPath string) (checksum string, size int64, err error) {
+	reader, err := os.Open(localPath)
+	if err != nil {
+		return "", 0, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	hash := sha256.New()
+	if size, err = io.Copy(hash, reader); err != nil {
+		return "", 0, errorutils.CheckError(err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+// Searches Artifactory for the checksums of the files, and returns the checksums which exist.
+func (puc *PlannedUploadCommand) searchChecksums(files []plannedFile) (map[string]bool, error) {
+	existingChecksums := make(map[string]bool)
+	if len(files) == 0 {
+		return existingChecksums, nil
+	}
+	serverDetails, err := puc.ServerDetails()
+	if err != nil {
+		return nil, err
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, puc.retries, puc.retryWaitMilliSecs, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, aql := range createChecksumsAqls(files) {
+		if err = searchAql(servicesManager, aql, existingChecksums); err != nil {
+			return nil, err
+		}
+	}
+	return existingChecksums, nil
+}
+
+func searchAql(servicesManager artifactory.ArtifactoryServicesManager, aql string, existingChecksums map[string]bool) (err error) {
+	log.Debug("Searching Artifactory using AQL query:", aql)
+	stream, err := servicesManager.Aql(aql)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(stream.Close()))
+	}()
+	result := new(servicesutils.AqlSearchResult)
+	if err = json.NewDecoder(stream).Decode(result); err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, item := range result.Results {
+		existingChecksums[item.Sha256] = true
+	}
+	return
+}
+
+// Creates the AQL queries which search for the checksums of the files, each of them for up to aqlBatchSize checksums.
+func createChecksumsAqls(files []plannedFile) (aqls []string) {
+	var conditions []string
+	searched := make(map[string]bool)
+	for _, file := range files {
+		if searched[file.sha256] {
+			continue
+		}
+		searched[file.sha256] = true
+		conditions = append(conditions, `{"sha256":"`+file.sha256+`"}`)
+		if len(conditions) == aqlBatchSize {
+			aqls = append(aqls, createChecksumsAql(conditions))
+			conditions = nil
+		}
+	}
+	if len(conditions) > 0 {
+		aqls = append(aqls, createChecksumsAql(conditions))
+	}
+	return
+}
+
+func createChecksumsAql(conditions []string) string {
+	return `items.find({"type":"file","$or":[` + strings.Join(conditions, ",") + `]}).include("sha256")`
+}
+
+func newPlan(files []plannedFile, existingChecksums map[string]bool) *Plan {
+	plan := &Plan{Files: len(files), minExistingSize: -1}
+	for _, file := range files {
+		plan.Bytes += file.size
+		if existingChecksums[file.sha256] {
+			plan.ExistingFiles++
+			plan.ExistingBytes += file.size
+			if plan.minExistingSize == -1 || file.size < plan.minExistingSize {
+				plan.minExistingSize = file.size
+			}
+		}
+	}
+	return plan
+}
+
+func (plan *Plan) log() {
+	log.Info(fmt.Sprintf("Upload plan: %d files (%s), of which %d files (%s) already exist in Artifactory and will be deployed by checksum.",
+		plan.Files, utils.ConvertIntToStorageSizeString(plan.Bytes), plan.ExistingFiles, utils.ConvertIntToStorageSizeString(plan.ExistingBytes)))
+	log.Info("Projected bytes saved by the upload:", utils.ConvertIntToStorageSizeString(plan.ExistingBytes))
+}
+
+// Lowers the JFROG_CLI_MIN_CHECKSUM_DEPLOY_SIZE_KB threshold of generic.UploadCommand to the size of the smallest file which
+// exists in Artifactory, if it's higher. Returns a function which restores the threshold once the upload is done.
+func (plan *Plan) lowerMinChecksumDeploySize() (restore func() error, err error) {
+	restore = func() error { return nil }
+	minChecksumDeploySize, err := getMinChecksumDeploySize()
+	if err != nil || plan.minExistingSize == -1 || plan.minExistingSize >= minChecksumDeploySize {
+		return
+	}
+	// The threshold is set in KB, and is rounded down so that it doesn't exceed the size of the file.
+	minSizeKb := strconv.FormatInt(plan.minExistingSize/1000, 10)
+	log.Debug("Deploying files of at least", minSizeKb, "KB by checksum.")
+	previous, isSet := os.LookupEnv(minChecksumDeploySizeEnv)
+	if err = errorutils.CheckError(os.Setenv(minChecksumDeploySizeEnv, minSizeKb)); err != nil {
+		return
+	}
+	return func() error {
+		if isSet {
+			return errorutils.CheckError(os.Setenv(minChecksumDeploySizeEnv, previous))
+		}
+		return errorutils.CheckError(os.Unsetenv(minChecksumDeploySizeEnv))
+	}, nil
+}
+
+// Returns the JFROG_CLI_MIN_CHECKSUM_DEPLOY_SIZE_KB threshold in bytes, the same way generic.UploadCommand reads it.
+func getMinChecksumDeploySize() (int64, error) {
+	minChecksumDeploySize := os.Getenv(minChecksumDeploySizeEnv)
+	if minChecksumDeploySize == "" {
+		return defaultMinChecksumDeploySize, nil
+	}
+	minSize, err := strconv.ParseInt(minChecksumDeploySize, 10, 64)
+	if err != nil {
+		return 0, errorutils.CheckError(err)
+	}
+	return minSize * 1000, nil
+}
@@ -0,0 +1,115 @@
+package checksumplan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateChecksumsAqls(t *testing.T) {
+	var files []plannedFile
+	for i := 0; i < aqlBatchSize+1; i++ {
+		files = append(files, plannedFile{sha256: fmt.Sprintf("%064x", i)})
+	}
+	// Files with the same checksum are searched once.
+	files = append(files, files[0])
+	aqls := createChecksumsAqls(files)
+	require.Len(t, aqls, 2)
+	assert.Equal(t, aqlBatchSize, strings.Count(aqls[0], `{"sha256":`))
+	assert.Equal(t, `items.find({"type":"file","$or":[{"sha256":"`+files[aqlBatchSize].sha256+`"}]}).include("sha256")`, aqls[1])
+}
+
+func TestNewPlan(t *testing.T) {
+	files := []plannedFile{{sha256: "a", size: 100}, {sha256: "b", size: 20}, {sha256: "a", size: 100}, {sha256: "c", size: 5}}
+	plan := newPlan(files, map[string]bool{"a": true, "c": true})
+	assert.Equal(t, Plan{Files: 4, Bytes: 225, ExistingFiles: 3, ExistingBytes: 205, minExistingSize: 5}, *plan)
+	assert.Equal(t, int64(-1), newPlan(files, nil).minExistingSize)
+}
+
+func TestLowerMinChecksumDeploySize(t *testing.T) {
+	t.Setenv(minChecksumDeploySizeEnv, "20")
+	restore, err := (&Plan{minExistingSize: 30000}).lowerMinChecksumDeploySize()
+	require.NoError(t, err)
+	assert.Equal(t, "20", os.Getenv(minChecksumDeploySizeEnv))
+	require.NoError(t, restore())
+
+	restore, err = (&Plan{minExistingSize: 5999}).lowerMinChecksumDeploySize()
+	require.NoError(t, err)
+	assert.Equal(t, "5", os.Getenv(minChecksumDeploySizeEnv))
+	require.NoError(t, restore())
+	assert.Equal(t, "20", os.Getenv(minChecksumDeploySizeEnv))
+}
+
+func TestRun(t *testing.T) {
+	localDir := t.TempDir()
+	t.Setenv(minChecksumDeploySizeEnv, "")
+	existingContent, newContent := []byte(strings.Repeat("existing", 300)), []byte("new")
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "existing.txt"), existingContent, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "new.txt"), newContent, 0600))
+	require.NoError(t, os.Symlink("new.txt", filepath.Join(localDir, "link.txt")))
+	existingSha256, newSha256 := sha256.Sum256(existingContent), sha256.Sum256(newContent)
+
+	var deployedMutex sync.Mutex
+	checksumDeployed := make(map[string]bool)
+	var putRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/search/aql":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Contains(t, string(body), hex.EncodeToString(existingSha256[:]))
+			assert.Contains(t, string(body), hex.EncodeToString(newSha256[:]))
+			content, err := json.Marshal(servicesutils.AqlSearchResult{Results: []servicesutils.ResultItem{{Repo: "other-repo", Name: "file", Sha256: hex.EncodeToString(existingSha256[:])}}})
+			assert.NoError(t, err)
+			_, _ = w.Write(content)
+		case r.Method == http.MethodPut:
+			isChecksumDeploy := r.Header.Get("X-Checksum-Deploy") == "true"
+			deployedMutex.Lock()
+			putRequests++
+			deployedMutex.Unlock()
+			if isChecksumDeploy && r.Header.Get("X-Checksum") != hex.EncodeToString(existingSha256[:]) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			deployedMutex.Lock()
+			checksumDeployed[r.URL.Path] = isChecksumDeploy
+			deployedMutex.Unlock()
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	uploadSpec := spec.NewBuilder().Pattern(filepath.ToSlash(localDir) + "/*.txt").Target("repo/").Flat(true).BuildSpec()
+	uploadCommand := generic.NewUploadCommand()
+	uploadCommand.SetUploadConfiguration(&utils.UploadConfiguration{Threads: 2}).SetSpec(uploadSpec).
+		SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"})
+	plannedUploadCommand := NewPlannedUploadCommand(uploadCommand)
+	require.NoError(t, plannedUploadCommand.Run())
+
+	assert.Equal(t, Plan{Files: 3, Bytes: 2406, ExistingFiles: 1, ExistingBytes: 2400, minExistingSize: 2400}, *plannedUploadCommand.Plan())
+	// The symlink is uploaded by the name of the file it links to, since the symlinks option isn't set.
+	assert.Equal(t, map[string]bool{"/repo/existing.txt": true, "/repo/new.txt": false}, checksumDeployed)
+	// The new files are smaller than the existing file, so they aren't tried to be deployed by checksum first.
+	assert.Equal(t, 3, putRequests)
+	assert.Equal(t, 3, plannedUploadCommand.Result().SuccessCount())
+	assert.Empty(t, os.Getenv(minChecksumDeploySizeEnv))
+}
@@ -0,0 +1,222 @@
+package cleanup
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The fields searched for each file. The properties are added by the search, since no sorting or limit is used.
+var searchIncludeFields = []string{"name", "repo", "path", "type", "size", "created", "modified", "stat.downloaded"}
+
+// Deletes the files selected by the rules of a retention policy.
+// The files are selected using the search command, and a report of the files to be deleted is printed before they
+// are deleted using the delete command. In dry run mode, the report is printed and nothing is deleted.
+type CleanupCommand struct {
+	serverDetails      *config.ServerDetails
+	policyPath         string
+	dryRun             bool
+	quiet              bool
+	threads            int
+	retries            int
+	retryWaitMilliSecs int
+	candidates         []Candidate
+	result             *commandsutils.Result
+}
+
+func NewCleanupCommand() *CleanupCommand {
+	return &CleanupCommand{threads: 3, result: new(commandsutils.Result)}
+}
+
+func (cc *CleanupCommand) SetServerDetails(serverDetails *config.ServerDetails) *CleanupCommand {
+	cc.serverDetails = serverDetails
+	return cc
+}
+
+func (cc *CleanupCommand) SetPolicyPath(policyPath string) *CleanupCommand {
+	cc.policyPath = policyPath
+	return cc
+}
+
+func (cc *CleanupCommand) SetDryRun(dryRun bool) *CleanupCommand {
+	cc.dryRun = dryRun
+	return cc
+}
+
+func (cc *CleanupCommand) SetQuiet(quiet bool) *CleanupCommand {
+	cc.quiet = quiet
+	return cc
+}
+
+func (cc *CleanupCommand) SetThreads(threads int) *CleanupCommand {
+	cc.threads = threads
+	return cc
+}
+
+func (cc *CleanupCommand) SetRetries(retries int) *CleanupCommand {
+	cc.retries = retries
+	return cc
+}
+
+func (cc *CleanupCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *CleanupCommand {
+	cc.retryWaitMilliSecs = retryWaitMilliSecs
+	return cc
+}
+
+func (cc *CleanupCommand) ServerDetails() (*config.ServerDetails, error) {
+	return cc.serverDetails, nil
+}
+
+// Returns the files selected for deletion, sorted by rule and path.
+func (cc *CleanupCommand) Candidates() []Candidate {
+	return cc.candidates
+}
+
+func (cc *CleanupCommand) Result() *commandsutils.Result {
+	return cc.result
+}
+
+func (cc *CleanupCommand) CommandName() string {
+	return "rt_cleanup"
+}
+
+func (cc *CleanupCommand) Run() (err error) {
+	policy, err := LoadPolicy(cc.policyPath)
+	if err != nil {
+		return
+	}
+	servicesManager, err := utils.CreateServiceManager(cc.serverDetails, cc.retries, cc.retryWaitMilliSecs, false)
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	selected := make(map[string]bool)
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		for _, repo := range rule.Repos {
+			var candidates []Candidate
+			if candidates, err = evaluateRepo(servicesManager, rule, repo, now); err != nil {
+				return
+			}
+			// A file selected by several rules is deleted once, and reported with the first rule that selected it.
+			for _, candidate := range candidates {
+				if !selected[candidate.Path] {
+					selected[candidate.Path] = true
+					cc.candidates = append(cc.candidates, candidate)
+				}
+			}
+		}
+	}
+	if err = cc.printReport(); err != nil || cc.dryRun || len(cc.candidates) == 0 {
+		return
+	}
+	if !cc.quiet && !coreutils.AskYesNo("Are you sure you want to delete the above "+strconv.Itoa(len(cc.candidates))+" files?", false) {
+		return
+	}
+	return cc.deleteCandidates()
+}
+
+func (cc *CleanupCommand) printReport() error {
+	var totalSize int64
+	for _, candidate := range cc.candidates {
+		totalSize += candidate.item.Size
+	}
+	title := "Files to delete"
+	if cc.dryRun {
+		title = "Files to delete [Dry run]"
+	}
+	if err := coreutils.PrintTable(cc.candidates, title, "No files match the cleanup policy", false); err != nil {
+		return err
+	}
+	if len(cc.candidates) > 0 {
+		log.Info(strconv.Itoa(len(cc.candidates)), "files,", utils.ConvertIntToStorageSizeString(totalSize)+", match the cleanup policy.")
+	}
+	return nil
+}
+
+func (cc *CleanupCommand) deleteCandidates() (err error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return
+	}
+	for _, candidate := range cc.candidates {
+		writer.Write(candidate.item)
+	}
+	if err = writer.Close(); err != nil {
+		return
+	}
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	deleteCommand := generic.NewDeleteCommand()
+	deleteCommand.SetThreads(cc.threads).SetServerDetails(cc.serverDetails).SetRetries(cc.retries).SetRetryWaitMilliSecs(cc.retryWaitMilliSecs)
+	successCount, failedCount, err := deleteCommand.DeleteFiles(reader)
+	cc.result.SetSuccessCount(successCount)
+	cc.result.SetFailCount(failedCount)
+	return
+}
+
+func evaluateRepo(servicesManager artifactory.ArtifactoryServicesManager, rule *Rule, repo string, now time.Time) (candidates []Candidate, err error) {
+	log.Info("Evaluating the cleanup rule '" + rule.Name + "' in " + repo + "...")
+	protectedPaths := make(map[string]bool)
+	if rule.Protect.Builds {
+		if protectedPaths, err = searchBuildsPaths(servicesManager, repo); err != nil {
+			return
+		}
+	}
+	pattern := strings.TrimPrefix(rule.Pattern, "/")
+	if pattern == "" {
+		pattern = "*"
+	}
+	file := spec.NewBuilder().Pattern(repo + "/" + pattern).Recursive(true).Include(searchIncludeFields).BuildSpec().Get(0)
+	reader, err := searchItems(servicesManager, file)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	return evaluate(rule, reader, protectedPaths, now)
+}
+
+// Returns the paths of the files in the repository, which are artifacts or dependencies of any build.
+func searchBuildsPaths(servicesManager artifactory.ArtifactoryServicesManager, repo string) (paths map[string]bool, err error) {
+	paths = make(map[string]bool)
+	for _, buildField := range []string{"artifact.module.build.name", "dependency.module.build.name"} {
+		file := spec.NewBuilder().Include([]string{"name", "repo", "path"}).BuildSpec().Get(0)
+		file.Aql = servicesutils.Aql{ItemsFind: `{"repo":"` + repo + `","type":"file","` + buildField + `":{"$match":"*"}}`}
+		var reader *content.ContentReader
+		if reader, err = searchItems(servicesManager, file); err != nil {
+			return
+		}
+		for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+			paths[item.GetItemRelativePath()] = true
+		}
+		if err = errors.Join(reader.GetError(), reader.Close()); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Returns a reader of the search results, which are stored in a temp file rather than in memory.
+func searchItems(servicesManager artifactory.ArtifactoryServicesManager, file *spec.File) (*content.ContentReader, error) {
+	searchParams, err := utils.GetSearchParams(file)
+	if err != nil {
+		return nil, err
+	}
+	return servicesManager.SearchFiles(searchParams)
+}
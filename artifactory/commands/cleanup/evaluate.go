@@ -0,0 +1,162 @@
+package cleanup
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+)
+
+const dayDuration = 24 * time.Hour
+
+// A file selected for deletion by a rule.
+type Candidate struct {
+	Path   string `json:"path" col-name:"Path"`
+	Rule   string `json:"rule" col-name:"Rule"`
+	Reason string `json:"reason" col-name:"Reason"`
+	Size   string `json:"size" col-name:"Size"`
+	item   servicesutils.ResultItem
+}
+
+type version struct {
+	path       string
+	lastCreate time.Time
+}
+
+// Returns the files which should be deleted by the rule, sorted by path.
+// The reader contains the files matching the rule in one of its repositories, and the protected paths are the paths of
+// the build artifacts and dependencies in that repository, if builds are protected by the rule. The files are read one
+// by one, so that the files of large repositories aren't loaded to memory, and the reader is reset afterwards.
+func evaluate(rule *Rule, reader *content.ContentReader, protectedPaths map[string]bool, now time.Time) ([]Candidate, error) {
+	keptVersions, err := getKeptVersions(reader, rule.KeepLastVersions)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []Candidate
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		if candidate := evaluateItem(rule, item, keptVersions, protectedPaths, now); candidate != nil {
+			candidates = append(candidates, *candidate)
+		}
+	}
+	if err = reader.GetError(); err != nil {
+		return nil, err
+	}
+	reader.Reset()
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Path < candidates[j].Path
+	})
+	return candidates, nil
+}
+
+// Returns the candidate for deletion if the file should be deleted by the rule, or nil otherwise.
+func evaluateItem(rule *Rule, item *servicesutils.ResultItem, keptVersions, protectedPaths map[string]bool, now time.Time) *Candidate {
+	itemPath := item.GetItemRelativePath()
+	if item.Type == "folder" || protectedPaths[itemPath] || hasProtectedProperty(item, rule.Protect.Properties) {
+		return nil
+	}
+	var reasons []string
+	if rule.KeepLastVersions > 0 {
+		if item.Path == "." || keptVersions[getVersionPath(item)] {
+			return nil
+		}
+		reasons = append(reasons, "not in the last "+strconv.Itoa(rule.KeepLastVersions)+" versions of "+path.Join(item.Repo, path.Dir(item.Path)))
+	}
+	if rule.NotDownloadedInDays > 0 {
+		lastUsed, downloaded := getLastUsed(item)
+		// Files whose age is unknown are kept.
+		if lastUsed.IsZero() || now.Sub(lastUsed) < time.Duration(rule.NotDownloadedInDays)*dayDuration {
+			return nil
+		}
+		if downloaded {
+			reasons = append(reasons, "last downloaded on "+lastUsed.Format(time.DateOnly))
+		} else {
+			reasons = append(reasons, "never downloaded since created on "+lastUsed.Format(time.DateOnly))
+		}
+	}
+	return &Candidate{Path: itemPath, Rule: rule.Name, Reason: strings.Join(reasons, "; "), Size: utils.ConvertIntToStorageSizeString(item.Size), item: *item}
+}
+
+// The version of a file is the folder containing it. Files directly under the repository root have no version.
+func getVersionPath(item *servicesutils.ResultItem) string {
+	return path.Join(item.Repo, item.Path)
+}
+
+// Returns the paths of the last versions of each artifact. The artifact is the parent folder of the version folder,
+// and the versions of each artifact are ordered by the creation time of their newest file.
+// Only the versions are kept in memory while the files are read, and the reader is reset afterwards.
+func getKeptVersions(reader *content.ContentReader, keepLastVersions int) (map[string]bool, error) {
+	kept := make(map[string]bool)
+	if keepLastVersions <= 0 {
+		return kept, nil
+	}
+	versions := make(map[string]*version)
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		if item.Type == "folder" || item.Path == "." {
+			continue
+		}
+		versionPath := getVersionPath(item)
+		created := parseTime(item.Created)
+		if existing, ok := versions[versionPath]; !ok {
+			versions[versionPath] = &version{path: versionPath, lastCreate: created}
+		} else if created.After(existing.lastCreate) {
+			existing.lastCreate = created
+		}
+	}
+	if err := reader.GetError(); err != nil {
+		return nil, err
+	}
+	reader.Reset()
+	artifactsVersions := make(map[string][]*version)
+	for _, v := range versions {
+		artifactPath := path.Dir(v.path)
+		artifactsVersions[artifactPath] = append(artifactsVersions[artifactPath], v)
+	}
+	for _, artifactVersions := range artifactsVersions {
+		sort.Slice(artifactVersions, func(i, j int) bool {
+			if artifactVersions[i].lastCreate.Equal(artifactVersions[j].lastCreate) {
+				return artifactVersions[i].path > artifactVersions[j].path
+			}
+			return artifactVersions[i].lastCreate.After(artifactVersions[j].lastCreate)
+		})
+		for i := 0; i < len(artifactVersions) && i < keepLastVersions; i++ {
+			kept[artifactVersions[i].path] = true
+		}
+	}
+	return kept, nil
+}
+
+func hasProtectedProperty(item *servicesutils.ResultItem, protectedProps map[string]string) bool {
+	for _, property := range item.Properties {
+		if value, ok := protectedProps[property.Key]; ok && (value == "*" || value == property.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the last download time of the file, or its creation time if it was never downloaded.
+func getLastUsed(item *servicesutils.ResultItem) (lastUsed time.Time, downloaded bool) {
+	for _, stat := range item.Stats {
+		if downloadTime := parseTime(stat.Downloaded); downloadTime.After(lastUsed) {
+			lastUsed, downloaded = downloadTime, true
+		}
+	}
+	if !downloaded {
+		lastUsed = parseTime(item.Created)
+	}
+	return
+}
+
+// Parses an AQL timestamp. Returns the zero time if the timestamp is empty or invalid.
+func parseTime(timestamp string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
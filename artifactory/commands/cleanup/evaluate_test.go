@@ -0,0 +1,122 @@
+package cleanup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+
+func createItem(itemPath, name string, createdDaysAgo, downloadedDaysAgo int, props ...servicesutils.Property) servicesutils.ResultItem {
+	item := servicesutils.ResultItem{Repo: "libs", Path: itemPath, Name: name, Type: "file", Created: now.AddDate(0, 0, -createdDaysAgo).Format(time.RFC3339), Properties: props}
+	if downloadedDaysAgo >= 0 {
+		item.Stats = []servicesutils.Stat{{Downloaded: now.AddDate(0, 0, -downloadedDaysAgo).Format(time.RFC3339)}}
+	}
+	return item
+}
+
+// Evaluates the rule on the items, read from a reader like the search results.
+func evaluateItems(t *testing.T, rule *Rule, items []servicesutils.ResultItem, protectedPaths map[string]bool) []Candidate {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	require.NoError(t, err)
+	for _, item := range items {
+		writer.Write(item)
+	}
+	require.NoError(t, writer.Close())
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	candidates, err := evaluate(rule, reader, protectedPaths, now)
+	require.NoError(t, err)
+	return candidates
+}
+
+func getCandidatesPaths(candidates []Candidate) []string {
+	var paths []string
+	for _, candidate := range candidates {
+		paths = append(paths, candidate.Path)
+	}
+	return paths
+}
+
+func TestEvaluateKeepLastVersions(t *testing.T) {
+	items := []servicesutils.ResultItem{
+		createItem("app/1.0", "app-1.0.jar", 30, -1),
+		createItem("app/1.0", "app-1.0.pom", 30, -1),
+		createItem("app/1.1", "app-1.1.jar", 20, -1),
+		createItem("app/1.2", "app-1.2.jar", 10, -1),
+		createItem("lib/0.1", "lib-0.1.jar", 40, -1),
+		createItem(".", "readme.txt", 50, -1),
+		{Repo: "libs", Path: "app", Name: "1.0", Type: "folder"},
+	}
+	rule := &Rule{Name: "versions", KeepLastVersions: 2}
+	candidates := evaluateItems(t, rule, items, nil)
+	assert.Equal(t, []string{"libs/app/1.0/app-1.0.jar", "libs/app/1.0/app-1.0.pom"}, getCandidatesPaths(candidates))
+	assert.Equal(t, "not in the last 2 versions of libs/app", candidates[0].Reason)
+	assert.Equal(t, "versions", candidates[0].Rule)
+}
+
+func TestEvaluateNotDownloaded(t *testing.T) {
+	items := []servicesutils.ResultItem{
+		createItem("app/1.0", "old-download.jar", 300, 100),
+		createItem("app/1.0", "recent-download.jar", 300, 10),
+		createItem("app/1.0", "never-downloaded.jar", 100, -1),
+		createItem("app/1.0", "new.jar", 10, -1),
+		{Repo: "libs", Path: "app/1.0", Name: "unknown-age.jar", Type: "file"},
+	}
+	candidates := evaluateItems(t, &Rule{Name: "unused", NotDownloadedInDays: 90}, items, nil)
+	assert.Equal(t, []string{"libs/app/1.0/never-downloaded.jar", "libs/app/1.0/old-download.jar"}, getCandidatesPaths(candidates))
+	assert.Equal(t, "never downloaded since created on 2023-03-23", candidates[0].Reason)
+	assert.Equal(t, "last downloaded on 2023-03-23", candidates[1].Reason)
+}
+
+func TestEvaluateAllCriteriaAndProtection(t *testing.T) {
+	items := []servicesutils.ResultItem{
+		createItem("app/1.0", "unused.jar", 300, 100),
+		createItem("app/1.0", "used.jar", 300, 10),
+		createItem("app/1.0", "released.jar", 300, 100, servicesutils.Property{Key: "release", Value: "true"}),
+		createItem("app/1.0", "retained.jar", 300, 100, servicesutils.Property{Key: "retain", Value: "forever"}),
+		createItem("app/1.0", "built.jar", 300, 100),
+		createItem("app/2.0", "latest.jar", 200, 100),
+	}
+	rule := &Rule{Name: "all", KeepLastVersions: 1, NotDownloadedInDays: 90, Protect: Protect{Properties: map[string]string{"release": "true", "retain": "*"}, Builds: true}}
+	candidates := evaluateItems(t, rule, items, map[string]bool{"libs/app/1.0/built.jar": true})
+	assert.Equal(t, []string{"libs/app/1.0/unused.jar"}, getCandidatesPaths(candidates))
+	assert.Equal(t, "not in the last 1 versions of libs/app; last downloaded on 2023-03-23", candidates[0].Reason)
+}
+
+func TestLoadPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   string
+		errorMsg string
+	}{
+		{"valid", "rules:\n  - name: a\n    repos: [libs]\n    keepLastVersions: 3\n    protect:\n      builds: true\n", ""},
+		{"noRules", "rules: []\n", "doesn't contain any rules"},
+		{"noName", "rules:\n  - repos: [libs]\n    keepLastVersions: 3\n", "has no name"},
+		{"noRepos", "rules:\n  - name: a\n    keepLastVersions: 3\n", "has no repositories"},
+		{"noCriteria", "rules:\n  - name: a\n    repos: [libs]\n", "should have a keepLastVersions or a notDownloadedInDays criterion"},
+		{"unknownField", "rules:\n  - name: a\n    repos: [libs]\n    keepLast: 3\n", "failed parsing the cleanup policy"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policyPath := filepath.Join(t.TempDir(), "cleanup.yaml")
+			assert.NoError(t, os.WriteFile(policyPath, []byte(test.policy), 0644))
+			policy, err := LoadPolicy(policyPath)
+			if test.errorMsg == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, Rule{Name: "a", Repos: []string{"libs"}, KeepLastVersions: 3, Protect: Protect{Builds: true}}, policy.Rules[0])
+				return
+			}
+			assert.ErrorContains(t, err, test.errorMsg)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package cleanup
+
+import (
+	"os"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v2"
+)
+
+// A retention policy, declared in a YAML file. For example:
+//
+//	rules:
+//	  - name: snapshots
+//	    repos: [libs-snapshot-local]
+//	    pattern: com/acme/*
+//	    keepLastVersions: 5
+//	    notDownloadedInDays: 90
+//	    protect:
+//	      properties:
+//	        release: "true"
+//	        retain: "*"
+//	      builds: true
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// A rule selects the files matching a pattern in each of its repositories.
+// A file is deleted if it meets all the criteria of the rule, and isn't protected.
+type Rule struct {
+	Name  string   `yaml:"name"`
+	Repos []string `yaml:"repos"`
+	// A path pattern inside the repositories, with optional wildcards. All the files in the repositories are selected if empty.
+	Pattern string `yaml:"pattern"`
+	// Keeps the files of the last N versions of each artifact. The version of a file is the folder containing it,
+	// and the artifact is the parent folder of the version, for example com/acme/app/1.0.0/app-1.0.0.jar.
+	// The versions are ordered by the creation time of their newest file.
+	KeepLastVersions int `yaml:"keepLastVersions"`
+	// Keeps the files which were downloaded, or created if never downloaded, in the last N days.
+	NotDownloadedInDays int     `yaml:"notDownloadedInDays"`
+	Protect             Protect `yaml:"protect"`
+}
+
+type Protect struct {
+	// Files with any of these properties are never deleted. A value of "*" matches any value of the property.
+	Properties map[string]string `yaml:"properties"`
+	// If true, files which are artifacts or dependencies of a build are never deleted.
+	Builds bool `yaml:"builds"`
+}
+
+func LoadPolicy(policyPath string) (*Policy, error) {
+	content, err := os.ReadFile(policyPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	policy := new(Policy)
+	if err = yaml.UnmarshalStrict(content, policy); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the cleanup policy %s: %s", policyPath, err.Error())
+	}
+	return policy, policy.validate()
+}
+
+func (p *Policy) validate() error {
+	if len(p.Rules) == 0 {
+		return errorutils.CheckErrorf("the cleanup policy doesn't contain any rules")
+	}
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Name == "" {
+			return errorutils.CheckErrorf("rule %d of the cleanup policy has no name", i+1)
+		}
+		if len(rule.Repos) == 0 {
+			return errorutils.CheckErrorf("the cleanup rule '%s' has no repositories", rule.Name)
+		}
+		if rule.KeepLastVersions < 0 || rule.NotDownloadedInDays < 0 {
+			return errorutils.CheckErrorf("the cleanup rule '%s' has a negative keepLastVersions or notDownloadedInDays value", rule.Name)
+		}
+		if rule.KeepLastVersions == 0 && rule.NotDownloadedInDays == 0 {
+			return errorutils.CheckErrorf("the cleanup rule '%s' should have a keepLastVersions or a notDownloadedInDays criterion", rule.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package diff
+
+import (
+	"sort"
+	"strings"
+)
+
+type Status string
+
+const (
+	Added        Status = "added"
+	Removed      Status = "removed"
+	Changed      Status = "changed"
+	PropsChanged Status = "props-changed"
+)
+
+// An artifact found on one side of the diff.
+type artifact struct {
+	path   string
+	sha1   string
+	sha256 string
+	props  map[string][]string
+}
+
+// A single difference between the source and the target. The path is relative to the side's base path,
+// or the artifact's name if the side is a build, prefixed by its module if other artifacts of the build share the name.
+type Entry struct {
+	Path         string       `json:"path" col-name:"Path"`
+	Status       Status       `json:"status" col-name:"Status"`
+	SourcePath   string       `json:"sourcePath,omitempty" col-name:"Source"`
+	TargetPath   string       `json:"targetPath,omitempty" col-name:"Target"`
+	SourceSha256 string       `json:"sourceSha256,omitempty"`
+	TargetSha256 string       `json:"targetSha256,omitempty"`
+	Props        []PropChange `json:"props,omitempty"`
+	PropsSummary string       `json:"-" col-name:"Properties"`
+}
+
+// A property which was added, removed or modified. A missing value means the property doesn't exist on that side.
+type PropChange struct {
+	Key         string   `json:"key"`
+	SourceValue []string `json:"source,omitempty"`
+	TargetValue []string `json:"target,omitempty"`
+}
+
+// Compares the source and target artifacts, mapped by their relative paths.
+// Returns the differences sorted by path. Identical artifacts with identical properties are omitted.
+func compare(source, target map[string]*artifact, compareProps bool) []Entry {
+	var entries []Entry
+	for path, sourceArtifact := range source {
+		targetArtifact, exists := target[path]
+		if !exists {
+			entries = append(entries, Entry{Path: path, Status: Removed, SourcePath: sourceArtifact.path, SourceSha256: sourceArtifact.sha256})
+			continue
+		}
+		entry := Entry{Path: path, SourcePath: sourceArtifact.path, TargetPath: targetArtifact.path, SourceSha256: sourceArtifact.sha256, TargetSha256: targetArtifact.sha256}
+		if compareProps {
+			entry.Props = compareProperties(sourceArtifact.props, targetArtifact.props)
+			entry.PropsSummary = summarizePropChanges(entry.Props)
+		}
+		switch {
+		case !isSameContent(sourceArtifact, targetArtifact):
+			entry.Status = Changed
+		case len(entry.Props) > 0:
+			entry.Status = PropsChanged
+		default:
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	for path, targetArtifact := range target {
+		if _, exists := source[path]; !exists {
+			entries = append(entries, Entry{Path: path, Status: Added, TargetPath: targetArtifact.path, TargetSha256: targetArtifact.sha256})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Path < entries[j].Path
+	})
+	return entries
+}
+
+// SHA-256 is compared if calculated on both sides, otherwise SHA-1 is used.
+func isSameContent(source, target *artifact) bool {
+	if source.sha256 != "" && target.sha256 != "" {
+		return source.sha256 == target.sha256
+	}
+	return source.sha1 == target.sha1
+}
+
+func compareProperties(source, target map[string][]string) []PropChange {
+	var changes []PropChange
+	for key, sourceValue := range source {
+		targetValue, exists := target[key]
+		if !exists || !isSameValue(sourceValue, targetValue) {
+			changes = append(changes, PropChange{Key: key, SourceValue: sourceValue, TargetValue: targetValue})
+		}
+	}
+	for key, targetValue := range target {
+		if _, exists := source[key]; !exists {
+			changes = append(changes, PropChange{Key: key, TargetValue: targetValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+// Properties may have multiple values, which are returned in no particular order.
+func isSameValue(source, target []string) bool {
+	if len(source) != len(target) {
+		return false
+	}
+	sortedSource := append([]string{}, source...)
+	sortedTarget := append([]string{}, target...)
+	sort.Strings(sortedSource)
+	sort.Strings(sortedTarget)
+	for i := range sortedSource {
+		if sortedSource[i] != sortedTarget[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func summarizePropChanges(changes []PropChange) string {
+	summaries := make([]string, len(changes))
+	for i, change := range changes {
+		switch {
+		case change.SourceValue == nil:
+			summaries[i] = "+" + change.Key + "=" + strings.Join(change.TargetValue, ",")
+		case change.TargetValue == nil:
+			summaries[i] = "-" + change.Key + "=" + strings.Join(change.SourceValue, ",")
+		default:
+			summaries[i] = change.Key + ": " + strings.Join(change.SourceValue, ",") + " -> " + strings.Join(change.TargetValue, ",")
+		}
+	}
+	return strings.Join(summaries, "\n")
+}
@@ -0,0 +1,98 @@
+package diff
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	source := map[string]*artifact{
+		"removed.jar":   {path: "libs/app/1.2/removed.jar", sha256: "1"},
+		"changed.jar":   {path: "libs/app/1.2/changed.jar", sha256: "2"},
+		"same.jar":      {path: "libs/app/1.2/same.jar", sha256: "3", props: map[string][]string{"a": {"1", "2"}}},
+		"props.jar":     {path: "libs/app/1.2/props.jar", sha1: "4", props: map[string][]string{"a": {"1"}, "b": {"2"}}},
+		"sha1-only.jar": {path: "libs/app/1.2/sha1-only.jar", sha1: "5"},
+	}
+	target := map[string]*artifact{
+		"added.jar":     {path: "libs/app/1.3/added.jar", sha256: "6"},
+		"changed.jar":   {path: "libs/app/1.3/changed.jar", sha256: "7"},
+		"same.jar":      {path: "libs/app/1.3/same.jar", sha256: "3", props: map[string][]string{"a": {"2", "1"}}},
+		"props.jar":     {path: "libs/app/1.3/props.jar", sha1: "4", props: map[string][]string{"a": {"3"}, "c": {"4"}}},
+		"sha1-only.jar": {path: "libs/app/1.3/sha1-only.jar", sha1: "5", sha256: "8"},
+	}
+
+	entries := compare(source, target, true)
+	var paths []string
+	var statuses []Status
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+		statuses = append(statuses, entry.Status)
+	}
+	assert.Equal(t, []string{"added.jar", "changed.jar", "props.jar", "removed.jar"}, paths)
+	assert.Equal(t, []Status{Added, Changed, PropsChanged, Removed}, statuses)
+	assert.Equal(t, []PropChange{
+		{Key: "a", SourceValue: []string{"1"}, TargetValue: []string{"3"}},
+		{Key: "b", SourceValue: []string{"2"}},
+		{Key: "c", TargetValue: []string{"4"}},
+	}, entries[2].Props)
+	assert.Equal(t, "a: 1 -> 3\n-b=2\n+c=4", entries[2].PropsSummary)
+
+	// Without comparing properties, artifacts with the same content are identical.
+	entries = compare(source, target, false)
+	assert.Len(t, entries, 3)
+}
+
+func TestGetBasePath(t *testing.T) {
+	// Serves the storage API, which returns the children of folders only.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/storage/libs/app/1.2":
+			_, _ = w.Write([]byte(`{"repo":"libs","path":"/app/1.2","children":[]}`))
+		case "/api/storage/libs/app/1.2/app.jar":
+			_, _ = w.Write([]byte(`{"repo":"libs","path":"/app/1.2/app.jar","size":"10"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	servicesManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, 0, false)
+	require.NoError(t, err)
+
+	tests := []struct {
+		side     Side
+		expected string
+	}{
+		{Side{Pattern: "libs/app/1.2"}, "libs/app/1.2/"},
+		{Side{Pattern: "libs/app/1.2/"}, "libs/app/1.2/"},
+		{Side{Pattern: "libs/app/1.2/app.jar"}, "libs/app/1.2/"},
+		{Side{Pattern: "libs/app/1.2/*.jar"}, "libs/app/1.2/"},
+		{Side{Pattern: "libs/app/1.*/lib/*"}, "libs/app/"},
+		{Side{Build: "app/41"}, ""},
+	}
+	for _, test := range tests {
+		pattern, err := getSearchPattern(servicesManager, &test.side)
+		require.NoError(t, err, test.side.String())
+		assert.Equal(t, test.expected, getBasePath(pattern), test.side.String())
+	}
+	// A file is searched by its path as is.
+	pattern, err := getSearchPattern(servicesManager, &Side{Pattern: "libs/app/1.2/app.jar"})
+	require.NoError(t, err)
+	assert.Equal(t, "libs/app/1.2/app.jar", pattern)
+	_, err = getSearchPattern(servicesManager, &Side{Pattern: "libs/app/1.3"})
+	assert.Error(t, err)
+}
+
+func TestGetSharedNameKey(t *testing.T) {
+	modules := map[string]string{"org/app/1.0/app.jar": getModuleName("org:app:1.0"), "app.jar": getModuleName("generic")}
+	assert.Equal(t, "org:app/app.jar", getSharedNameKey(&servicesutils.ResultItem{Repo: "libs", Path: "org/app/1.0", Name: "app.jar"}, modules))
+	assert.Equal(t, "generic/app.jar", getSharedNameKey(&servicesutils.ResultItem{Repo: "libs", Path: ".", Name: "app.jar"}, modules))
+	// The path of an artifact which isn't found in the build-info is its key.
+	assert.Equal(t, "libs/other/app.jar", getSharedNameKey(&servicesutils.ResultItem{Repo: "libs", Path: "other", Name: "app.jar"}, modules))
+}
@@ -0,0 +1,281 @@
+package diff
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	commandsutils "github.com/jfrog/jfrog-cli/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// One side of the diff: the artifacts matching a pattern, or the artifacts of a build.
+type Side struct {
+	// A repository path, with optional wildcards. If the side is a build, the pattern filters the build's artifacts.
+	Pattern string
+	// The build in the form of <build name>/<build number>. Empty if the side isn't a build.
+	Build string
+}
+
+func (s *Side) isBuild() bool {
+	return s.Build != ""
+}
+
+func (s *Side) String() string {
+	if s.isBuild() {
+		return "build " + s.Build
+	}
+	return s.Pattern
+}
+
+// Lists the artifacts which were added, removed or changed between two repository paths or two builds,
+// and the differences in their properties.
+type DiffCommand struct {
+	serverDetails      *config.ServerDetails
+	source             Side
+	target             Side
+	compareProps       bool
+	format             commandsutils.Format
+	retries            int
+	retryWaitMilliSecs int
+	entries            []Entry
+}
+
+func NewDiffCommand() *DiffCommand {
+	return &DiffCommand{compareProps: true, format: commandsutils.Table}
+}
+
+func (dc *DiffCommand) SetServerDetails(serverDetails *config.ServerDetails) *DiffCommand {
+	dc.serverDetails = serverDetails
+	return dc
+}
+
+func (dc *DiffCommand) SetSource(source Side) *DiffCommand {
+	dc.source = source
+	return dc
+}
+
+func (dc *DiffCommand) SetTarget(target Side) *DiffCommand {
+	dc.target = target
+	return dc
+}
+
+func (dc *DiffCommand) SetCompareProps(compareProps bool) *DiffCommand {
+	dc.compareProps = compareProps
+	return dc
+}
+
+func (dc *DiffCommand) SetFormat(format commandsutils.Format) *DiffCommand {
+	dc.format = format
+	return dc
+}
+
+func (dc *DiffCommand) SetRetries(retries int) *DiffCommand {
+	dc.retries = retries
+	return dc
+}
+
+func (dc *DiffCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *DiffCommand {
+	dc.retryWaitMilliSecs = retryWaitMilliSecs
+	return dc
+}
+
+func (dc *DiffCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dc.serverDetails, nil
+}
+
+func (dc *DiffCommand) Entries() []Entry {
+	return dc.entries
+}
+
+func (dc *DiffCommand) CommandName() string {
+	return "rt_diff"
+}
+
+func (dc *DiffCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(dc.serverDetails, dc.retries, dc.retryWaitMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	source, err := collectArtifacts(servicesManager, &dc.source)
+	if err != nil {
+		return err
+	}
+	target, err := collectArtifacts(servicesManager, &dc.target)
+	if err != nil {
+		return err
+	}
+	dc.entries = compare(source, target, dc.compareProps)
+	entries := dc.entries
+	if entries == nil {
+		entries = []Entry{}
+	}
+	return commandsutils.Print(dc.format, entries, func() error {
+		return coreutils.PrintTable(dc.entries, "Differences between "+dc.source.String()+" and "+dc.target.String(), "No differences were found", false)
+	})
+}
+
+// Searches the artifacts of the side, and maps them by their relative paths.
+// The relative path of an artifact matching a pattern is its path under the pattern's base path,
+// and the relative path of a build artifact is its name, since build artifacts are usually deployed to a path
+// containing the build number or version. Build artifacts which share their name are told apart by their modules.
+func collectArtifacts(servicesManager artifactory.ArtifactoryServicesManager, side *Side) (artifacts map[string]*artifact, err error) {
+	pattern, err := getSearchPattern(servicesManager, side)
+	if err != nil {
+		return
+	}
+	file := spec.NewBuilder().Pattern(pattern).Build(side.Build).Recursive(true).BuildSpec().Get(0)
+	searchParams, err := utils.GetSearchParams(file)
+	if err != nil {
+		return
+	}
+	log.Info("Searching the artifacts of", side.String()+"...")
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	basePath := getBasePath(pattern)
+	artifacts = make(map[string]*artifact)
+	var buildItems []*servicesutils.ResultItem
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		if item.Type == "folder" {
+			continue
+		}
+		if side.isBuild() {
+			buildItems = append(buildItems, item)
+			continue
+		}
+		itemPath := item.GetItemRelativePath()
+		artifacts[strings.TrimPrefix(itemPath, basePath)] = toArtifact(item)
+	}
+	if err = reader.GetError(); err != nil || !side.isBuild() {
+		return
+	}
+	nameCounts := make(map[string]int)
+	for _, item := range buildItems {
+		nameCounts[item.Name]++
+	}
+	var modules map[string]string
+	for _, count := range nameCounts {
+		if count > 1 {
+			modules, err = getArtifactModules(servicesManager, side.Build)
+			break
+		}
+	}
+	if err != nil {
+		return
+	}
+	for _, item := range buildItems {
+		key := item.Name
+		if nameCounts[item.Name] > 1 {
+			key = getSharedNameKey(item, modules)
+		}
+		artifacts[key] = toArtifact(item)
+	}
+	return
+}
+
+func toArtifact(item *servicesutils.ResultItem) *artifact {
+	return &artifact{path: item.GetItemRelativePath(), sha1: item.Actual_Sha1, sha256: item.Sha256, props: toPropsMap(item.Properties)}
+}
+
+// Returns the key of a build artifact whose name is shared by other artifacts of the build. The name is prefixed by
+// the ID of the artifact's module without its version, such as 'org.example:app/app.jar'. If the artifact's module is
+// unknown, its path is the key.
+func getSharedNameKey(item *servicesutils.ResultItem, modules map[string]string) string {
+	if module, exists := modules[getPathInRepo(item)]; exists {
+		return module + "/" + item.Name
+	}
+	return item.GetItemRelativePath()
+}
+
+// Maps the paths of the build's artifacts, without their repositories, to the IDs of their modules without the versions.
+func getArtifactModules(servicesManager artifactory.ArtifactoryServicesManager, build string) (map[string]string, error) {
+	buildName, buildNumber, err := servicesutils.ParseNameAndVersion(build, true)
+	if err != nil {
+		return nil, err
+	}
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: buildName, BuildNumber: buildNumber})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckErrorf("build %s was not found in Artifactory", build)
+	}
+	modules := make(map[string]string)
+	for _, module := range publishedBuildInfo.BuildInfo.Modules {
+		for _, moduleArtifact := range module.Artifacts {
+			modules[moduleArtifact.Path] = getModuleName(module.Id)
+		}
+	}
+	return modules, nil
+}
+
+// Returns the path of the item without its repository, like the path of a build-info artifact.
+func getPathInRepo(item *servicesutils.ResultItem) string {
+	if item.Path == "." {
+		return item.Name
+	}
+	return item.Path + "/" + item.Name
+}
+
+// Returns the module ID without its version, which follows the last colon, like in 'org.example:app:1.0', so that
+// the artifacts of a module are matched between builds of different versions. IDs without a colon have no version.
+func getModuleName(moduleId string) string {
+	if separatorIndex := strings.LastIndex(moduleId, ":"); separatorIndex >= 0 {
+		return moduleId[:separatorIndex]
+	}
+	return moduleId
+}
+
+// A pattern without wildcards is a path to a folder or a file. For a folder, all the files under it are compared.
+func getSearchPattern(servicesManager artifactory.ArtifactoryServicesManager, side *Side) (string, error) {
+	pattern := side.Pattern
+	if pattern == "" {
+		return "*", nil
+	}
+	if strings.Contains(pattern, "*") || strings.HasSuffix(pattern, "/") {
+		return pattern, nil
+	}
+	folder, err := isFolder(servicesManager, pattern)
+	if err != nil || !folder {
+		return pattern, err
+	}
+	return pattern + "/", nil
+}
+
+// Returns true if the path is a folder, and false if it's a file. Fails if the path doesn't exist.
+func isFolder(servicesManager artifactory.ArtifactoryServicesManager, itemPath string) (bool, error) {
+	info, err := servicesManager.FolderInfo(itemPath)
+	if err != nil {
+		return false, err
+	}
+	// The storage API returns the children of folders only.
+	return info.Children != nil, nil
+}
+
+// Returns the part of the pattern up to the last slash before the first wildcard.
+func getBasePath(pattern string) string {
+	if wildcardIndex := strings.Index(pattern, "*"); wildcardIndex >= 0 {
+		pattern = pattern[:wildcardIndex]
+	}
+	return pattern[:strings.LastIndex(pattern, "/")+1]
+}
+
+func toPropsMap(properties []servicesutils.Property) map[string][]string {
+	props := make(map[string][]string)
+	for _, property := range properties {
+		props[property.Key] = append(props[property.Key], property.Value)
+	}
+	return props
+}
@@ -0,0 +1,167 @@
+package incremental
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	commandsutils "github.com/jfrog/jfrog-cli/artifactory/commands/utils"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	SyncsDirName  = "incremental"
+	commandName   = "rt_download_incremental"
+	aqlTimeFormat = "2006-01-02T15:04:05.000Z"
+	// Items which are being deployed while the sync time is taken may be committed with an earlier modification time,
+	// so the next incremental download starts a bit before the sync time.
+	syncTimeOverlap = time.Minute
+)
+
+// Tracks the last successful download of a File Spec from an Artifactory server.
+// The state is stored under the JFrog CLI home directory, and is identified the same way as the resumable
+// download journal - by the server URL and the File Spec.
+type Sync struct {
+	path     string
+	syncTime time.Time
+	lastSync *time.Time
+}
+
+type syncState struct {
+	LastSync time.Time `json:"lastSync"`
+}
+
+// Loads the last successful sync of the File Spec, and takes the current time of the Artifactory server,
+// which is stored as the last sync time once the download completes successfully.
+func NewSync(serverDetails *config.ServerDetails, downloadSpec *spec.SpecFiles, retries, retryWaitMilliSecs int) (*Sync, error) {
+	id, err := commandsutils.CreateSpecId(commandName, serverDetails.ArtifactoryUrl, downloadSpec)
+	if err != nil {
+		return nil, err
+	}
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	sync := &Sync{path: filepath.Join(homeDir, SyncsDirName, id+".json")}
+	if sync.lastSync, err = loadLastSync(sync.path); err != nil {
+		return nil, err
+	}
+	if sync.syncTime, err = getServerTime(serverDetails, retries, retryWaitMilliSecs); err != nil {
+		return nil, err
+	}
+	return sync, nil
+}
+
+func loadLastSync(path string) (*time.Time, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	state := new(syncState)
+	if err = json.Unmarshal(content, state); err != nil {
+		return nil, errorutils.CheckErrorf("failed reading the incremental download state from '%s': %s", path, err.Error())
+	}
+	return &state.LastSync, nil
+}
+
+// Returns the current time of the Artifactory server, taken from the Date header of its response.
+// The local time is used if the server doesn't return the header.
+func getServerTime(serverDetails *config.ServerDetails, retries, retryWaitMilliSecs int) (time.Time, error) {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, retries, retryWaitMilliSecs, false)
+	if err != nil {
+		return time.Time{}, err
+	}
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(serviceDetails.GetUrl()+"api/system/ping", true, &httpClientDetails)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return time.Time{}, err
+	}
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		log.Debug("The Artifactory server didn't return a valid Date header. Using the local time as the sync time.")
+		return time.Now(), nil
+	}
+	return serverTime, nil
+}
+
+// Returns the time of the last successful sync, or nil if the File Spec wasn't downloaded successfully before.
+func (s *Sync) LastSync() *time.Time {
+	return s.lastSync
+}
+
+// Returns a copy of the spec, which only matches the items created or modified in Artifactory since the last sync.
+// If there is no previous sync, the spec is returned as is.
+// Spec files which search by build only are not filtered, since they are not searched using AQL.
+func (s *Sync) IncrementalSpec(downloadSpec *spec.SpecFiles) (*spec.SpecFiles, error) {
+	if s.lastSync == nil {
+		log.Info("No previous incremental download of this File Spec was found. Downloading all the matching files.")
+		return downloadSpec, nil
+	}
+	since := s.lastSync.Add(-syncTimeOverlap).UTC().Format(aqlTimeFormat)
+	log.Info("Downloading the files created or modified since the last incremental download, at", s.lastSync.Local().Format(time.RFC3339))
+	incrementalSpec := &spec.SpecFiles{Files: make([]spec.File, len(downloadSpec.Files))}
+	for i, file := range downloadSpec.Files {
+		aqlBody, err := getAqlBody(&file)
+		if err != nil {
+			return nil, err
+		}
+		if aqlBody != "" {
+			// A copied or moved item keeps its modification time, so its update time is checked as well.
+			// The pattern is kept, so that the local paths are resolved the same way as in a full download.
+			file.Aql = servicesutils.Aql{ItemsFind: fmt.Sprintf(`{"$and":[%s,{"$or":[{"modified":{"$gt":"%s"}},{"updated":{"$gt":"%s"}}]}]}`, aqlBody, since, since)}
+		}
+		incrementalSpec.Files[i] = file
+	}
+	return incrementalSpec, nil
+}
+
+// Returns the AQL body the download command searches the spec file with, or an empty string if it isn't searched using AQL.
+func getAqlBody(file *spec.File) (string, error) {
+	params, err := file.ToCommonParams()
+	if err != nil {
+		return "", err
+	}
+	switch params.GetSpecType() {
+	case servicesutils.AQL:
+		return params.Aql.ItemsFind, nil
+	case servicesutils.WILDCARD:
+		if params.Recursive, err = file.IsRecursive(true); err != nil {
+			return "", err
+		}
+		if params.IncludeDirs, err = file.IsIncludeDirs(false); err != nil {
+			return "", err
+		}
+		return servicesutils.CreateAqlBodyForSpecWithPattern(params)
+	default:
+		return "", nil
+	}
+}
+
+// Stores the sync time as the time of the last successful sync.
+func (s *Sync) Save() error {
+	content, err := json.Marshal(syncState{LastSync: s.syncTime})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(s.path)); err != nil {
+		return err
+	}
+	return errorutils.CheckError(os.WriteFile(s.path, content, 0600))
+}
@@ -0,0 +1,59 @@
+package incremental
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncrementalSpec(t *testing.T) {
+	downloadSpec := &spec.SpecFiles{Files: []spec.File{
+		{Pattern: "repo/path/*.zip", Target: "out/"},
+		{Aql: servicesutils.Aql{ItemsFind: `{"repo":"repo"}`}},
+		{Build: "build/1"},
+	}}
+
+	// Without a previous sync, the spec is not modified.
+	sync := &Sync{}
+	incrementalSpec, err := sync.IncrementalSpec(downloadSpec)
+	require.NoError(t, err)
+	assert.Same(t, downloadSpec, incrementalSpec)
+
+	lastSync := time.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC)
+	sync.lastSync = &lastSync
+	incrementalSpec, err = sync.IncrementalSpec(downloadSpec)
+	require.NoError(t, err)
+	timeFilter := `{"$or":[{"modified":{"$gt":"2023-05-01T10:29:00.000Z"}},{"updated":{"$gt":"2023-05-01T10:29:00.000Z"}}]}]}`
+
+	patternFile := incrementalSpec.Get(0)
+	assert.Equal(t, "repo/path/*.zip", patternFile.Pattern)
+	assert.Equal(t, "out/", patternFile.Target)
+	assert.True(t, strings.HasPrefix(patternFile.Aql.ItemsFind, `{"$and":[{`), patternFile.Aql.ItemsFind)
+	assert.True(t, strings.HasSuffix(patternFile.Aql.ItemsFind, timeFilter), patternFile.Aql.ItemsFind)
+	assert.Contains(t, patternFile.Aql.ItemsFind, `"repo":"repo"`)
+
+	assert.Equal(t, `{"$and":[{"repo":"repo"},`+timeFilter, incrementalSpec.Get(1).Aql.ItemsFind)
+	assert.Empty(t, incrementalSpec.Get(2).Aql.ItemsFind)
+	// The original spec is not modified.
+	assert.Empty(t, downloadSpec.Get(0).Aql.ItemsFind)
+}
+
+func TestSaveAndLoadLastSync(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SyncsDirName, "id.json")
+	lastSync, err := loadLastSync(path)
+	require.NoError(t, err)
+	assert.Nil(t, lastSync)
+
+	syncTime := time.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC)
+	require.NoError(t, (&Sync{path: path, syncTime: syncTime}).Save())
+	lastSync, err = loadLastSync(path)
+	require.NoError(t, err)
+	require.NotNil(t, lastSync)
+	assert.True(t, syncTime.Equal(*lastSync))
+}
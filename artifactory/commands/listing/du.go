@@ -0,0 +1,277 @@
+package listing
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	commandsutils "github.com/jfrog/jfrog-cli/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The storage usage of a folder, including all the files in its sub-folders.
+type FolderUsage struct {
+	Path  string `json:"path"`
+	Size  int64  `json:"size"`
+	Files int    `json:"files"`
+	// The growth since the compared snapshot. Folders which were removed since the snapshot have zero size and files.
+	SizeGrowth  *int64 `json:"sizeGrowth,omitempty"`
+	FilesGrowth *int   `json:"filesGrowth,omitempty"`
+}
+
+// A saved usage report, which later reports of the same path and depth can be compared to.
+type UsageSnapshot struct {
+	Path    string        `json:"path"`
+	Depth   int           `json:"depth"`
+	Created time.Time     `json:"created"`
+	Folders []FolderUsage `json:"folders"`
+}
+
+// Reports the storage usage of a folder in Artifactory and of its sub-folders, like the du command of the local file system.
+// The folders are sorted by their size, from the largest.
+type DiskUsageCommand struct {
+	serverDetails      *config.ServerDetails
+	path               string
+	depth              int
+	format             commandsutils.Format
+	snapshotPath       string
+	comparePath        string
+	retries            int
+	retryWaitMilliSecs int
+	folders            []FolderUsage
+}
+
+func NewDiskUsageCommand() *DiskUsageCommand {
+	return &DiskUsageCommand{depth: 1, format: commandsutils.Table}
+}
+
+func (duc *DiskUsageCommand) SetServerDetails(serverDetails *config.ServerDetails) *DiskUsageCommand {
+	duc.serverDetails = serverDetails
+	return duc
+}
+
+// The path of the reported folder, in the following format: <repository name>/<repository path>.
+func (duc *DiskUsageCommand) SetPath(path string) *DiskUsageCommand {
+	duc.path = path
+	return duc
+}
+
+// The number of sub-folder levels reported below the folder. Zero reports all levels.
+func (duc *DiskUsageCommand) SetDepth(depth int) *DiskUsageCommand {
+	duc.depth = depth
+	return duc
+}
+
+func (duc *DiskUsageCommand) SetFormat(format commandsutils.Format) *DiskUsageCommand {
+	duc.format = format
+	return duc
+}
+
+// Saves the report as a snapshot to this file, so that later reports can be compared to it.
+func (duc *DiskUsageCommand) SetSnapshotPath(snapshotPath string) *DiskUsageCommand {
+	duc.snapshotPath = snapshotPath
+	return duc
+}
+
+// Compares the report to the snapshot saved in this file, and reports the growth of each folder.
+func (duc *DiskUsageCommand) SetComparePath(comparePath string) *DiskUsageCommand {
+	duc.comparePath = comparePath
+	return duc
+}
+
+func (duc *DiskUsageCommand) SetRetries(retries int) *DiskUsageCommand {
+	duc.retries = retries
+	return duc
+}
+
+func (duc *DiskUsageCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *DiskUsageCommand {
+	duc.retryWaitMilliSecs = retryWaitMilliSecs
+	return duc
+}
+
+func (duc *DiskUsageCommand) Folders() []FolderUsage {
+	return duc.folders
+}
+
+func (duc *DiskUsageCommand) ServerDetails() (*config.ServerDetails, error) {
+	return duc.serverDetails, nil
+}
+
+func (duc *DiskUsageCommand) CommandName() string {
+	return "rt_du"
+}
+
+func (duc *DiskUsageCommand) Run() error {
+	if duc.depth < 0 {
+		return errorutils.CheckErrorf("the depth must be zero or a positive number")
+	}
+	root := newFolder(duc.path)
+	var previous *UsageSnapshot
+	if duc.comparePath != "" {
+		// The snapshot is loaded first, to fail before searching if it can't be compared.
+		var err error
+		if previous, err = loadSnapshot(duc.comparePath, root.String(), duc.depth); err != nil {
+			return err
+		}
+	}
+	servicesManager, err := utils.CreateServiceManager(duc.serverDetails, duc.retries, duc.retryWaitMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	rootNode, err := searchTree(servicesManager, root, duc.depth)
+	if err != nil {
+		return err
+	}
+	duc.folders = sortUsage(rootNode.usage(root.String(), nil))
+	if duc.snapshotPath != "" {
+		snapshot := &UsageSnapshot{Path: root.String(), Depth: duc.depth, Created: time.Now(), Folders: duc.folders}
+		if err = saveSnapshot(duc.snapshotPath, snapshot); err != nil {
+			return err
+		}
+	}
+	if previous != nil {
+		duc.folders = sortUsage(compareUsage(duc.folders, previous.Folders))
+	}
+	return duc.printUsage(previous != nil)
+}
+
+// Returns the usage of the folder node and of all the folder nodes under it.
+func (node *treeNode) usage(nodePath string, folders []FolderUsage) []FolderUsage {
+	folders = append(folders, FolderUsage{Path: nodePath, Size: node.size, Files: node.files})
+	for name, child := range node.children {
+		if child.isFolder {
+			folders = child.usage(nodePath+"/"+name, folders)
+		}
+	}
+	return folders
+}
+
+// Sorts the folders by their size, from the largest.
+func sortUsage(folders []FolderUsage) []FolderUsage {
+	sort.Slice(folders, func(i, j int) bool {
+		if folders[i].Size != folders[j].Size {
+			return folders[i].Size > folders[j].Size
+		}
+		return folders[i].Path < folders[j].Path
+	})
+	return folders
+}
+
+// Returns the current usage with the growth of each folder since the previous usage.
+// Folders which were removed since the previous usage are added with zero size and files.
+func compareUsage(current, previous []FolderUsage) []FolderUsage {
+	previousByPath := make(map[string]FolderUsage, len(previous))
+	for _, folder := range previous {
+		previousByPath[folder.Path] = folder
+	}
+	compared := make([]FolderUsage, 0, len(current))
+	for _, folder := range current {
+		sizeGrowth, filesGrowth := folder.Size-previousByPath[folder.Path].Size, folder.Files-previousByPath[folder.Path].Files
+		delete(previousByPath, folder.Path)
+		compared = append(compared, FolderUsage{Path: folder.Path, Size: folder.Size, Files: folder.Files, SizeGrowth: &sizeGrowth, FilesGrowth: &filesGrowth})
+	}
+	for _, folder := range previousByPath {
+		sizeGrowth, filesGrowth := -folder.Size, -folder.Files
+		compared = append(compared, FolderUsage{Path: folder.Path, SizeGrowth: &sizeGrowth, FilesGrowth: &filesGrowth})
+	}
+	return compared
+}
+
+func loadSnapshot(snapshotPath, folderPath string, depth int) (*UsageSnapshot, error) {
+	content, err := os.ReadFile(snapshotPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	snapshot := new(UsageSnapshot)
+	if err = json.Unmarshal(content, snapshot); err != nil {
+		return nil, errorutils.CheckErrorf("failed reading the usage snapshot from '%s': %s", snapshotPath, err.Error())
+	}
+	if snapshot.Path != folderPath || snapshot.Depth != depth {
+		return nil, errorutils.CheckErrorf("the usage snapshot in '%s' was taken of '%s' with depth %d, and can't be compared to '%s' with depth %d",
+			snapshotPath, snapshot.Path, snapshot.Depth, folderPath, depth)
+	}
+	return snapshot, nil
+}
+
+func saveSnapshot(snapshotPath string, snapshot *UsageSnapshot) error {
+	content, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(snapshotPath)); err != nil {
+		return err
+	}
+	if err = os.WriteFile(snapshotPath, content, 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("Saved the usage snapshot to", snapshotPath)
+	return nil
+}
+
+func (duc *DiskUsageCommand) printUsage(compared bool) error {
+	switch duc.format {
+	case commandsutils.Json:
+		return commandsutils.PrintJson(duc.folders)
+	case commandsutils.Csv:
+		var output strings.Builder
+		writer := csv.NewWriter(&output)
+		header := []string{"path", "size", "files"}
+		if compared {
+			header = append(header, "size_growth", "files_growth")
+		}
+		_ = writer.Write(header)
+		for _, folder := range duc.folders {
+			row := []string{folder.Path, strconv.FormatInt(folder.Size, 10), strconv.Itoa(folder.Files)}
+			if compared {
+				row = append(row, strconv.FormatInt(*folder.SizeGrowth, 10), strconv.Itoa(*folder.FilesGrowth))
+			}
+			_ = writer.Write(row)
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(strings.TrimSuffix(output.String(), "\n"))
+	default:
+		log.Output(formatUsageTable(duc.folders, compared))
+	}
+	return nil
+}
+
+func formatUsageTable(folders []FolderUsage, compared bool) string {
+	var output strings.Builder
+	writer := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	if compared {
+		_, _ = fmt.Fprintln(writer, "SIZE\tSIZE GROWTH\tFILES\tFILES GROWTH\tPATH")
+	} else {
+		_, _ = fmt.Fprintln(writer, "SIZE\tFILES\tPATH")
+	}
+	for _, folder := range folders {
+		size := utils.ConvertIntToStorageSizeString(folder.Size)
+		if compared {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\t%d\t%+d\t%s\n", size, formatSizeGrowth(*folder.SizeGrowth), folder.Files, *folder.FilesGrowth, folder.Path)
+		} else {
+			_, _ = fmt.Fprintf(writer, "%s\t%d\t%s\n", size, folder.Files, folder.Path)
+		}
+	}
+	_ = writer.Flush()
+	return strings.TrimSuffix(output.String(), "\n")
+}
+
+func formatSizeGrowth(growth int64) string {
+	if growth < 0 {
+		return "-" + utils.ConvertIntToStorageSizeString(-growth)
+	}
+	return "+" + utils.ConvertIntToStorageSizeString(growth)
+}
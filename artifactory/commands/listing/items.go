@@ -0,0 +1,109 @@
+package listing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The number of items fetched by a single AQL query. Huge folders are listed page by page.
+const pageSize = 1000
+
+// A folder in Artifactory, split to its repository and its path inside the repository.
+// The path of the repository root is ".", like in AQL.
+type folder struct {
+	repo string
+	path string
+}
+
+func newFolder(folderPath string) folder {
+	repo, pathInRepo, _ := strings.Cut(strings.Trim(folderPath, "/"), "/")
+	if pathInRepo == "" {
+		pathInRepo = "."
+	}
+	return folder{repo: repo, path: pathInRepo}
+}
+
+func (f folder) String() string {
+	if f.path == "." {
+		return f.repo
+	}
+	return f.repo + "/" + f.path
+}
+
+// Returns the path of the item relative to the folder.
+func (f folder) relativePath(item *servicesutils.ResultItem) string {
+	itemPath := path.Join(item.Path, item.Name)
+	if f.path == "." {
+		return itemPath
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(itemPath, f.path), "/")
+}
+
+// Returns the AQL criteria of the items in the folder. If recursive, the items in its sub-folders are included as well.
+func (f folder) criteria(recursive bool) string {
+	criteria := fmt.Sprintf(`"repo":%q,"type":"any"`, f.repo)
+	switch {
+	case !recursive:
+		criteria += fmt.Sprintf(`,"path":%q`, f.path)
+	case f.path != ".":
+		criteria += fmt.Sprintf(`,"$or":[{"path":%q},{"path":{"$match":%q}}]`, f.path, f.path+"/*")
+	}
+	return "{" + criteria + "}"
+}
+
+// Searches the items matching the AQL query page by page, and calls handlePage with the items of each page.
+// The repository root, which AQL returns as an item named ".", is skipped.
+func searchItems(servicesManager artifactory.ArtifactoryServicesManager, aql string, handlePage func(items []servicesutils.ResultItem) error) error {
+	for offset := 0; ; offset += pageSize {
+		items, err := execAql(servicesManager, fmt.Sprintf("%s.offset(%d).limit(%d)", aql, offset, pageSize))
+		if err != nil {
+			return err
+		}
+		pageItems := make([]servicesutils.ResultItem, 0, len(items))
+		for _, item := range items {
+			if item.Name != "." {
+				pageItems = append(pageItems, item)
+			}
+		}
+		if err = handlePage(pageItems); err != nil {
+			return err
+		}
+		if len(items) < pageSize {
+			return nil
+		}
+	}
+}
+
+func execAql(servicesManager artifactory.ArtifactoryServicesManager, aql string) (items []servicesutils.ResultItem, err error) {
+	log.Debug("Searching Artifactory using AQL query:", aql)
+	stream, err := servicesManager.Aql(aql)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(stream.Close()))
+	}()
+	result := new(servicesutils.AqlSearchResult)
+	if err = json.NewDecoder(stream).Decode(result); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return result.Results, nil
+}
+
+// Returns true if the path is a folder, and false if it's a file. Fails if the path doesn't exist.
+func isFolder(servicesManager artifactory.ArtifactoryServicesManager, itemPath string) (bool, error) {
+	info, err := servicesManager.FolderInfo(itemPath)
+	if err != nil {
+		return false, err
+	}
+	// The storage API returns the children of folders only.
+	return info.Children != nil, nil
+}
@@ -0,0 +1,158 @@
+package listing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	commandsutils "github.com/jfrog/jfrog-cli/artifactory/commands/utils"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pagingRegexp = regexp.MustCompile(`\.offset\((\d+)\)\.limit\((\d+)\)$`)
+
+// Serves the items by the offset and limit of the AQL queries, and the storage API of the folders.
+func createServer(t *testing.T, items []servicesutils.ResultItem, folders ...string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, folder := range folders {
+			if r.URL.Path == "/api/storage/"+folder {
+				_, _ = w.Write([]byte(`{"repo":"repo","path":"/","children":[]}`))
+				return
+			}
+		}
+		if r.URL.Path != "/api/search/aql" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		paging := pagingRegexp.FindStringSubmatch(string(body))
+		require.Len(t, paging, 3)
+		offset, err := strconv.Atoi(paging[1])
+		require.NoError(t, err)
+		limit, err := strconv.Atoi(paging[2])
+		require.NoError(t, err)
+		page := []servicesutils.ResultItem{}
+		if offset < len(items) {
+			page = items[offset:]
+		}
+		if len(page) > limit {
+			page = page[:limit]
+		}
+		content, err := json.Marshal(servicesutils.AqlSearchResult{Results: page})
+		require.NoError(t, err)
+		_, _ = w.Write(content)
+	}))
+}
+
+func TestFolderCriteria(t *testing.T) {
+	assert.Equal(t, `{"repo":"repo","type":"any","path":"."}`, newFolder("repo/").criteria(false))
+	assert.Equal(t, `{"repo":"repo","type":"any"}`, newFolder("repo").criteria(true))
+	assert.Equal(t, `{"repo":"repo","type":"any","$or":[{"path":"a/b"},{"path":{"$match":"a/b/*"}}]}`, newFolder("/repo/a/b/").criteria(true))
+	assert.Equal(t, "c/d.txt", newFolder("repo/a/b").relativePath(&servicesutils.ResultItem{Path: "a/b/c", Name: "d.txt"}))
+	assert.Equal(t, "a/b", newFolder("repo").relativePath(&servicesutils.ResultItem{Path: "a", Name: "b"}))
+
+	listCommand := NewListCommand().SetRecursive(false).SetSortField(SortBySize)
+	assert.Equal(t, `items.find({"repo":"repo","type":"any","path":"a"}).include(`+includedFields+`).sort({"$desc":["size","path","name"]})`,
+		listCommand.createAql(newFolder("repo/a")))
+}
+
+func TestSearchItemsPaging(t *testing.T) {
+	items := []servicesutils.ResultItem{{Repo: "repo", Path: ".", Name: ".", Type: "folder"}}
+	for i := 0; i < pageSize*2; i++ {
+		items = append(items, servicesutils.ResultItem{Repo: "repo", Path: ".", Name: fmt.Sprintf("file%d", i), Type: "file"})
+	}
+	server := createServer(t, items)
+	defer server.Close()
+	servicesManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, 0, false)
+	require.NoError(t, err)
+
+	var pages []int
+	err = searchItems(servicesManager, `items.find({"repo":"repo"})`, func(items []servicesutils.ResultItem) error {
+		pages = append(pages, len(items))
+		return nil
+	})
+	require.NoError(t, err)
+	// The repository root is skipped.
+	assert.Equal(t, []int{pageSize - 1, pageSize, 1}, pages)
+}
+
+func TestTree(t *testing.T) {
+	items := []servicesutils.ResultItem{
+		{Repo: "repo", Path: "libs", Name: "a.jar", Type: "file", Size: 1024},
+		{Repo: "repo", Path: "libs", Name: "empty", Type: "folder"},
+		{Repo: "repo", Path: "libs", Name: "nested", Type: "folder"},
+		{Repo: "repo", Path: "libs/nested", Name: "b.jar", Type: "file", Size: 2048},
+		{Repo: "repo", Path: "libs/nested/deep", Name: "c.jar", Type: "file", Size: 4096},
+	}
+	server := createServer(t, items, "repo/libs")
+	defer server.Close()
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/"}
+
+	treeCommand := NewTreeCommand().SetServerDetails(serverDetails).SetPath("repo/libs")
+	require.NoError(t, treeCommand.Run())
+	assert.Equal(t, `repo/libs/ (7.0KB, 3 files)
+├── a.jar (1.0KB)
+├── empty/ (0.0KB, 0 files)
+└── nested/ (6.0KB, 2 files)
+    ├── b.jar (2.0KB)
+    └── deep/ (4.0KB, 1 files)
+        └── c.jar (4.0KB)`, treeCommand.root.String())
+
+	// The sizes below the depth limit are rolled up to the folders at the limit.
+	treeCommand = NewTreeCommand().SetServerDetails(serverDetails).SetPath("repo/libs").SetDepth(1)
+	require.NoError(t, treeCommand.Run())
+	assert.Equal(t, `repo/libs/ (7.0KB, 3 files)
+├── a.jar (1.0KB)
+├── empty/ (0.0KB, 0 files)
+└── nested/ (6.0KB, 2 files)`, treeCommand.root.String())
+
+	assert.ErrorContains(t, NewTreeCommand().SetServerDetails(serverDetails).SetPath("repo/missing").Run(), "404")
+}
+
+func TestDiskUsage(t *testing.T) {
+	items := []servicesutils.ResultItem{
+		{Repo: "repo", Path: "libs", Name: "a.jar", Type: "file", Size: 1024},
+		{Repo: "repo", Path: "libs", Name: "nested", Type: "folder"},
+		{Repo: "repo", Path: "libs", Name: "old", Type: "folder"},
+		{Repo: "repo", Path: "libs/nested", Name: "b.jar", Type: "file", Size: 2048},
+		{Repo: "repo", Path: "libs/nested/deep", Name: "c.jar", Type: "file", Size: 4096},
+	}
+	server := createServer(t, items, "repo/libs")
+	defer server.Close()
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/"}
+	snapshotPath := filepath.Join(t.TempDir(), "snapshots", "libs.json")
+
+	duCommand := NewDiskUsageCommand().SetServerDetails(serverDetails).SetPath("repo/libs").SetSnapshotPath(snapshotPath)
+	require.NoError(t, duCommand.Run())
+	assert.Equal(t, []FolderUsage{{Path: "repo/libs", Size: 7168, Files: 3}, {Path: "repo/libs/nested", Size: 6144, Files: 2},
+		{Path: "repo/libs/old", Size: 0, Files: 0}}, duCommand.Folders())
+	assert.Equal(t, `SIZE   FILES  PATH
+7.0KB  3      repo/libs
+6.0KB  2      repo/libs/nested
+0.0KB  0      repo/libs/old`, formatUsageTable(duCommand.Folders(), false))
+
+	// Compare to a snapshot in which 'old' had files and 'nested' didn't exist.
+	previous := UsageSnapshot{Path: "repo/libs", Depth: 1, Folders: []FolderUsage{{Path: "repo/libs", Size: 3072, Files: 2}, {Path: "repo/libs/old", Size: 2048, Files: 1}}}
+	require.NoError(t, saveSnapshot(snapshotPath, &previous))
+	duCommand = NewDiskUsageCommand().SetServerDetails(serverDetails).SetPath("repo/libs").SetComparePath(snapshotPath).SetFormat(commandsutils.Csv)
+	require.NoError(t, duCommand.Run())
+	assert.Equal(t, `SIZE   SIZE GROWTH  FILES  FILES GROWTH  PATH
+7.0KB  +4.0KB       3      +1            repo/libs
+6.0KB  +6.0KB       2      +2            repo/libs/nested
+0.0KB  -2.0KB       0      -1            repo/libs/old`, formatUsageTable(duCommand.Folders(), true))
+
+	// Snapshots of other paths or depths can't be compared.
+	err := NewDiskUsageCommand().SetServerDetails(serverDetails).SetPath("repo/libs").SetDepth(2).SetComparePath(snapshotPath).Run()
+	assert.ErrorContains(t, err, "was taken of 'repo/libs' with depth 1")
+}
@@ -0,0 +1,183 @@
+package listing
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type SortField string
+
+const (
+	SortByName     SortField = "name"
+	SortBySize     SortField = "size"
+	SortByModified SortField = "modified"
+	SortByCreated  SortField = "created"
+
+	// The time format of the long listing.
+	timeFormat = "2006-01-02 15:04"
+	// The width of the sizes column of the long listing, which is aligned to the right.
+	sizeWidth = 12
+
+	includedFields = `"repo","path","name","type","size","modified","created","created_by"`
+)
+
+func GetSortField(sortField string) (SortField, error) {
+	switch SortField(sortField) {
+	case SortByName, SortBySize, SortByModified, SortByCreated:
+		return SortField(sortField), nil
+	case "":
+		return SortByName, nil
+	default:
+		return "", errorutils.CheckErrorf("unsupported sort field '%s'. Possible values are: %s, %s, %s and %s", sortField, SortByName, SortBySize, SortByModified, SortByCreated)
+	}
+}
+
+// Lists the items in a folder in Artifactory, like the ls command of the local file system.
+// The items are sorted by Artifactory and printed page by page, so that huge folders aren't kept in memory.
+type ListCommand struct {
+	serverDetails      *config.ServerDetails
+	path               string
+	long               bool
+	recursive          bool
+	sortField          SortField
+	retries            int
+	retryWaitMilliSecs int
+}
+
+func NewListCommand() *ListCommand {
+	return &ListCommand{sortField: SortByName}
+}
+
+func (lc *ListCommand) SetServerDetails(serverDetails *config.ServerDetails) *ListCommand {
+	lc.serverDetails = serverDetails
+	return lc
+}
+
+// The path to list, in the following format: <repository name>/<repository path>.
+func (lc *ListCommand) SetPath(path string) *ListCommand {
+	lc.path = path
+	return lc
+}
+
+// Lists the size, modification time and creator of each item.
+func (lc *ListCommand) SetLong(long bool) *ListCommand {
+	lc.long = long
+	return lc
+}
+
+func (lc *ListCommand) SetRecursive(recursive bool) *ListCommand {
+	lc.recursive = recursive
+	return lc
+}
+
+func (lc *ListCommand) SetSortField(sortField SortField) *ListCommand {
+	lc.sortField = sortField
+	return lc
+}
+
+func (lc *ListCommand) SetRetries(retries int) *ListCommand {
+	lc.retries = retries
+	return lc
+}
+
+func (lc *ListCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *ListCommand {
+	lc.retryWaitMilliSecs = retryWaitMilliSecs
+	return lc
+}
+
+func (lc *ListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return lc.serverDetails, nil
+}
+
+func (lc *ListCommand) CommandName() string {
+	return "rt_ls"
+}
+
+func (lc *ListCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(lc.serverDetails, lc.retries, lc.retryWaitMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	listed := newFolder(lc.path)
+	if listed.path != "." {
+		isFolder, err := isFolder(servicesManager, listed.String())
+		if err != nil {
+			return err
+		}
+		if !isFolder {
+			return lc.listFile(servicesManager, listed)
+		}
+	}
+	return searchItems(servicesManager, lc.createAql(listed), func(items []servicesutils.ResultItem) error {
+		lc.printItems(listed, items)
+		return nil
+	})
+}
+
+// Lists a single file, like ls does when its argument is a file.
+func (lc *ListCommand) listFile(servicesManager artifactory.ArtifactoryServicesManager, file folder) error {
+	dir, name := path.Split(file.path)
+	parent := folder{repo: file.repo, path: strings.TrimSuffix(dir, "/")}
+	if parent.path == "" {
+		parent.path = "."
+	}
+	items, err := execAql(servicesManager, fmt.Sprintf(`items.find({"repo":%q,"path":%q,"name":%q}).include(%s)`, file.repo, parent.path, name, includedFields))
+	if err != nil {
+		return err
+	}
+	lc.printItems(parent, items)
+	return nil
+}
+
+func (lc *ListCommand) createAql(listed folder) string {
+	// Sizes and times are sorted in a descending order, like ls -S and ls -t.
+	sort := `{"$asc":["path","name"]}`
+	if lc.sortField != SortByName {
+		sort = fmt.Sprintf(`{"$desc":[%q,"path","name"]}`, lc.sortField)
+	}
+	return fmt.Sprintf(`items.find(%s).include(%s).sort(%s)`, listed.criteria(lc.recursive), includedFields, sort)
+}
+
+func (lc *ListCommand) printItems(listed folder, items []servicesutils.ResultItem) {
+	if len(items) == 0 {
+		return
+	}
+	var output strings.Builder
+	writer := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	for i := range items {
+		name := listed.relativePath(&items[i])
+		if items[i].Type == "folder" {
+			name += "/"
+		}
+		if !lc.long {
+			output.WriteString(name + "\n")
+			continue
+		}
+		size := "-"
+		if items[i].Type != "folder" {
+			size = strconv.FormatInt(items[i].Size, 10)
+		}
+		_, _ = fmt.Fprintf(writer, "%*s  %s\t%s\t%s\n", sizeWidth, size, formatTime(items[i].Modified), items[i].CreatedBy, name)
+	}
+	_ = writer.Flush()
+	log.Output(strings.TrimSuffix(output.String(), "\n"))
+}
+
+func formatTime(aqlTime string) string {
+	parsed, err := time.Parse(time.RFC3339, aqlTime)
+	if err != nil {
+		return aqlTime
+	}
+	return parsed.Local().Format(timeFormat)
+}
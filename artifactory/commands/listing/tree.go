@@ -0,0 +1,175 @@
+package listing
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Prints the items under a folder in Artifactory as a tree, like the tree command of the local file system.
+// Each folder shows the total size and number of the files under it, including the files below the depth limit.
+type TreeCommand struct {
+	serverDetails      *config.ServerDetails
+	path               string
+	depth              int
+	retries            int
+	retryWaitMilliSecs int
+	root               *treeNode
+}
+
+type treeNode struct {
+	name     string
+	isFolder bool
+	size     int64
+	files    int
+	children map[string]*treeNode
+}
+
+func newTreeNode(name string, isFolder bool) *treeNode {
+	return &treeNode{name: name, isFolder: isFolder, children: make(map[string]*treeNode)}
+}
+
+func NewTreeCommand() *TreeCommand {
+	return &TreeCommand{}
+}
+
+func (tc *TreeCommand) SetServerDetails(serverDetails *config.ServerDetails) *TreeCommand {
+	tc.serverDetails = serverDetails
+	return tc
+}
+
+// The path of the tree's root folder, in the following format: <repository name>/<repository path>.
+func (tc *TreeCommand) SetPath(path string) *TreeCommand {
+	tc.path = path
+	return tc
+}
+
+// The number of levels printed below the root folder. Zero prints all levels.
+func (tc *TreeCommand) SetDepth(depth int) *TreeCommand {
+	tc.depth = depth
+	return tc
+}
+
+func (tc *TreeCommand) SetRetries(retries int) *TreeCommand {
+	tc.retries = retries
+	return tc
+}
+
+func (tc *TreeCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *TreeCommand {
+	tc.retryWaitMilliSecs = retryWaitMilliSecs
+	return tc
+}
+
+func (tc *TreeCommand) ServerDetails() (*config.ServerDetails, error) {
+	return tc.serverDetails, nil
+}
+
+func (tc *TreeCommand) CommandName() string {
+	return "rt_tree"
+}
+
+func (tc *TreeCommand) Run() error {
+	if tc.depth < 0 {
+		return errorutils.CheckErrorf("the depth must be zero or a positive number")
+	}
+	servicesManager, err := utils.CreateServiceManager(tc.serverDetails, tc.retries, tc.retryWaitMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	if tc.root, err = searchTree(servicesManager, newFolder(tc.path), tc.depth); err != nil {
+		return err
+	}
+	log.Output(tc.root.String())
+	return nil
+}
+
+// Searches all the items under the folder and returns them as a tree, with nodes up to the given depth.
+// The sizes of the files below the depth are rolled up to the folders above them. Zero depth creates nodes for all levels.
+func searchTree(servicesManager artifactory.ArtifactoryServicesManager, root folder, depth int) (*treeNode, error) {
+	if root.path != "." {
+		isFolder, err := isFolder(servicesManager, root.String())
+		if err != nil {
+			return nil, err
+		}
+		if !isFolder {
+			return nil, errorutils.CheckErrorf("'%s' is not a folder", root)
+		}
+	}
+	rootNode := newTreeNode(root.String(), true)
+	levels := depth
+	if levels == 0 {
+		levels = math.MaxInt
+	}
+	aql := fmt.Sprintf(`items.find(%s).include("repo","path","name","type","size").sort({"$asc":["path","name"]})`, root.criteria(true))
+	err := searchItems(servicesManager, aql, func(items []servicesutils.ResultItem) error {
+		for i := range items {
+			rootNode.add(strings.Split(root.relativePath(&items[i]), "/"), items[i].Type == "folder", items[i].Size, levels)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rootNode, nil
+}
+
+// Adds an item by its path segments relative to the node. The size of a file is rolled up to all the folders above it,
+// but nodes are created only for the given number of levels below the node.
+func (node *treeNode) add(segments []string, isFolder bool, size int64, levels int) {
+	if !isFolder {
+		node.size += size
+		node.files++
+	}
+	if len(segments) == 0 || levels == 0 {
+		return
+	}
+	node.child(segments[0], isFolder || len(segments) > 1).add(segments[1:], isFolder, size, levels-1)
+}
+
+func (node *treeNode) child(name string, isFolder bool) *treeNode {
+	child, exists := node.children[name]
+	if !exists {
+		child = newTreeNode(name, isFolder)
+		node.children[name] = child
+	}
+	return child
+}
+
+func (node *treeNode) String() string {
+	var output strings.Builder
+	output.WriteString(node.label())
+	node.writeChildren(&output, "")
+	return output.String()
+}
+
+func (node *treeNode) label() string {
+	if !node.isFolder {
+		return fmt.Sprintf("%s (%s)", node.name, utils.ConvertIntToStorageSizeString(node.size))
+	}
+	return fmt.Sprintf("%s/ (%s, %d files)", node.name, utils.ConvertIntToStorageSizeString(node.size), node.files)
+}
+
+func (node *treeNode) writeChildren(output *strings.Builder, prefix string) {
+	names := make([]string, 0, len(node.children))
+	for name := range node.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		connector, childPrefix := "├── ", "│   "
+		if i == len(names)-1 {
+			connector, childPrefix = "└── ", "    "
+		}
+		child := node.children[name]
+		output.WriteString("\n" + prefix + connector + child.label())
+		child.writeChildren(output, prefix+childPrefix)
+	}
+}
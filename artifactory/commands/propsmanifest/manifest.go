@@ -0,0 +1,223 @@
+package propsmanifest
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type TargetType string
+
+const (
+	PathTarget   TargetType = "path"
+	Sha1Target   TargetType = "sha1"
+	Sha256Target TargetType = "sha256"
+
+	propsSeparator       = ";"
+	multiValuesSeparator = ","
+)
+
+var targetTypes = []TargetType{PathTarget, Sha1Target, Sha256Target}
+
+// A manifest row, setting properties on the artifact in a path, or on all the artifacts with a checksum.
+type Row struct {
+	// The number of the row in the manifest, starting from 1. The header row of a CSV manifest isn't counted.
+	Number     int
+	TargetType TargetType
+	Target     string
+	// The properties to set, in the form of key1=value1;key2=value2,...
+	Props string
+}
+
+// An entry of a JSON manifest. A property value may be a single value or a list of values.
+type jsonRow struct {
+	Path   string                `json:"path,omitempty"`
+	Sha1   string                `json:"sha1,omitempty"`
+	Sha256 string                `json:"sha256,omitempty"`
+	Props  map[string]propValues `json:"props,omitempty"`
+}
+
+type propValues []string
+
+func (pv *propValues) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*pv = propValues{value}
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return errors.New("a property value must be a string or a list of strings")
+	}
+	*pv = values
+	return nil
+}
+
+// Reads a CSV or JSON manifest, according to the file extension.
+func ReadManifest(manifestPath string) ([]*Row, error) {
+	file, err := os.Open(manifestPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	switch strings.ToLower(filepath.Ext(manifestPath)) {
+	case ".csv":
+		return parseCsvManifest(file)
+	case ".json":
+		return parseJsonManifest(file)
+	default:
+		return nil, errorutils.CheckErrorf("unsupported manifest file '%s'. The manifest should be a CSV file with a .csv extension or a JSON file with a .json extension", manifestPath)
+	}
+}
+
+// The header of a CSV manifest contains a single target column, named path, sha1 or sha256.
+// Each of the other columns is a property key. Empty cells are skipped, and multiple values in a cell are separated by commas.
+func parseCsvManifest(reader io.Reader) ([]*Row, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, errorutils.CheckErrorf("the manifest is empty")
+	}
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	targetColumn := -1
+	for i, column := range header {
+		header[i] = strings.TrimSpace(column)
+		if isTargetType(header[i]) {
+			if targetColumn >= 0 {
+				return nil, errorutils.CheckErrorf("the manifest header should contain a single target column, but both '%s' and '%s' were found", header[targetColumn], header[i])
+			}
+			targetColumn = i
+		}
+	}
+	if targetColumn < 0 {
+		return nil, errorutils.CheckErrorf("the manifest header should contain one of the following target columns: %s", joinTargetTypes())
+	}
+	var rows []*Row
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		var props []string
+		for i, value := range record {
+			if i != targetColumn && value != "" {
+				props = append(props, header[i]+"="+escapePropsSeparator(value))
+			}
+		}
+		row := &Row{Number: len(rows) + 1, TargetType: TargetType(header[targetColumn]), Target: strings.TrimSpace(record[targetColumn]), Props: strings.Join(props, propsSeparator)}
+		if err = row.validate(); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+// A JSON manifest is an array of objects, each with a path, sha1 or sha256 field, and a props object.
+func parseJsonManifest(reader io.Reader) ([]*Row, error) {
+	var jsonRows []jsonRow
+	if err := json.NewDecoder(reader).Decode(&jsonRows); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the manifest: %s", err.Error())
+	}
+	rows := make([]*Row, len(jsonRows))
+	for i, jsonRow := range jsonRows {
+		row := &Row{Number: i + 1, Props: toPropsString(jsonRow.Props)}
+		for targetType, target := range map[TargetType]string{PathTarget: jsonRow.Path, Sha1Target: jsonRow.Sha1, Sha256Target: jsonRow.Sha256} {
+			if target == "" {
+				continue
+			}
+			if row.TargetType != "" {
+				return nil, errorutils.CheckErrorf("row %d of the manifest should contain a single target field, one of: %s", row.Number, joinTargetTypes())
+			}
+			row.TargetType, row.Target = targetType, target
+		}
+		if err := row.validate(); err != nil {
+			return nil, err
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// Each value of a JSON manifest is taken as is, so commas and semicolons in the values are escaped.
+func toPropsString(props map[string]propValues) string {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var propsStrings []string
+	for _, key := range keys {
+		var values []string
+		for _, value := range props[key] {
+			if value != "" {
+				values = append(values, strings.ReplaceAll(escapePropsSeparator(value), multiValuesSeparator, `\`+multiValuesSeparator))
+			}
+		}
+		if len(values) > 0 {
+			propsStrings = append(propsStrings, key+"="+strings.Join(values, multiValuesSeparator))
+		}
+	}
+	return strings.Join(propsStrings, propsSeparator)
+}
+
+func escapePropsSeparator(value string) string {
+	return strings.ReplaceAll(value, propsSeparator, `\`+propsSeparator)
+}
+
+func (row *Row) validate() error {
+	switch row.TargetType {
+	case "":
+		return errorutils.CheckErrorf("row %d of the manifest should contain one of the following targets: %s", row.Number, joinTargetTypes())
+	case PathTarget:
+		row.Target = strings.TrimPrefix(row.Target, "/")
+		if !strings.Contains(row.Target, "/") || strings.HasSuffix(row.Target, "/") {
+			return errorutils.CheckErrorf("row %d of the manifest contains an invalid artifact path '%s'. The path should be in the form of <repository>/<path in repository>", row.Number, row.Target)
+		}
+	case Sha1Target, Sha256Target:
+		row.Target = strings.ToLower(row.Target)
+		expectedLength := 40
+		if row.TargetType == Sha256Target {
+			expectedLength = 64
+		}
+		if _, err := hex.DecodeString(row.Target); err != nil || len(row.Target) != expectedLength {
+			return errorutils.CheckErrorf("row %d of the manifest contains an invalid %s checksum '%s'", row.Number, row.TargetType, row.Target)
+		}
+	}
+	if row.Props == "" {
+		return errorutils.CheckErrorf("row %d of the manifest doesn't contain any properties to set", row.Number)
+	}
+	return nil
+}
+
+func isTargetType(column string) bool {
+	for _, targetType := range targetTypes {
+		if TargetType(column) == targetType {
+			return true
+		}
+	}
+	return false
+}
+
+func joinTargetTypes() string {
+	names := make([]string, len(targetTypes))
+	for i, targetType := range targetTypes {
+		names[i] = string(targetType)
+	}
+	return strings.Join(names, ", ")
+}
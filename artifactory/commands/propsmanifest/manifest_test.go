@@ -0,0 +1,114 @@
+package propsmanifest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSha1   = "5ba93c9db0cff93f52b521d7420e43f6eda2784f"
+	testSha256 = "6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d"
+)
+
+func TestParseCsvManifest(t *testing.T) {
+	manifest := "path, triage.status, triage.owner\n" +
+		"/libs/app/1.0/app.jar,ignored,security-team\n" +
+		"libs/app.jar,\"fixed;verified\",\n"
+	rows, err := parseCsvManifest(strings.NewReader(manifest))
+	assert.NoError(t, err)
+	assert.Equal(t, []*Row{
+		{Number: 1, TargetType: PathTarget, Target: "libs/app/1.0/app.jar", Props: "triage.status=ignored;triage.owner=security-team"},
+		{Number: 2, TargetType: PathTarget, Target: "libs/app.jar", Props: `triage.status=fixed\;verified`},
+	}, rows)
+}
+
+func TestParseCsvManifestErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		errorMsg string
+	}{
+		{"empty", "", "the manifest is empty"},
+		{"noTarget", "repo,status\nlibs,fixed\n", "target columns"},
+		{"multipleTargets", "path,sha1,status\na/b," + testSha1 + ",fixed\n", "single target column"},
+		{"invalidPath", "path,status\napp.jar,fixed\n", "invalid artifact path"},
+		{"invalidChecksum", "sha256,status\n" + testSha1 + ",fixed\n", "invalid sha256 checksum"},
+		{"noProps", "sha1,status\n" + testSha1 + ",\n", "row 1 of the manifest doesn't contain any properties"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseCsvManifest(strings.NewReader(test.manifest))
+			assert.ErrorContains(t, err, test.errorMsg)
+		})
+	}
+}
+
+func TestParseJsonManifest(t *testing.T) {
+	manifest := `[
+		{"path": "libs/app/1.0/app.jar", "props": {"triage.status": "ignored", "triage.owner": "security-team"}},
+		{"sha1": "` + strings.ToUpper(testSha1) + `", "props": {"cve": ["CVE-2023-1", "CVE-2023-2"]}},
+		{"sha256": "` + testSha256 + `", "props": {"note": "a,b;c"}}
+	]`
+	rows, err := parseJsonManifest(strings.NewReader(manifest))
+	assert.NoError(t, err)
+	assert.Equal(t, []*Row{
+		{Number: 1, TargetType: PathTarget, Target: "libs/app/1.0/app.jar", Props: "triage.owner=security-team;triage.status=ignored"},
+		{Number: 2, TargetType: Sha1Target, Target: testSha1, Props: "cve=CVE-2023-1,CVE-2023-2"},
+		{Number: 3, TargetType: Sha256Target, Target: testSha256, Props: `note=a\,b\;c`},
+	}, rows)
+
+	_, err = parseJsonManifest(strings.NewReader(`[{"path": "libs/app.jar", "sha1": "` + testSha1 + `", "props": {"a": "b"}}]`))
+	assert.ErrorContains(t, err, "single target field")
+	_, err = parseJsonManifest(strings.NewReader(`[{"path": "libs/app.jar", "props": {"a": 1}}]`))
+	assert.ErrorContains(t, err, "failed parsing the manifest")
+}
+
+func TestCreateChecksumsAql(t *testing.T) {
+	assert.Equal(t, `items.find({"type":"file","$or":[{"actual_sha1":"1"},{"actual_sha1":"2"}]}).include("repo","path","name","type","actual_sha1","sha256")`,
+		createChecksumsAql(Sha1Target, []string{"1", "2"}))
+}
+
+func TestGroupRowsByProps(t *testing.T) {
+	rows := []*Row{{Props: "a=1"}, {Props: "b=2"}, {Props: "a=1"}, {Props: "c=3"}, {Props: "b=2"}}
+	assert.Equal(t, [][]int{{0, 2}, {1, 4}, {3}}, groupRowsByProps(rows))
+}
+
+func TestSetPropsManifest(t *testing.T) {
+	// Fails setting the properties of one artifact.
+	var requestsMutex sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestsMutex.Lock()
+		requests = append(requests, r.URL.Path+"?"+r.URL.Query().Get("properties"))
+		requestsMutex.Unlock()
+		if r.URL.Path == "/api/storage/libs/missing.jar" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	manifestPath := filepath.Join(t.TempDir(), "manifest.csv")
+	require.NoError(t, os.WriteFile(manifestPath, []byte("path,status\nlibs/a.jar,fixed\nlibs/b.jar,ignored\nlibs/c.jar,fixed\nlibs/missing.jar,ignored\n"), 0600))
+
+	command := NewSetPropsManifestCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).SetManifestPath(manifestPath)
+	assert.ErrorContains(t, command.Run(), "failed setting the properties of 1 out of 4 manifest rows")
+	assert.Equal(t, 3, command.SuccessCount())
+	assert.Equal(t, 1, command.FailCount())
+	var statuses []string
+	for _, result := range command.Results() {
+		statuses = append(statuses, result.Target+":"+result.Status)
+	}
+	assert.Equal(t, []string{"libs/a.jar:succeeded", "libs/b.jar:succeeded", "libs/c.jar:succeeded", "libs/missing.jar:failed"}, statuses)
+	// The rows of 'fixed' are set together. The rows of 'ignored' are set together, and then one by one after the failure.
+	assert.Len(t, requests, 6)
+}
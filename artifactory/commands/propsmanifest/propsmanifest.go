@@ -0,0 +1,314 @@
+package propsmanifest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The maximal number of checksums searched by a single AQL query.
+	checksumsPerQuery = 100
+
+	SucceededStatus = "succeeded"
+	FailedStatus    = "failed"
+)
+
+// The outcome of setting the properties of a single manifest row.
+type RowResult struct {
+	Row       int    `json:"row" col-name:"Row"`
+	Target    string `json:"target" col-name:"Target"`
+	Props     string `json:"props" col-name:"Properties"`
+	Artifacts int    `json:"artifacts" col-name:"Artifacts"`
+	Status    string `json:"status" col-name:"Status"`
+	Error     string `json:"error,omitempty" col-name:"Error"`
+}
+
+// Sets different properties on specific artifacts, according to a CSV or JSON manifest.
+// Each row of the manifest sets its properties on an artifact path, or on all the artifacts with a checksum.
+// Rows which set the same properties are handled together, and the result of each row is reported separately.
+type SetPropsManifestCommand struct {
+	serverDetails      *config.ServerDetails
+	manifestPath       string
+	threads            int
+	retries            int
+	retryWaitMilliSecs int
+	results            []RowResult
+	successCount       int
+	failCount          int
+}
+
+func NewSetPropsManifestCommand() *SetPropsManifestCommand {
+	return &SetPropsManifestCommand{threads: 3}
+}
+
+func (smc *SetPropsManifestCommand) SetServerDetails(serverDetails *config.ServerDetails) *SetPropsManifestCommand {
+	smc.serverDetails = serverDetails
+	return smc
+}
+
+func (smc *SetPropsManifestCommand) SetManifestPath(manifestPath string) *SetPropsManifestCommand {
+	smc.manifestPath = manifestPath
+	return smc
+}
+
+func (smc *SetPropsManifestCommand) SetThreads(threads int) *SetPropsManifestCommand {
+	smc.threads = threads
+	return smc
+}
+
+func (smc *SetPropsManifestCommand) SetRetries(retries int) *SetPropsManifestCommand {
+	smc.retries = retries
+	return smc
+}
+
+func (smc *SetPropsManifestCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *SetPropsManifestCommand {
+	smc.retryWaitMilliSecs = retryWaitMilliSecs
+	return smc
+}
+
+func (smc *SetPropsManifestCommand) ServerDetails() (*config.ServerDetails, error) {
+	return smc.serverDetails, nil
+}
+
+// Returns the results of the manifest rows, in the order of the rows in the manifest.
+func (smc *SetPropsManifestCommand) Results() []RowResult {
+	return smc.results
+}
+
+// Returns the number of rows whose properties were set on all their artifacts.
+func (smc *SetPropsManifestCommand) SuccessCount() int {
+	return smc.successCount
+}
+
+func (smc *SetPropsManifestCommand) FailCount() int {
+	return smc.failCount
+}
+
+func (smc *SetPropsManifestCommand) CommandName() string {
+	return "rt_set_properties_manifest"
+}
+
+func (smc *SetPropsManifestCommand) Run() error {
+	rows, err := ReadManifest(smc.manifestPath)
+	if err != nil {
+		return err
+	}
+	// The properties are set by the services manager, which sets them on the artifacts in parallel using the command's threads.
+	servicesManager, err := utils.CreateServiceManagerWithThreads(smc.serverDetails, false, smc.threads, smc.retries, smc.retryWaitMilliSecs)
+	if err != nil {
+		return err
+	}
+	checksumsArtifacts, err := searchChecksums(servicesManager, rows)
+	if err != nil {
+		return err
+	}
+
+	smc.results = make([]RowResult, len(rows))
+	for _, group := range groupRowsByProps(rows) {
+		smc.setGroupProps(servicesManager, rows, group, checksumsArtifacts)
+	}
+	for _, result := range smc.results {
+		if result.Status == SucceededStatus {
+			smc.successCount++
+		} else {
+			smc.failCount++
+		}
+	}
+	if smc.failCount > 0 {
+		return errorutils.CheckErrorf("failed setting the properties of %d out of %d manifest rows", smc.failCount, len(rows))
+	}
+	return nil
+}
+
+// Groups the indexes of the rows by their properties, in the order of the rows in the manifest.
+func groupRowsByProps(rows []*Row) [][]int {
+	var groups [][]int
+	groupsIndexes := make(map[string]int)
+	for i, row := range rows {
+		groupIndex, exists := groupsIndexes[row.Props]
+		if !exists {
+			groupIndex = len(groups)
+			groupsIndexes[row.Props] = groupIndex
+			groups = append(groups, nil)
+		}
+		groups[groupIndex] = append(groups[groupIndex], i)
+	}
+	return groups
+}
+
+// Sets the properties of a group of rows, which set the same properties, on the artifacts of all the rows at once.
+// If the properties weren't set on all the artifacts, they are set again row by row, to report which of the rows failed.
+func (smc *SetPropsManifestCommand) setGroupProps(servicesManager artifactory.ArtifactoryServicesManager, rows []*Row, group []int, checksumsArtifacts map[string][]servicesutils.ResultItem) {
+	rowsArtifacts := make([][]servicesutils.ResultItem, len(group))
+	var groupArtifacts []servicesutils.ResultItem
+	for i, rowIndex := range group {
+		rowsArtifacts[i] = getRowArtifacts(rows[rowIndex], checksumsArtifacts)
+		groupArtifacts = append(groupArtifacts, rowsArtifacts[i]...)
+	}
+	if len(group) > 1 && len(groupArtifacts) > 0 {
+		succeeded, err := setProps(servicesManager, rows[group[0]].Props, groupArtifacts)
+		if err == nil && succeeded == len(groupArtifacts) {
+			for i, rowIndex := range group {
+				if len(rowsArtifacts[i]) == 0 {
+					smc.results[rowIndex] = setRowProps(servicesManager, rows[rowIndex], nil)
+					continue
+				}
+				smc.results[rowIndex] = newRowResult(rows[rowIndex])
+				smc.results[rowIndex].Artifacts = len(rowsArtifacts[i])
+				smc.results[rowIndex].Status = SucceededStatus
+			}
+			return
+		}
+		log.Debug("Setting the properties of the rows one by one, since they weren't set on all the artifacts of", strconv.Itoa(len(group)), "rows.")
+	}
+	for i, rowIndex := range group {
+		smc.results[rowIndex] = setRowProps(servicesManager, rows[rowIndex], rowsArtifacts[i])
+	}
+}
+
+func getRowArtifacts(row *Row, checksumsArtifacts map[string][]servicesutils.ResultItem) []servicesutils.ResultItem {
+	if row.TargetType != PathTarget {
+		return checksumsArtifacts[getChecksumKey(row.TargetType, row.Target)]
+	}
+	// The artifact is not searched, so a missing artifact fails when its properties are set.
+	repo, relativePath, _ := strings.Cut(row.Target, "/")
+	return []servicesutils.ResultItem{{Repo: repo, Path: path.Dir(relativePath), Name: path.Base(relativePath), Type: "file"}}
+}
+
+// Returns a failed result of the row, which is updated once its properties are set.
+func newRowResult(row *Row) RowResult {
+	result := RowResult{Row: row.Number, Target: string(row.TargetType) + ":" + row.Target, Props: row.Props, Status: FailedStatus}
+	if row.TargetType == PathTarget {
+		result.Target = row.Target
+	}
+	return result
+}
+
+func setRowProps(servicesManager artifactory.ArtifactoryServicesManager, row *Row, artifacts []servicesutils.ResultItem) (result RowResult) {
+	result = newRowResult(row)
+	if len(artifacts) == 0 {
+		result.Error = "no artifacts were found"
+		log.Error("Row", strconv.Itoa(row.Number), "of the manifest:", result.Error, "with", result.Target)
+		return
+	}
+	var err error
+	result.Artifacts, err = setProps(servicesManager, row.Props, artifacts)
+	if err == nil && result.Artifacts < len(artifacts) {
+		err = errors.New("the properties were set on " + strconv.Itoa(result.Artifacts) + " out of " + strconv.Itoa(len(artifacts)) + " artifacts")
+	}
+	if err != nil {
+		result.Error = err.Error()
+		log.Error("Row", strconv.Itoa(row.Number), "of the manifest:", result.Error)
+		return
+	}
+	result.Status = SucceededStatus
+	return
+}
+
+func setProps(servicesManager artifactory.ArtifactoryServicesManager, props string, artifacts []servicesutils.ResultItem) (succeeded int, err error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return
+	}
+	for _, artifact := range artifacts {
+		writer.Write(artifact)
+	}
+	if err = writer.Close(); err != nil {
+		return
+	}
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	propsParams := services.NewPropsParams()
+	propsParams.Reader = reader
+	propsParams.Props = props
+	return servicesManager.SetProps(propsParams)
+}
+
+// Searches the artifacts with the checksums of the manifest rows, and maps them by their checksums.
+func searchChecksums(servicesManager artifactory.ArtifactoryServicesManager, rows []*Row) (map[string][]servicesutils.ResultItem, error) {
+	checksumsArtifacts := make(map[string][]servicesutils.ResultItem)
+	for _, targetType := range []TargetType{Sha1Target, Sha256Target} {
+		var checksums []string
+		for _, row := range rows {
+			if row.TargetType == targetType {
+				checksums = append(checksums, row.Target)
+			}
+		}
+		if len(checksums) > 0 {
+			log.Info("Searching the artifacts of", strconv.Itoa(len(checksums)), "manifest rows by their", string(targetType), "checksums...")
+		}
+		for start := 0; start < len(checksums); start += checksumsPerQuery {
+			end := start + checksumsPerQuery
+			if end > len(checksums) {
+				end = len(checksums)
+			}
+			artifacts, err := execAql(servicesManager, createChecksumsAql(targetType, checksums[start:end]))
+			if err != nil {
+				return nil, err
+			}
+			for _, artifact := range artifacts {
+				checksum := artifact.Sha256
+				if targetType == Sha1Target {
+					checksum = artifact.Actual_Sha1
+				}
+				key := getChecksumKey(targetType, checksum)
+				checksumsArtifacts[key] = append(checksumsArtifacts[key], artifact)
+			}
+		}
+	}
+	return checksumsArtifacts, nil
+}
+
+func getChecksumKey(targetType TargetType, checksum string) string {
+	return string(targetType) + ":" + strings.ToLower(checksum)
+}
+
+func getAqlField(targetType TargetType) string {
+	if targetType == Sha1Target {
+		return "actual_sha1"
+	}
+	return "sha256"
+}
+
+func createChecksumsAql(targetType TargetType, checksums []string) string {
+	conditions := make([]string, len(checksums))
+	for i, checksum := range checksums {
+		conditions[i] = `{"` + getAqlField(targetType) + `":"` + checksum + `"}`
+	}
+	return `items.find({"type":"file","$or":[` + strings.Join(conditions, ",") + `]}).include("repo","path","name","type","actual_sha1","sha256")`
+}
+
+func execAql(servicesManager artifactory.ArtifactoryServicesManager, aql string) (artifacts []servicesutils.ResultItem, err error) {
+	log.Debug("Searching Artifactory using AQL query:", aql)
+	stream, err := servicesManager.Aql(aql)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(stream.Close()))
+	}()
+	body, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	result := new(servicesutils.AqlSearchResult)
+	if err = json.Unmarshal(body, result); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return result.Results, nil
+}
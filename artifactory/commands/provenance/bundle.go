@@ -0,0 +1,115 @@
+package provenance
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	bundleMediaType  = "application/vnd.dev.sigstore.bundle+json;version=0.2"
+	bundleFileSuffix = ".sigstore.json"
+	dsseKind         = "dsse"
+	dsseKindVersion  = "0.0.1"
+)
+
+// A Sigstore bundle of a keyless signature, which contains everything needed to verify it offline.
+// Integers are encoded as strings, and bytes in base64, as in the JSON encoding of the bundle protobuf.
+// See https://github.com/sigstore/protobuf-specs/blob/main/protos/sigstore_bundle.proto
+type bundle struct {
+	MediaType            string `json:"mediaType"`
+	VerificationMaterial struct {
+		X509CertificateChain struct {
+			Certificates []rawBytes `json:"certificates"`
+		} `json:"x509CertificateChain"`
+		TlogEntries []*transparencyLogEntry `json:"tlogEntries"`
+	} `json:"verificationMaterial"`
+	DsseEnvelope *envelope `json:"dsseEnvelope"`
+}
+
+type rawBytes struct {
+	RawBytes []byte `json:"rawBytes"`
+}
+
+type transparencyLogEntry struct {
+	LogIndex string `json:"logIndex"`
+	LogId    struct {
+		KeyId []byte `json:"keyId"`
+	} `json:"logId"`
+	KindVersion struct {
+		Kind    string `json:"kind"`
+		Version string `json:"version"`
+	} `json:"kindVersion"`
+	IntegratedTime   string `json:"integratedTime"`
+	InclusionPromise struct {
+		SignedEntryTimestamp string `json:"signedEntryTimestamp"`
+	} `json:"inclusionPromise"`
+	InclusionProof    *inclusionProof `json:"inclusionProof,omitempty"`
+	CanonicalizedBody string          `json:"canonicalizedBody"`
+}
+
+type inclusionProof struct {
+	LogIndex   string   `json:"logIndex"`
+	RootHash   []byte   `json:"rootHash"`
+	TreeSize   string   `json:"treeSize"`
+	Hashes     [][]byte `json:"hashes"`
+	Checkpoint struct {
+		Envelope string `json:"envelope"`
+	} `json:"checkpoint"`
+}
+
+// A transparency log entry, as returned by the Rekor API.
+// See https://github.com/sigstore/rekor/blob/main/openapi.yaml
+type rekorEntry struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogId          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+	Verification   struct {
+		InclusionProof *struct {
+			Checkpoint string   `json:"checkpoint"`
+			Hashes     []string `json:"hashes"`
+			LogIndex   int64    `json:"logIndex"`
+			RootHash   string   `json:"rootHash"`
+			TreeSize   int64    `json:"treeSize"`
+		} `json:"inclusionProof"`
+		SignedEntryTimestamp string `json:"signedEntryTimestamp"`
+	} `json:"verification"`
+}
+
+func (re *rekorEntry) toTransparencyLogEntry() (*transparencyLogEntry, error) {
+	if re.Verification.SignedEntryTimestamp == "" {
+		return nil, errorutils.CheckErrorf("the transparency log entry %d has no signed entry timestamp", re.LogIndex)
+	}
+	if _, err := base64.StdEncoding.DecodeString(re.Body); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	entry := &transparencyLogEntry{
+		LogIndex:          strconv.FormatInt(re.LogIndex, 10),
+		IntegratedTime:    strconv.FormatInt(re.IntegratedTime, 10),
+		CanonicalizedBody: re.Body,
+	}
+	var err error
+	if entry.LogId.KeyId, err = hex.DecodeString(re.LogId); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	entry.KindVersion.Kind, entry.KindVersion.Version = dsseKind, dsseKindVersion
+	entry.InclusionPromise.SignedEntryTimestamp = re.Verification.SignedEntryTimestamp
+	if proof := re.Verification.InclusionProof; proof != nil {
+		entry.InclusionProof = &inclusionProof{LogIndex: strconv.FormatInt(proof.LogIndex, 10), TreeSize: strconv.FormatInt(proof.TreeSize, 10)}
+		entry.InclusionProof.Checkpoint.Envelope = proof.Checkpoint
+		if entry.InclusionProof.RootHash, err = hex.DecodeString(proof.RootHash); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		for _, hash := range proof.Hashes {
+			decoded, err := hex.DecodeString(hash)
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			entry.InclusionProof.Hashes = append(entry.InclusionProof.Hashes, decoded)
+		}
+	}
+	return entry, nil
+}
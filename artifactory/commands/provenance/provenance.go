@@ -0,0 +1,180 @@
+package provenance
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/buildsbom"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Creates a signed SLSA provenance of a published build, and deploys it to the Artifactory folder of the build
+// artifacts. The provenance is signed with a local private key, or keylessly if no key is provided. Keyless signatures
+// are recorded in the Rekor transparency log, and are deployed as Sigstore bundles.
+type ProvenanceCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *utils.BuildConfiguration
+	buildInfo          *buildinfo.BuildInfo
+	keyPath            string
+	fulcioUrl          string
+	rekorUrl           string
+	retries            int
+	retryWaitMilliSecs int
+	signer             signer
+	deployedPath       string
+}
+
+func NewProvenanceCommand() *ProvenanceCommand {
+	return &ProvenanceCommand{fulcioUrl: DefaultFulcioUrl, rekorUrl: DefaultRekorUrl}
+}
+
+func (pc *ProvenanceCommand) SetServerDetails(serverDetails *config.ServerDetails) *ProvenanceCommand {
+	pc.serverDetails = serverDetails
+	return pc
+}
+
+func (pc *ProvenanceCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *ProvenanceCommand {
+	pc.buildConfiguration = buildConfiguration
+	return pc
+}
+
+// Creates the provenance of this build-info, instead of the build-info published to Artifactory.
+func (pc *ProvenanceCommand) SetBuildInfo(buildInfo *buildinfo.BuildInfo) *ProvenanceCommand {
+	pc.buildInfo = buildInfo
+	return pc
+}
+
+// Path to a PEM file of the private signing key. If empty, the provenance is signed keylessly.
+func (pc *ProvenanceCommand) SetKeyPath(keyPath string) *ProvenanceCommand {
+	pc.keyPath = keyPath
+	return pc
+}
+
+// The Fulcio server which issues the certificates of keyless signatures.
+func (pc *ProvenanceCommand) SetFulcioUrl(fulcioUrl string) *ProvenanceCommand {
+	pc.fulcioUrl = fulcioUrl
+	return pc
+}
+
+// The Rekor transparency log in which keyless signatures are recorded.
+func (pc *ProvenanceCommand) SetRekorUrl(rekorUrl string) *ProvenanceCommand {
+	pc.rekorUrl = rekorUrl
+	return pc
+}
+
+func (pc *ProvenanceCommand) SetRetries(retries int) *ProvenanceCommand {
+	pc.retries = retries
+	return pc
+}
+
+func (pc *ProvenanceCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *ProvenanceCommand {
+	pc.retryWaitMilliSecs = retryWaitMilliSecs
+	return pc
+}
+
+// Returns the path of the deployed provenance in Artifactory, in the following format: <repository name>/<repository path>.
+// The path is empty if the build has no artifacts in Artifactory, since the provenance isn't deployed then.
+func (pc *ProvenanceCommand) DeployedPath() string {
+	return pc.deployedPath
+}
+
+func (pc *ProvenanceCommand) ServerDetails() (*config.ServerDetails, error) {
+	return pc.serverDetails, nil
+}
+
+func (pc *ProvenanceCommand) CommandName() string {
+	return "rt_build_provenance"
+}
+
+// Creates the signer of the provenance, by loading the signing key or getting the OIDC token of the keyless signature.
+// Call it before the build is published, to fail before anything is deployed if the key or the token are missing.
+func (pc *ProvenanceCommand) CreateSigner() error {
+	var statementSigner signer
+	var err error
+	if pc.keyPath != "" {
+		statementSigner, err = newKeySigner(pc.keyPath)
+	} else {
+		statementSigner, err = pc.createKeylessSigner()
+	}
+	if err != nil {
+		return err
+	}
+	pc.signer = statementSigner
+	return nil
+}
+
+func (pc *ProvenanceCommand) Run() error {
+	if pc.signer == nil {
+		if err := pc.CreateSigner(); err != nil {
+			return err
+		}
+	}
+	servicesManager, err := utils.CreateServiceManager(pc.serverDetails, pc.retries, pc.retryWaitMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	buildName, err := pc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := pc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	buildInfo := pc.buildInfo
+	if buildInfo == nil {
+		if buildInfo, err = buildsbom.GetPublishedBuildInfo(servicesManager, buildName, buildNumber, pc.buildConfiguration.GetProject()); err != nil {
+			return err
+		}
+	}
+	provenanceStatement := newStatement(buildInfo, pc.buildConfiguration.GetProject(), time.Now())
+	if len(provenanceStatement.Subject) == 0 {
+		return errorutils.CheckErrorf("build %s/%s has no artifacts with checksums to create a provenance for", buildName, buildNumber)
+	}
+	payload, err := json.Marshal(provenanceStatement)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	signedEnvelope, err := signEnvelope(payload, pc.signer)
+	if err != nil {
+		return err
+	}
+	content, fileSuffix, err := pc.signer.encode(signedEnvelope)
+	if err != nil {
+		return err
+	}
+	fileName := strings.ReplaceAll(buildName+"-"+buildNumber, "/", "-") + fileSuffix
+	props := servicesutils.NewProperties()
+	props.AddProperty("build.name", buildName)
+	props.AddProperty("build.number", buildNumber)
+	props.AddProperty("slsa.predicateType", predicateType)
+	pc.deployedPath, err = buildsbom.DeployNextToArtifacts(servicesManager, buildInfo, fileName, content, props)
+	return err
+}
+
+// The Sigstore services are accessed with the proxy and TLS settings of the CLI, and the client certificate of the server.
+func (pc *ProvenanceCommand) createKeylessSigner() (*keylessSigner, error) {
+	certsPath, err := coreutils.GetJfrogCertsDir()
+	if err != nil {
+		return nil, err
+	}
+	client, err := httpclient.ClientBuilder().
+		SetCertificatesPath(certsPath).
+		SetInsecureTls(pc.serverDetails.InsecureTls).
+		SetClientCertPath(pc.serverDetails.ClientCertPath).
+		SetClientCertKeyPath(pc.serverDetails.ClientCertKeyPath).
+		SetRetries(pc.retries).
+		SetRetryWaitMilliSecs(pc.retryWaitMilliSecs).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return newKeylessSigner(pc.fulcioUrl, pc.rekorUrl, client)
+}
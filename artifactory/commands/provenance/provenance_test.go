@@ -0,0 +1,260 @@
+package provenance
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createBuildInfo() *buildinfo.BuildInfo {
+	return &buildinfo.BuildInfo{
+		Name:       "my-build",
+		Number:     "7",
+		Started:    "2023-08-01T12:00:00.000+0200",
+		Agent:      &buildinfo.Agent{Name: "jfrog-cli-go", Version: "2.45.0"},
+		BuildAgent: &buildinfo.Agent{Name: "GENERIC"},
+		Properties: buildinfo.Env{
+			"buildInfo.env.GITHUB_ACTIONS":      "true",
+			"buildInfo.env.GITHUB_SERVER_URL":   "https://github.com",
+			"buildInfo.env.GITHUB_REPOSITORY":   "org/repo",
+			"buildInfo.env.GITHUB_RUN_ID":       "100",
+			"buildInfo.env.GITHUB_RUN_ATTEMPT":  "2",
+			"buildInfo.env.GITHUB_WORKFLOW_REF": "org/repo/.github/workflows/build.yml@refs/heads/main",
+			"other":                             "value",
+		},
+		VcsList: []buildinfo.Vcs{{Url: "https://github.com/org/repo.git", Revision: "abc123", Branch: "main"}},
+		Modules: []buildinfo.Module{{
+			Type: buildinfo.Npm,
+			Id:   "app:1.0.0",
+			Artifacts: []buildinfo.Artifact{
+				{Name: "app-1.0.0.tgz", Path: "app/-/app-1.0.0.tgz", Checksum: buildinfo.Checksum{Sha256: "a256", Sha1: "a1"}},
+				{Name: "no-checksums.txt"},
+			},
+			Dependencies: []buildinfo.Dependency{
+				{Id: "lodash:4.17.21", Checksum: buildinfo.Checksum{Sha256: "l256"}},
+				{Id: "lodash:4.17.21", Checksum: buildinfo.Checksum{Sha256: "l256"}},
+			},
+		}},
+	}
+}
+
+func TestNewStatement(t *testing.T) {
+	provenance := newStatement(createBuildInfo(), "proj", time.Date(2023, 8, 1, 10, 30, 0, 0, time.UTC))
+	assert.Equal(t, "https://in-toto.io/Statement/v1", provenance.Type)
+	assert.Equal(t, "https://slsa.dev/provenance/v1", provenance.PredicateType)
+	assert.Equal(t, []resourceDescriptor{{Name: "app/-/app-1.0.0.tgz", Digest: map[string]string{"sha256": "a256", "sha1": "a1"}}}, provenance.Subject)
+
+	definition := provenance.Predicate.BuildDefinition
+	assert.Equal(t, externalParameters{BuildName: "my-build", BuildNumber: "7", Project: "proj",
+		Source: "git+https://github.com/org/repo.git@refs/heads/main"}, definition.ExternalParameters)
+	assert.Equal(t, []resourceDescriptor{
+		{Uri: "git+https://github.com/org/repo.git@refs/heads/main", Digest: map[string]string{"gitCommit": "abc123"}},
+		{Uri: "pkg:npm/lodash@4.17.21", Name: "lodash:4.17.21", Digest: map[string]string{"sha256": "l256"}},
+	}, definition.ResolvedDependencies)
+
+	run := provenance.Predicate.RunDetails
+	assert.Equal(t, builder{Id: "https://github.com/org/repo/.github/workflows/build.yml@refs/heads/main",
+		Version: map[string]string{"jfrog-cli-go": "2.45.0"}}, run.Builder)
+	assert.Equal(t, metadata{InvocationId: "https://github.com/org/repo/actions/runs/100/attempts/2", StartedOn: "2023-08-01T10:00:00Z",
+		FinishedOn: "2023-08-01T10:30:00Z"}, run.Metadata)
+}
+
+func TestDetectCi(t *testing.T) {
+	buildInfo := &buildinfo.BuildInfo{BuildUrl: "https://ci.example.com/7", BuildAgent: &buildinfo.Agent{Name: "Maven"}}
+	assert.Equal(t, ciDetails{builderId: "https://gitlab.com/group/project/-/runners/12", invocationId: "https://gitlab.com/group/project/-/jobs/5"},
+		detectCi(map[string]string{"GITLAB_CI": "true", "CI_SERVER_URL": "https://gitlab.com", "CI_PROJECT_PATH": "group/project",
+			"CI_RUNNER_ID": "12", "CI_JOB_URL": "https://gitlab.com/group/project/-/jobs/5"}, buildInfo))
+	assert.Equal(t, ciDetails{builderId: "https://jenkins.example.com/", invocationId: "https://jenkins.example.com/job/app/7/"},
+		detectCi(map[string]string{"JENKINS_URL": "https://jenkins.example.com/", "BUILD_URL": "https://jenkins.example.com/job/app/7/"}, buildInfo))
+	assert.Equal(t, ciDetails{builderId: "https://jfrog.com/cli/maven", invocationId: "https://ci.example.com/7"}, detectCi(map[string]string{}, buildInfo))
+}
+
+func TestKeySigner(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+
+	keySigner, err := newKeySigner(keyPath)
+	require.NoError(t, err)
+	signedEnvelope, err := signEnvelope([]byte(`{"_type":"test"}`), keySigner)
+	require.NoError(t, err)
+	assert.Equal(t, "application/vnd.in-toto+json", signedEnvelope.PayloadType)
+	payload, err := base64.StdEncoding.DecodeString(signedEnvelope.Payload)
+	require.NoError(t, err)
+	assert.Equal(t, `{"_type":"test"}`, string(payload))
+
+	require.Len(t, signedEnvelope.Signatures, 1)
+	expectedKeyId, err := publicKeyId(publicKey)
+	require.NoError(t, err)
+	assert.Equal(t, expectedKeyId, signedEnvelope.Signatures[0].KeyId)
+	sig, err := base64.StdEncoding.DecodeString(signedEnvelope.Signatures[0].Sig)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey, []byte("DSSEv1 28 application/vnd.in-toto+json 16 {\"_type\":\"test\"}"), sig))
+
+	require.NoError(t, os.WriteFile(keyPath, []byte("not a key"), 0600))
+	_, err = newKeySigner(keyPath)
+	assert.Error(t, err)
+}
+
+func TestKeylessSigner(t *testing.T) {
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"repo:org/repo:ref:refs/heads/main"}`))
+	idToken := "header." + claims + ".signature"
+	leafCertificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("leaf")}))
+	rootCertificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("root")}))
+	var publicKey *ecdsa.PublicKey
+	var recordedEnvelope string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/signingCert":
+			request := struct {
+				Credentials struct {
+					OidcIdentityToken string `json:"oidcIdentityToken"`
+				} `json:"credentials"`
+				PublicKeyRequest struct {
+					PublicKey struct {
+						Content string `json:"content"`
+					} `json:"publicKey"`
+					ProofOfPossession string `json:"proofOfPossession"`
+				} `json:"publicKeyRequest"`
+			}{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, idToken, request.Credentials.OidcIdentityToken)
+			block, _ := pem.Decode([]byte(request.PublicKeyRequest.PublicKey.Content))
+			require.NotNil(t, block)
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			require.NoError(t, err)
+			publicKey = key.(*ecdsa.PublicKey)
+			proof, err := base64.StdEncoding.DecodeString(request.PublicKeyRequest.ProofOfPossession)
+			require.NoError(t, err)
+			digest := sha256.Sum256([]byte("repo:org/repo:ref:refs/heads/main"))
+			assert.True(t, ecdsa.VerifyASN1(publicKey, digest[:], proof))
+			response, err := json.Marshal(map[string]interface{}{"signedCertificateEmbeddedSct": map[string]interface{}{
+				"chain": map[string]interface{}{"certificates": []string{leafCertificate, rootCertificate}}}})
+			require.NoError(t, err)
+			_, _ = w.Write(response)
+		case "/api/v1/log/entries":
+			request := struct {
+				Kind string `json:"kind"`
+				Spec struct {
+					ProposedContent struct {
+						Envelope  string   `json:"envelope"`
+						Verifiers []string `json:"verifiers"`
+					} `json:"proposedContent"`
+				} `json:"spec"`
+			}{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, "dsse", request.Kind)
+			assert.Equal(t, []string{base64.StdEncoding.EncodeToString([]byte(leafCertificate))}, request.Spec.ProposedContent.Verifiers)
+			recordedEnvelope = request.Spec.ProposedContent.Envelope
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"uuid1":{"body":"Ym9keQ==","integratedTime":1690884000,"logID":"0a0b","logIndex":42,"verification":{
+				"inclusionProof":{"checkpoint":"checkpoint","hashes":["0c"],"logIndex":41,"rootHash":"0d","treeSize":100},"signedEntryTimestamp":"c2V0"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("SIGSTORE_ID_TOKEN", idToken)
+	client, err := httpclient.ClientBuilder().Build()
+	require.NoError(t, err)
+	keyless, err := newKeylessSigner(server.URL+"/", server.URL, client)
+	require.NoError(t, err)
+	signedEnvelope, err := signEnvelope([]byte("{}"), keyless)
+	require.NoError(t, err)
+	require.Len(t, signedEnvelope.Signatures, 1)
+	assert.Empty(t, signedEnvelope.Signatures[0].KeyId)
+	sig, err := base64.StdEncoding.DecodeString(signedEnvelope.Signatures[0].Sig)
+	require.NoError(t, err)
+	digest := sha256.Sum256(preAuthEncoding(payloadType, []byte("{}")))
+	assert.True(t, ecdsa.VerifyASN1(publicKey, digest[:], sig))
+	assert.Equal(t, elliptic.P256(), publicKey.Curve)
+
+	content, fileSuffix, err := keyless.encode(signedEnvelope)
+	require.NoError(t, err)
+	assert.Equal(t, ".sigstore.json", fileSuffix)
+	expectedEnvelope, err := json.Marshal(signedEnvelope)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expectedEnvelope), recordedEnvelope)
+	var signatureBundle bundle
+	require.NoError(t, json.Unmarshal(content, &signatureBundle))
+	assert.Equal(t, "application/vnd.dev.sigstore.bundle+json;version=0.2", signatureBundle.MediaType)
+	assert.Equal(t, signedEnvelope, signatureBundle.DsseEnvelope)
+	assert.Equal(t, []rawBytes{{RawBytes: []byte("leaf")}, {RawBytes: []byte("root")}}, signatureBundle.VerificationMaterial.X509CertificateChain.Certificates)
+	require.Len(t, signatureBundle.VerificationMaterial.TlogEntries, 1)
+	tlogEntry := signatureBundle.VerificationMaterial.TlogEntries[0]
+	assert.Equal(t, "42", tlogEntry.LogIndex)
+	assert.Equal(t, []byte{0x0a, 0x0b}, tlogEntry.LogId.KeyId)
+	assert.Equal(t, "1690884000", tlogEntry.IntegratedTime)
+	assert.Equal(t, "c2V0", tlogEntry.InclusionPromise.SignedEntryTimestamp)
+	assert.Equal(t, "Ym9keQ==", tlogEntry.CanonicalizedBody)
+	require.NotNil(t, tlogEntry.InclusionProof)
+	assert.Equal(t, "41", tlogEntry.InclusionProof.LogIndex)
+	assert.Equal(t, "100", tlogEntry.InclusionProof.TreeSize)
+	assert.Equal(t, []byte{0x0d}, tlogEntry.InclusionProof.RootHash)
+	assert.Equal(t, [][]byte{{0x0c}}, tlogEntry.InclusionProof.Hashes)
+	assert.Equal(t, "checkpoint", tlogEntry.InclusionProof.Checkpoint.Envelope)
+}
+
+func TestKeylessSignerWithoutToken(t *testing.T) {
+	t.Setenv("SIGSTORE_ID_TOKEN", "")
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", "")
+	client, err := httpclient.ClientBuilder().Build()
+	require.NoError(t, err)
+	_, err = newKeylessSigner(DefaultFulcioUrl, DefaultRekorUrl, client)
+	assert.ErrorContains(t, err, "keyless signing requires an OIDC token")
+}
+
+func TestCreateSigner(t *testing.T) {
+	t.Setenv("SIGSTORE_ID_TOKEN", "")
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", "")
+	command := NewProvenanceCommand().SetServerDetails(&config.ServerDetails{})
+	assert.ErrorContains(t, command.CreateSigner(), "keyless signing requires an OIDC token")
+	assert.Nil(t, command.signer)
+
+	command.SetKeyPath(filepath.Join(t.TempDir(), "missing.pem"))
+	assert.Error(t, command.CreateSigner())
+	assert.Nil(t, command.signer)
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(command.keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+	require.NoError(t, command.CreateSigner())
+	assert.IsType(t, &keySigner{}, command.signer)
+}
+
+func TestTokenSubject(t *testing.T) {
+	encode := func(claims string) string {
+		return "h." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".s"
+	}
+	subject, err := tokenSubject(encode(`{"sub":"123","email":"dev@example.com"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "dev@example.com", subject)
+	_, err = tokenSubject(encode(`{}`))
+	assert.Error(t, err)
+	_, err = tokenSubject("not-a-jwt")
+	assert.Error(t, err)
+}
@@ -0,0 +1,353 @@
+package provenance
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	payloadType = "application/vnd.in-toto+json"
+	// The public Sigstore certificate authority, which issues the certificates of the keyless signatures.
+	DefaultFulcioUrl = "https://fulcio.sigstore.dev"
+	// The public Sigstore transparency log, in which the keyless signatures are recorded.
+	DefaultRekorUrl  = "https://rekor.sigstore.dev"
+	sigstoreAudience = "sigstore"
+)
+
+// A DSSE envelope of a signed in-toto statement.
+// See https://github.com/secure-systems-lab/dsse/blob/master/envelope.md
+type envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []signature `json:"signatures"`
+}
+
+type signature struct {
+	KeyId string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// Signs the payload of DSSE envelopes.
+type signer interface {
+	sign(payload []byte) (signature, error)
+	// Returns the content of the file in which the envelope signed by the signer is deployed, and the suffix of its name.
+	encode(signedEnvelope *envelope) (content []byte, fileSuffix string, err error)
+}
+
+// Returns the DSSE envelope of the payload, signed by the signer.
+func signEnvelope(payload []byte, signer signer) (*envelope, error) {
+	envelopeSignature, err := signer.sign(preAuthEncoding(payloadType, payload))
+	if err != nil {
+		return nil, err
+	}
+	return &envelope{PayloadType: payloadType, Payload: base64.StdEncoding.EncodeToString(payload), Signatures: []signature{envelopeSignature}}, nil
+}
+
+// The DSSE pre-authentication encoding, which is the message signed in DSSE envelopes.
+func preAuthEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// Signs with a private key read from a local PEM file. ECDSA, Ed25519 and RSA keys are supported.
+type keySigner struct {
+	key   crypto.Signer
+	keyId string
+}
+
+func newKeySigner(keyPath string) (*keySigner, error) {
+	content, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errorutils.CheckErrorf("the signing key %s is not a PEM file", keyPath)
+	}
+	var key interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, errorutils.CheckErrorf("the signing key %s has an unsupported PEM type '%s'. Encrypted keys are not supported", keyPath, block.Type)
+	}
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	cryptoSigner, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errorutils.CheckErrorf("the signing key %s is not supported", keyPath)
+	}
+	keyId, err := publicKeyId(cryptoSigner.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &keySigner{key: cryptoSigner, keyId: keyId}, nil
+}
+
+func (ks *keySigner) sign(payload []byte) (signature, error) {
+	sig, err := signMessage(ks.key, payload)
+	if err != nil {
+		return signature{}, err
+	}
+	return signature{KeyId: ks.keyId, Sig: base64.StdEncoding.EncodeToString(sig)}, nil
+}
+
+// The envelope is deployed in the JSON Lines format of in-toto attestation bundles, with a single envelope.
+func (ks *keySigner) encode(signedEnvelope *envelope) ([]byte, string, error) {
+	content, err := json.Marshal(signedEnvelope)
+	if err != nil {
+		return nil, "", errorutils.CheckError(err)
+	}
+	return append(content, '\n'), ".intoto.jsonl", nil
+}
+
+// Signs the message with SHA-256, or with the message itself in case of Ed25519 keys.
+func signMessage(key crypto.Signer, message []byte) ([]byte, error) {
+	var sig []byte
+	var err error
+	switch key.(type) {
+	case ed25519.PrivateKey:
+		sig, err = key.Sign(rand.Reader, message, crypto.Hash(0))
+	case *ecdsa.PrivateKey, *rsa.PrivateKey:
+		digest := sha256.Sum256(message)
+		sig, err = key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return nil, errorutils.CheckErrorf("unsupported signing key type %T", key)
+	}
+	return sig, errorutils.CheckError(err)
+}
+
+// The key ID is the SHA-256 of the DER encoded public key.
+func publicKeyId(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	digest := sha256.Sum256(der)
+	return hex.EncodeToString(digest[:]), nil
+}
+
+// Signs keylessly, with an ephemeral key and a short-lived certificate issued by Fulcio for the identity in the
+// OIDC token of the CI run. The signed envelope is recorded in the Rekor transparency log, which proves that it was
+// signed while the certificate was valid, so that it can be verified after the certificate expires.
+// See https://github.com/sigstore/fulcio/blob/main/docs/how-certificate-issuing-works.md
+type keylessSigner struct {
+	fulcioUrl string
+	rekorUrl  string
+	idToken   string
+	client    *httpclient.HttpClient
+	// The PEM encoded certificate chain of the ephemeral key, starting with the signing certificate.
+	certificates []string
+}
+
+func newKeylessSigner(fulcioUrl, rekorUrl string, client *httpclient.HttpClient) (*keylessSigner, error) {
+	idToken, err := getIdToken(client)
+	if err != nil {
+		return nil, err
+	}
+	return &keylessSigner{fulcioUrl: strings.TrimSuffix(fulcioUrl, "/"), rekorUrl: strings.TrimSuffix(rekorUrl, "/"), idToken: idToken, client: client}, nil
+}
+
+// Returns the OIDC token of the signer's identity. The token is taken from the SIGSTORE_ID_TOKEN environment variable,
+// or requested from GitHub Actions when the workflow has the id-token write permission.
+func getIdToken(client *httpclient.HttpClient) (string, error) {
+	if idToken := os.Getenv("SIGSTORE_ID_TOKEN"); idToken != "" {
+		return idToken, nil
+	}
+	requestUrl, requestToken := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL"), os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN")
+	if requestUrl == "" || requestToken == "" {
+		return "", errorutils.CheckErrorf("keyless signing requires an OIDC token. Set the SIGSTORE_ID_TOKEN environment variable, " +
+			"run in GitHub Actions with the id-token write permission, or sign with a local key instead")
+	}
+	log.Debug("Requesting an OIDC token from GitHub Actions...")
+	httpClientDetails := httputils.HttpClientDetails{Headers: map[string]string{"Authorization": "Bearer " + requestToken}}
+	resp, body, _, err := client.SendGet(requestUrl+"&audience="+sigstoreAudience, true, httpClientDetails, "")
+	if err != nil {
+		return "", err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return "", err
+	}
+	response := struct {
+		Value string `json:"value"`
+	}{}
+	return response.Value, errorutils.CheckError(json.Unmarshal(body, &response))
+}
+
+func (ks *keylessSigner) sign(payload []byte) (signature, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return signature{}, errorutils.CheckError(err)
+	}
+	if ks.certificates, err = ks.requestCertificates(key); err != nil {
+		return signature{}, err
+	}
+	sig, err := signMessage(key, payload)
+	if err != nil {
+		return signature{}, err
+	}
+	return signature{Sig: base64.StdEncoding.EncodeToString(sig)}, nil
+}
+
+// Requests the certificate chain of the ephemeral key from Fulcio. The key is proven to be owned by signing the
+// subject of the OIDC token.
+func (ks *keylessSigner) requestCertificates(key *ecdsa.PrivateKey) ([]string, error) {
+	subject, err := tokenSubject(ks.idToken)
+	if err != nil {
+		return nil, err
+	}
+	proofOfPossession, err := signMessage(key, []byte(subject))
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	body := map[string]interface{}{
+		"credentials": map[string]string{"oidcIdentityToken": ks.idToken},
+		"publicKeyRequest": map[string]interface{}{
+			"publicKey":         map[string]string{"algorithm": "ECDSA", "content": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))},
+			"proofOfPossession": base64.StdEncoding.EncodeToString(proofOfPossession),
+		},
+	}
+	type chain struct {
+		Chain struct {
+			Certificates []string `json:"certificates"`
+		} `json:"chain"`
+	}
+	response := struct {
+		EmbeddedSct *chain `json:"signedCertificateEmbeddedSct"`
+		DetachedSct *chain `json:"signedCertificateDetachedSct"`
+	}{}
+	log.Debug("Requesting a signing certificate from", ks.fulcioUrl+"...")
+	if err = ks.postJson(ks.fulcioUrl+"/api/v2/signingCert", body, &response, http.StatusOK, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	switch {
+	case response.EmbeddedSct != nil && len(response.EmbeddedSct.Chain.Certificates) > 0:
+		return response.EmbeddedSct.Chain.Certificates, nil
+	case response.DetachedSct != nil && len(response.DetachedSct.Chain.Certificates) > 0:
+		return response.DetachedSct.Chain.Certificates, nil
+	default:
+		return nil, errorutils.CheckErrorf("no signing certificate was returned by %s", ks.fulcioUrl)
+	}
+}
+
+// Records the signed envelope in Rekor, and returns the Sigstore bundle of the envelope, its certificate chain and
+// its transparency log entry.
+func (ks *keylessSigner) encode(signedEnvelope *envelope) ([]byte, string, error) {
+	if len(ks.certificates) == 0 {
+		return nil, "", errorutils.CheckErrorf("the envelope was not signed keylessly")
+	}
+	tlogEntry, err := ks.uploadToRekor(signedEnvelope)
+	if err != nil {
+		return nil, "", err
+	}
+	signatureBundle := bundle{MediaType: bundleMediaType, DsseEnvelope: signedEnvelope}
+	signatureBundle.VerificationMaterial.TlogEntries = []*transparencyLogEntry{tlogEntry}
+	for _, certificate := range ks.certificates {
+		block, _ := pem.Decode([]byte(certificate))
+		if block == nil {
+			return nil, "", errorutils.CheckErrorf("the signing certificate returned by %s is not PEM encoded", ks.fulcioUrl)
+		}
+		signatureBundle.VerificationMaterial.X509CertificateChain.Certificates = append(
+			signatureBundle.VerificationMaterial.X509CertificateChain.Certificates, rawBytes{RawBytes: block.Bytes})
+	}
+	content, err := json.Marshal(signatureBundle)
+	return content, bundleFileSuffix, errorutils.CheckError(err)
+}
+
+// Uploads a DSSE entry of the signed envelope to Rekor, and returns the created entry.
+// See https://github.com/sigstore/rekor/blob/main/pkg/types/dsse/v0.0.1/dsse_v0_0_1_schema.json
+func (ks *keylessSigner) uploadToRekor(signedEnvelope *envelope) (*transparencyLogEntry, error) {
+	envelopeContent, err := json.Marshal(signedEnvelope)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	body := map[string]interface{}{
+		"apiVersion": dsseKindVersion,
+		"kind":       dsseKind,
+		"spec": map[string]interface{}{
+			"proposedContent": map[string]interface{}{
+				"envelope":  string(envelopeContent),
+				"verifiers": []string{base64.StdEncoding.EncodeToString([]byte(ks.certificates[0]))},
+			},
+		},
+	}
+	// The response maps the UUID of the created entry to the entry.
+	response := make(map[string]rekorEntry)
+	log.Debug("Recording the signature in the transparency log", ks.rekorUrl+"...")
+	if err = ks.postJson(ks.rekorUrl+"/api/v1/log/entries", body, &response, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	for _, entry := range response {
+		return entry.toTransparencyLogEntry()
+	}
+	return nil, errorutils.CheckErrorf("no transparency log entry was returned by %s", ks.rekorUrl)
+}
+
+func (ks *keylessSigner) postJson(url string, body, response interface{}, expectedStatusCodes ...int) error {
+	content, err := json.Marshal(body)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	httpClientDetails := httputils.HttpClientDetails{Headers: map[string]string{"Content-Type": "application/json"}}
+	resp, respBody, err := ks.client.SendPost(url, content, httpClientDetails, "")
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, respBody, expectedStatusCodes...); err != nil {
+		return err
+	}
+	return errorutils.CheckError(json.Unmarshal(respBody, response))
+}
+
+// Returns the identity of the OIDC token, which is its email claim if it has one, or its subject claim otherwise.
+func tokenSubject(idToken string) (string, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return "", errorutils.CheckErrorf("the OIDC token is not a valid JWT")
+	}
+	content, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	claims := struct {
+		Subject string `json:"sub"`
+		Email   string `json:"email"`
+	}{}
+	if err = json.Unmarshal(content, &claims); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if claims.Email != "" {
+		return claims.Email, nil
+	}
+	if claims.Subject == "" {
+		return "", errorutils.CheckErrorf("the OIDC token has no subject")
+	}
+	return claims.Subject, nil
+}
@@ -0,0 +1,209 @@
+package provenance
+
+import (
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/buildsbom"
+)
+
+const (
+	statementType = "https://in-toto.io/Statement/v1"
+	predicateType = "https://slsa.dev/provenance/v1"
+	// The build type of the provenance. The external parameters identify the published build and its sources.
+	buildType = "https://jfrog.com/cli/build-publish/v1"
+)
+
+// An in-toto statement with an SLSA v1 provenance predicate.
+// See https://slsa.dev/spec/v1.0/provenance
+type statement struct {
+	Type          string               `json:"_type"`
+	Subject       []resourceDescriptor `json:"subject"`
+	PredicateType string               `json:"predicateType"`
+	Predicate     predicate            `json:"predicate"`
+}
+
+type resourceDescriptor struct {
+	Uri    string            `json:"uri,omitempty"`
+	Name   string            `json:"name,omitempty"`
+	Digest map[string]string `json:"digest"`
+}
+
+type predicate struct {
+	BuildDefinition buildDefinition `json:"buildDefinition"`
+	RunDetails      runDetails      `json:"runDetails"`
+}
+
+type buildDefinition struct {
+	BuildType            string               `json:"buildType"`
+	ExternalParameters   externalParameters   `json:"externalParameters"`
+	InternalParameters   map[string]string    `json:"internalParameters,omitempty"`
+	ResolvedDependencies []resourceDescriptor `json:"resolvedDependencies"`
+}
+
+type externalParameters struct {
+	BuildName   string `json:"buildName"`
+	BuildNumber string `json:"buildNumber"`
+	Project     string `json:"project,omitempty"`
+	Source      string `json:"source,omitempty"`
+}
+
+type runDetails struct {
+	Builder  builder  `json:"builder"`
+	Metadata metadata `json:"metadata"`
+}
+
+type builder struct {
+	Id      string            `json:"id"`
+	Version map[string]string `json:"version,omitempty"`
+}
+
+type metadata struct {
+	InvocationId string `json:"invocationId,omitempty"`
+	StartedOn    string `json:"startedOn,omitempty"`
+	FinishedOn   string `json:"finishedOn,omitempty"`
+}
+
+// Creates the provenance statement of a published build. The subjects are the artifacts of the build and the
+// resolved dependencies are its sources and the dependencies of its modules. The builder and the invocation are
+// identified by the CI environment variables collected into the build-info by the build-collect-env command.
+func newStatement(buildInfo *buildinfo.BuildInfo, project string, finishedOn time.Time) *statement {
+	env := collectedEnv(buildInfo)
+	ci := detectCi(env, buildInfo)
+	provenance := &statement{
+		Type:          statementType,
+		Subject:       []resourceDescriptor{},
+		PredicateType: predicateType,
+		Predicate: predicate{
+			BuildDefinition: buildDefinition{
+				BuildType:            buildType,
+				ExternalParameters:   externalParameters{BuildName: buildInfo.Name, BuildNumber: buildInfo.Number, Project: project},
+				ResolvedDependencies: []resourceDescriptor{},
+			},
+			RunDetails: runDetails{
+				Builder:  builder{Id: ci.builderId},
+				Metadata: metadata{InvocationId: ci.invocationId, FinishedOn: finishedOn.UTC().Format(time.RFC3339)},
+			},
+		},
+	}
+	if buildInfo.Agent != nil && buildInfo.Agent.Name != "" {
+		provenance.Predicate.RunDetails.Builder.Version = map[string]string{buildInfo.Agent.Name: buildInfo.Agent.Version}
+	}
+	if started, err := time.Parse(buildinfo.TimeFormat, buildInfo.Started); err == nil {
+		provenance.Predicate.RunDetails.Metadata.StartedOn = started.UTC().Format(time.RFC3339)
+	}
+	if buildInfo.BuildUrl != "" {
+		provenance.Predicate.BuildDefinition.InternalParameters = map[string]string{"buildUrl": buildInfo.BuildUrl}
+	}
+
+	for _, vcs := range buildInfo.VcsList {
+		if vcs.Url == "" || vcs.Revision == "" {
+			continue
+		}
+		source := vcsUri(vcs)
+		if provenance.Predicate.BuildDefinition.ExternalParameters.Source == "" {
+			provenance.Predicate.BuildDefinition.ExternalParameters.Source = source
+		}
+		provenance.Predicate.BuildDefinition.ResolvedDependencies = append(provenance.Predicate.BuildDefinition.ResolvedDependencies,
+			resourceDescriptor{Uri: source, Digest: map[string]string{"gitCommit": vcs.Revision}})
+	}
+	dependencies := make(map[string]bool)
+	for _, module := range buildInfo.Modules {
+		for _, artifact := range module.Artifacts {
+			if digest := digestSet(artifact.Checksum); len(digest) > 0 {
+				provenance.Subject = append(provenance.Subject, resourceDescriptor{Name: artifactPath(artifact), Digest: digest})
+			}
+		}
+		for _, dependency := range module.Dependencies {
+			digest := digestSet(dependency.Checksum)
+			if dependencies[dependency.Id] || len(digest) == 0 {
+				continue
+			}
+			dependencies[dependency.Id] = true
+			provenance.Predicate.BuildDefinition.ResolvedDependencies = append(provenance.Predicate.BuildDefinition.ResolvedDependencies,
+				resourceDescriptor{Uri: buildsbom.DependencyPurl(dependency.Id, module.Type), Name: dependency.Id, Digest: digest})
+		}
+	}
+	return provenance
+}
+
+// Returns the environment variables collected into the build-info.
+func collectedEnv(buildInfo *buildinfo.BuildInfo) map[string]string {
+	env := make(map[string]string)
+	for key, value := range buildInfo.Properties {
+		if strings.HasPrefix(key, buildinfo.BuildInfoEnvPrefix) {
+			env[strings.TrimPrefix(key, buildinfo.BuildInfoEnvPrefix)] = value
+		}
+	}
+	return env
+}
+
+type ciDetails struct {
+	builderId    string
+	invocationId string
+}
+
+// Identifies the CI server which ran the build, and the run of the build in it. If the build didn't run on a
+// supported CI server, or its environment wasn't collected, the builder is identified by the build agent.
+func detectCi(env map[string]string, buildInfo *buildinfo.BuildInfo) ciDetails {
+	switch {
+	case env["GITHUB_ACTIONS"] == "true":
+		repositoryUrl := env["GITHUB_SERVER_URL"] + "/" + env["GITHUB_REPOSITORY"]
+		invocationId := repositoryUrl + "/actions/runs/" + env["GITHUB_RUN_ID"]
+		if env["GITHUB_RUN_ATTEMPT"] != "" {
+			invocationId += "/attempts/" + env["GITHUB_RUN_ATTEMPT"]
+		}
+		builderId := repositoryUrl + "/actions"
+		if env["GITHUB_WORKFLOW_REF"] != "" {
+			builderId = env["GITHUB_SERVER_URL"] + "/" + env["GITHUB_WORKFLOW_REF"]
+		}
+		return ciDetails{builderId: builderId, invocationId: invocationId}
+	case env["GITLAB_CI"] == "true":
+		builderId := env["CI_SERVER_URL"] + "/" + env["CI_PROJECT_PATH"]
+		if env["CI_RUNNER_ID"] != "" {
+			builderId += "/-/runners/" + env["CI_RUNNER_ID"]
+		}
+		return ciDetails{builderId: builderId, invocationId: env["CI_JOB_URL"]}
+	case env["JENKINS_URL"] != "":
+		return ciDetails{builderId: env["JENKINS_URL"], invocationId: env["BUILD_URL"]}
+	}
+	builderId := "https://jfrog.com/cli"
+	if buildInfo.BuildAgent != nil && buildInfo.BuildAgent.Name != "" && buildInfo.BuildAgent.Name != "GENERIC" {
+		builderId += "/" + strings.ToLower(buildInfo.BuildAgent.Name)
+	}
+	return ciDetails{builderId: builderId, invocationId: buildInfo.BuildUrl}
+}
+
+// Returns the URI of the sources in the SPDX download location format, such as git+https://github.com/org/repo@refs/heads/main.
+func vcsUri(vcs buildinfo.Vcs) string {
+	uri := vcs.Url
+	if !strings.HasPrefix(uri, "git+") {
+		uri = "git+" + uri
+	}
+	if vcs.Branch != "" {
+		uri += "@refs/heads/" + vcs.Branch
+	}
+	return uri
+}
+
+func digestSet(checksum buildinfo.Checksum) map[string]string {
+	digest := make(map[string]string)
+	if checksum.Sha256 != "" {
+		digest["sha256"] = checksum.Sha256
+	}
+	if checksum.Sha1 != "" {
+		digest["sha1"] = checksum.Sha1
+	}
+	if checksum.Md5 != "" {
+		digest["md5"] = checksum.Md5
+	}
+	return digest
+}
+
+func artifactPath(artifact buildinfo.Artifact) string {
+	if artifact.Path != "" {
+		return artifact.Path
+	}
+	return artifact.Name
+}
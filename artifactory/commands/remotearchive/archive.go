@@ -0,0 +1,301 @@
+package remotearchive
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/gofrog/stringutils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	commandsutils "github.com/jfrog/jfrog-cli/artifactory/commands/utils"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// Separates the path of an archive in Artifactory from the path of an entry inside it.
+	EntrySeparator = "!"
+	// Writes the extracted entry to the standard output.
+	StdoutTarget = "-"
+)
+
+// Splits "repo/path/archive.zip!entry/path" into the archive path and the entry.
+func SplitEntryPath(entryPath string) (archivePath, entry string) {
+	archivePath, entry, _ = strings.Cut(entryPath, EntrySeparator)
+	return archivePath, strings.TrimPrefix(entry, "/")
+}
+
+type Entry struct {
+	Name           string `json:"name" col-name:"Name"`
+	Size           uint64 `json:"size"`
+	CompressedSize uint64 `json:"compressedSize"`
+	Modified       string `json:"modified" col-name:"Modified"`
+	SizeSummary    string `json:"-" col-name:"Size"`
+	IsDir          bool   `json:"isDir,omitempty"`
+}
+
+type archiveCommand struct {
+	serverDetails      *config.ServerDetails
+	archivePath        string
+	retries            int
+	retryWaitMilliSecs int
+}
+
+// Opens the zip archive in Artifactory, reading its central directory only.
+func (ac *archiveCommand) open() (*zip.Reader, *rangeReader, error) {
+	servicesManager, err := utils.CreateServiceManager(ac.serverDetails, ac.retries, ac.retryWaitMilliSecs, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	artifactUrl, err := servicesutils.BuildArtifactoryUrl(servicesManager.GetConfig().GetServiceDetails().GetUrl(), ac.archivePath, make(map[string]string))
+	if err != nil {
+		return nil, nil, err
+	}
+	reader, err := newArtifactRangeReader(servicesManager, artifactUrl)
+	if err != nil {
+		return nil, nil, err
+	}
+	zipReader, err := zip.NewReader(reader, reader.size)
+	if err != nil {
+		return nil, nil, errorutils.CheckErrorf("failed reading '%s' as a zip archive: %s", ac.archivePath, err.Error())
+	}
+	return zipReader, reader, nil
+}
+
+// Lists the entries of a zip archive in Artifactory, without downloading the archive.
+type ArchiveListCommand struct {
+	archiveCommand
+	entriesPattern string
+	format         commandsutils.Format
+	entries        []Entry
+}
+
+func NewArchiveListCommand() *ArchiveListCommand {
+	return &ArchiveListCommand{}
+}
+
+func (alc *ArchiveListCommand) SetServerDetails(serverDetails *config.ServerDetails) *ArchiveListCommand {
+	alc.serverDetails = serverDetails
+	return alc
+}
+
+func (alc *ArchiveListCommand) SetArchivePath(archivePath string) *ArchiveListCommand {
+	alc.archivePath = archivePath
+	return alc
+}
+
+// Lists only the entries matching the wildcard pattern.
+func (alc *ArchiveListCommand) SetEntriesPattern(entriesPattern string) *ArchiveListCommand {
+	alc.entriesPattern = entriesPattern
+	return alc
+}
+
+func (alc *ArchiveListCommand) SetFormat(format commandsutils.Format) *ArchiveListCommand {
+	alc.format = format
+	return alc
+}
+
+func (alc *ArchiveListCommand) SetRetries(retries int) *ArchiveListCommand {
+	alc.retries = retries
+	return alc
+}
+
+func (alc *ArchiveListCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *ArchiveListCommand {
+	alc.retryWaitMilliSecs = retryWaitMilliSecs
+	return alc
+}
+
+func (alc *ArchiveListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return alc.serverDetails, nil
+}
+
+func (alc *ArchiveListCommand) Entries() []Entry {
+	return alc.entries
+}
+
+func (alc *ArchiveListCommand) CommandName() string {
+	return "rt_archive_ls"
+}
+
+func (alc *ArchiveListCommand) Run() error {
+	zipReader, reader, err := alc.open()
+	if err != nil {
+		return err
+	}
+	if alc.entries, err = listEntries(zipReader, alc.entriesPattern); err != nil {
+		return err
+	}
+	log.Debug(fmt.Sprintf("Read %d bytes of the %d bytes of %s.", reader.fetched, reader.size, alc.archivePath))
+	return commandsutils.Print(alc.format, alc.entries, func() error {
+		return coreutils.PrintTable(alc.entries, "Entries of "+alc.archivePath, "No entries were found", false)
+	})
+}
+
+func listEntries(zipReader *zip.Reader, entriesPattern string) ([]Entry, error) {
+	entries := []Entry{}
+	for _, file := range zipReader.File {
+		if entriesPattern != "" {
+			matched, err := stringutils.MatchWildcardPattern(entriesPattern, file.Name)
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			if !matched {
+				continue
+			}
+		}
+		entry := Entry{Name: file.Name, Size: file.UncompressedSize64, CompressedSize: file.CompressedSize64, IsDir: file.FileInfo().IsDir()}
+		if !file.Modified.IsZero() {
+			entry.Modified = file.Modified.Format("2006-01-02 15:04:05")
+		}
+		if !entry.IsDir {
+			entry.SizeSummary = strconv.FormatUint(entry.Size, 10)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Extracts a single entry of a zip archive in Artifactory, downloading only the entry's compressed content.
+type ArchiveGetCommand struct {
+	archiveCommand
+	entry  string
+	target string
+}
+
+func NewArchiveGetCommand() *ArchiveGetCommand {
+	return &ArchiveGetCommand{}
+}
+
+func (agc *ArchiveGetCommand) SetServerDetails(serverDetails *config.ServerDetails) *ArchiveGetCommand {
+	agc.serverDetails = serverDetails
+	return agc
+}
+
+func (agc *ArchiveGetCommand) SetArchivePath(archivePath string) *ArchiveGetCommand {
+	agc.archivePath = archivePath
+	return agc
+}
+
+func (agc *ArchiveGetCommand) SetEntry(entry string) *ArchiveGetCommand {
+	agc.entry = entry
+	return agc
+}
+
+// The local path to extract the entry to. If the target is a directory, the entry is extracted into it by its file name.
+func (agc *ArchiveGetCommand) SetTarget(target string) *ArchiveGetCommand {
+	agc.target = target
+	return agc
+}
+
+func (agc *ArchiveGetCommand) SetRetries(retries int) *ArchiveGetCommand {
+	agc.retries = retries
+	return agc
+}
+
+func (agc *ArchiveGetCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *ArchiveGetCommand {
+	agc.retryWaitMilliSecs = retryWaitMilliSecs
+	return agc
+}
+
+func (agc *ArchiveGetCommand) ServerDetails() (*config.ServerDetails, error) {
+	return agc.serverDetails, nil
+}
+
+func (agc *ArchiveGetCommand) CommandName() string {
+	return "rt_archive_get"
+}
+
+func (agc *ArchiveGetCommand) Run() error {
+	zipReader, reader, err := agc.open()
+	if err != nil {
+		return err
+	}
+	file, err := findEntry(zipReader, agc.entry)
+	if err != nil {
+		return err
+	}
+	if agc.target == StdoutTarget {
+		err = extractEntry(file, os.Stdout)
+	} else {
+		err = extractEntryToFile(file, getTargetPath(agc.target, agc.entry))
+	}
+	if err != nil {
+		return err
+	}
+	log.Debug(fmt.Sprintf("Read %d bytes of the %d bytes of %s.", reader.fetched, reader.size, agc.archivePath))
+	return nil
+}
+
+func findEntry(zipReader *zip.Reader, entry string) (*zip.File, error) {
+	for _, file := range zipReader.File {
+		if file.Name != entry {
+			continue
+		}
+		if file.FileInfo().IsDir() {
+			return nil, errorutils.CheckErrorf("'%s' is a directory. Only files can be extracted from the archive", entry)
+		}
+		return file, nil
+	}
+	return nil, errorutils.CheckErrorf("the archive doesn't contain the entry '%s'", entry)
+}
+
+// Returns the local path of the extracted entry. By default, the entry is extracted to the current directory.
+func getTargetPath(target, entry string) string {
+	if target == "" {
+		target = "." + string(filepath.Separator)
+	}
+	isDir, err := fileutils.IsDirExists(target, false)
+	if strings.HasSuffix(target, "/") || strings.HasSuffix(target, string(filepath.Separator)) || (err == nil && isDir) {
+		return filepath.Join(target, path.Base(entry))
+	}
+	return target
+}
+
+func extractEntryToFile(file *zip.File, targetPath string) (err error) {
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(targetPath)); err != nil {
+		return
+	}
+	log.Info(fmt.Sprintf("Extracting %s to %s", file.Name, targetPath))
+	// The entry is extracted to a temporary file first, so that an existing file isn't overwritten by a partial entry.
+	tempPath := targetPath + ".jfrog-extract"
+	targetFile, err := os.Create(tempPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, errorutils.CheckError(os.Remove(tempPath)))
+		}
+	}()
+	err = extractEntry(file, targetFile)
+	if closeErr := targetFile.Close(); err == nil {
+		err = errorutils.CheckError(closeErr)
+	}
+	if err != nil {
+		return
+	}
+	return errorutils.CheckError(os.Rename(tempPath, targetPath))
+}
+
+// Writes the uncompressed content of the entry. The content is verified against the entry's CRC-32 checksum.
+func extractEntry(file *zip.File, writer io.Writer) error {
+	entryReader, err := file.Open()
+	if err != nil {
+		return errorutils.CheckErrorf("failed opening '%s': %s", file.Name, err.Error())
+	}
+	_, err = io.Copy(writer, entryReader)
+	if err != nil {
+		err = errorutils.CheckErrorf("failed extracting '%s': %s", file.Name, err.Error())
+	}
+	return errors.Join(err, errorutils.CheckError(entryReader.Close()))
+}
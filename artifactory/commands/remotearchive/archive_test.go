@@ -0,0 +1,92 @@
+package remotearchive
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Creates a zip archive with a large stored entry, followed by small entries.
+func createArchive(t *testing.T) []byte {
+	var archive bytes.Buffer
+	zipWriter := zip.NewWriter(&archive)
+	large, err := zipWriter.CreateHeader(&zip.FileHeader{Name: "large.bin", Method: zip.Store})
+	require.NoError(t, err)
+	largeContent := make([]byte, 5*blockSize)
+	_, err = rand.Read(largeContent)
+	require.NoError(t, err)
+	_, err = large.Write(largeContent)
+	require.NoError(t, err)
+	_, err = zipWriter.Create("docs/")
+	require.NoError(t, err)
+	readme, err := zipWriter.Create("docs/README.md")
+	require.NoError(t, err)
+	_, err = readme.Write([]byte("# readme"))
+	require.NoError(t, err)
+	require.NoError(t, zipWriter.Close())
+	return archive.Bytes()
+}
+
+func newBytesRangeReader(content []byte) *rangeReader {
+	return newRangeReader(int64(len(content)), func(offset, length int64) ([]byte, error) {
+		return content[offset : offset+length], nil
+	})
+}
+
+func TestListAndExtractEntries(t *testing.T) {
+	archive := createArchive(t)
+	reader := newBytesRangeReader(archive)
+	zipReader, err := zip.NewReader(reader, reader.size)
+	require.NoError(t, err)
+
+	entries, err := listEntries(zipReader, "")
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, uint64(5*blockSize), entries[0].Size)
+	assert.True(t, entries[1].IsDir)
+	entries, err = listEntries(zipReader, "docs/*.md")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "docs/README.md", entries[0].Name)
+
+	file, err := findEntry(zipReader, "docs/README.md")
+	require.NoError(t, err)
+	targetDir := t.TempDir()
+	targetPath := getTargetPath(targetDir, file.Name)
+	assert.Equal(t, filepath.Join(targetDir, "README.md"), targetPath)
+	require.NoError(t, extractEntryToFile(file, targetPath))
+	content, err := os.ReadFile(targetPath)
+	require.NoError(t, err)
+	assert.Equal(t, "# readme", string(content))
+	// Only the last block, which contains the small entry and the central directory, was read.
+	assert.Equal(t, int64(blockSize), reader.fetched)
+
+	// Extracting the large entry reads the blocks of its content.
+	file, err = findEntry(zipReader, "large.bin")
+	require.NoError(t, err)
+	var largeContent bytes.Buffer
+	require.NoError(t, extractEntry(file, &largeContent))
+	dataOffset, err := file.DataOffset()
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(archive[dataOffset:dataOffset+5*blockSize], largeContent.Bytes()))
+
+	_, err = findEntry(zipReader, "docs/")
+	assert.ErrorContains(t, err, "is a directory")
+	_, err = findEntry(zipReader, "missing.txt")
+	assert.ErrorContains(t, err, "doesn't contain")
+}
+
+func TestSplitEntryPath(t *testing.T) {
+	archivePath, entry := SplitEntryPath("repo/libs/app.jar!/META-INF/MANIFEST.MF")
+	assert.Equal(t, "repo/libs/app.jar", archivePath)
+	assert.Equal(t, "META-INF/MANIFEST.MF", entry)
+	archivePath, entry = SplitEntryPath("repo/libs/app.jar")
+	assert.Equal(t, "repo/libs/app.jar", archivePath)
+	assert.Empty(t, entry)
+}
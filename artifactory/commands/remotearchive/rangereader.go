@@ -0,0 +1,112 @@
+package remotearchive
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The size of the ranges requested from Artifactory. A zip's central directory is usually read with a single request.
+	blockSize = 1024 * 1024
+	// The number of blocks kept in memory, so that reading an entry doesn't request the blocks around it again.
+	cachedBlocks = 4
+)
+
+// Reads the content of a file with a fetch of a byte range.
+type fetchRange func(offset, length int64) ([]byte, error)
+
+// An io.ReaderAt over a remote file, which reads it in blocks, so that only the blocks which are needed are downloaded.
+type rangeReader struct {
+	size   int64
+	fetch  fetchRange
+	blocks map[int64][]byte
+	// The cached blocks, in the order they were fetched.
+	order []int64
+	// The number of bytes fetched from the remote file.
+	fetched int64
+}
+
+func newRangeReader(size int64, fetch fetchRange) *rangeReader {
+	return &rangeReader{size: size, fetch: fetch, blocks: make(map[int64][]byte)}
+}
+
+func (rr *rangeReader) ReadAt(p []byte, offset int64) (n int, err error) {
+	if offset < 0 {
+		return 0, errorutils.CheckErrorf("negative offset %d", offset)
+	}
+	for n < len(p) {
+		if offset >= rr.size {
+			return n, io.EOF
+		}
+		// The blocks are aligned to the end of the file, where the central directory of a zip is.
+		blockIndex := (rr.size - 1 - offset) / blockSize
+		block, blockStart, err := rr.getBlock(blockIndex)
+		if err != nil {
+			return n, err
+		}
+		copied := copy(p[n:], block[offset-blockStart:])
+		n += copied
+		offset += int64(copied)
+	}
+	return n, nil
+}
+
+func (rr *rangeReader) getBlock(blockIndex int64) (block []byte, blockStart int64, err error) {
+	blockEnd := rr.size - blockIndex*blockSize
+	blockStart = blockEnd - blockSize
+	if blockStart < 0 {
+		blockStart = 0
+	}
+	if block, exists := rr.blocks[blockIndex]; exists {
+		return block, blockStart, nil
+	}
+	length := blockEnd - blockStart
+	if block, err = rr.fetch(blockStart, length); err != nil {
+		return
+	}
+	if int64(len(block)) != length {
+		return nil, 0, errorutils.CheckErrorf("expected %d bytes at offset %d, but received %d bytes", length, blockStart, len(block))
+	}
+	rr.fetched += length
+	if len(rr.order) == cachedBlocks {
+		delete(rr.blocks, rr.order[0])
+		rr.order = rr.order[1:]
+	}
+	rr.blocks[blockIndex] = block
+	rr.order = append(rr.order, blockIndex)
+	return block, blockStart, nil
+}
+
+// Returns a reader over a file in Artifactory, and its size.
+// Fails if Artifactory doesn't support range requests for the file.
+func newArtifactRangeReader(servicesManager artifactory.ArtifactoryServicesManager, artifactUrl string) (*rangeReader, error) {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	fileDetails, resp, err := servicesManager.Client().GetRemoteFileDetails(artifactUrl, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Header.Get("Accept-Ranges") != "bytes" {
+		return nil, errorutils.CheckErrorf("the server doesn't support range requests for '%s'", artifactUrl)
+	}
+	fetch := func(offset, length int64) ([]byte, error) {
+		rangeDetails := serviceDetails.CreateHttpClientDetails()
+		servicesutils.AddHeader("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1), &rangeDetails.Headers)
+		log.Debug(fmt.Sprintf("Reading bytes %d-%d of %s", offset, offset+length-1, artifactUrl))
+		resp, body, _, err := servicesManager.Client().SendGet(artifactUrl, true, &rangeDetails)
+		if err != nil {
+			return nil, err
+		}
+		if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusPartialContent); err != nil {
+			return nil, err
+		}
+		return body, nil
+	}
+	return newRangeReader(fileDetails.Size, fetch), nil
+}
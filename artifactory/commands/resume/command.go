@@ -0,0 +1,124 @@
+package resume
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/jfrog/gofrog/parallel"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	rtcommandsutils "github.com/jfrog/jfrog-cli/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	ioutils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const tasksCapacity = 10000
+
+type produceTasksFunc func(producer parallel.Runner, errorsQueue *clientutils.ErrorsQueue)
+
+// The common part of the resumable upload and download commands.
+type transferCommand struct {
+	serverDetails      *config.ServerDetails
+	spec               *spec.SpecFiles
+	retries            int
+	retryWaitMilliSecs int
+	progress           ioutils.ProgressMgr
+	result             *commandsutils.Result
+	servicesManager    artifactory.ArtifactoryServicesManager
+	journal            *Journal
+	resultsWriter      *content.ContentWriter
+	successCount       int
+	failCount          int
+	countersMutex      sync.Mutex
+}
+
+func (tc *transferCommand) ServerDetails() (*config.ServerDetails, error) {
+	return tc.serverDetails, nil
+}
+
+func (tc *transferCommand) SetProgress(progress ioutils.ProgressMgr) {
+	tc.progress = progress
+}
+
+func (tc *transferCommand) Result() *commandsutils.Result {
+	return tc.result
+}
+
+func (tc *transferCommand) run(commandName string, threads int, produceTasks produceTasksFunc) (err error) {
+	tc.result = new(commandsutils.Result)
+	if tc.progress != nil {
+		tc.progress.InitProgressReaders()
+	}
+	tc.servicesManager, err = utils.CreateServiceManager(tc.serverDetails, tc.retries, tc.retryWaitMilliSecs, false)
+	if err != nil {
+		return
+	}
+	journalId, err := rtcommandsutils.CreateSpecId(commandName, tc.serverDetails.ArtifactoryUrl, tc.spec)
+	if err != nil {
+		return
+	}
+	if tc.journal, err = OpenJournal(journalId); err != nil {
+		return
+	}
+	if tc.resultsWriter, err = content.NewContentWriter(content.DefaultKey, true, false); err != nil {
+		return errors.Join(err, tc.journal.Close())
+	}
+
+	errorsQueue := clientutils.NewErrorsQueue(1)
+	producerConsumer := parallel.NewRunner(threads, tasksCapacity, false)
+	go func() {
+		defer producerConsumer.Done()
+		produceTasks(producerConsumer, errorsQueue)
+	}()
+	producerConsumer.Run()
+
+	err = tc.resultsWriter.Close()
+	tc.result.SetReader(content.NewContentReader(tc.resultsWriter.GetFilePath(), content.DefaultKey))
+	tc.result.SetSuccessCount(tc.successCount)
+	tc.result.SetFailCount(tc.failCount)
+	if err = errors.Join(err, errorsQueue.GetError()); err != nil || tc.failCount > 0 {
+		log.Info("The transfer journal was kept. Run the same command with the --resume option again, to continue from where it stopped.")
+		return errors.Join(err, tc.journal.Close())
+	}
+	return tc.journal.Delete()
+}
+
+func (tc *transferCommand) createHttpClientDetails() httputils.HttpClientDetails {
+	httpClientDetails := tc.servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	if httpClientDetails.Headers == nil {
+		httpClientDetails.Headers = make(map[string]string)
+	}
+	servicesutils.AddAuthHeaders(httpClientDetails.Headers, tc.servicesManager.GetConfig().GetServiceDetails())
+	return httpClientDetails
+}
+
+func (tc *transferCommand) incrementGeneralProgress() {
+	if tc.progress != nil {
+		tc.progress.IncrementGeneralProgress()
+	}
+}
+
+// Counts the file as succeeded or failed, and adds the succeeded files to the command result.
+func (tc *transferCommand) countResult(err error, entry *FileEntry, sha256 string) {
+	tc.countersMutex.Lock()
+	defer tc.countersMutex.Unlock()
+	if err != nil {
+		log.Error("Failed transferring", entry.Source+":", err.Error())
+		tc.failCount++
+		return
+	}
+	tc.successCount++
+	tc.resultsWriter.Write(clientutils.FileTransferDetails{
+		SourcePath: entry.Source,
+		TargetPath: entry.Target,
+		RtUrl:      tc.serverDetails.ArtifactoryUrl,
+		Sha256:     sha256,
+	})
+}
@@ -0,0 +1,374 @@
+package resume
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioutils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	partialFileName = "partial"
+	mergedFileName  = "merged"
+)
+
+// Downloads the files matching the spec, while recording the completed files and chunks in a journal.
+// Running the command again with the same spec skips the files that were already downloaded,
+// and continues split downloads from the chunks that were already completed.
+type ResumableDownloadCommand struct {
+	transferCommand
+	configuration *utils.DownloadConfiguration
+}
+
+func NewResumableDownloadCommand() *ResumableDownloadCommand {
+	return &ResumableDownloadCommand{}
+}
+
+func (rdc *ResumableDownloadCommand) SetServerDetails(serverDetails *config.ServerDetails) *ResumableDownloadCommand {
+	rdc.serverDetails = serverDetails
+	return rdc
+}
+
+func (rdc *ResumableDownloadCommand) SetSpec(spec *spec.SpecFiles) *ResumableDownloadCommand {
+	rdc.spec = spec
+	return rdc
+}
+
+func (rdc *ResumableDownloadCommand) SetConfiguration(configuration *utils.DownloadConfiguration) *ResumableDownloadCommand {
+	rdc.configuration = configuration
+	return rdc
+}
+
+func (rdc *ResumableDownloadCommand) SetRetries(retries int) *ResumableDownloadCommand {
+	rdc.retries = retries
+	return rdc
+}
+
+func (rdc *ResumableDownloadCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *ResumableDownloadCommand {
+	rdc.retryWaitMilliSecs = retryWaitMilliSecs
+	return rdc
+}
+
+func (rdc *ResumableDownloadCommand) CommandName() string {
+	return "rt_download_resume"
+}
+
+func (rdc *ResumableDownloadCommand) Run() error {
+	return rdc.run(rdc.CommandName(), rdc.configuration.Threads, rdc.produceDownloadTasks)
+}
+
+func (rdc *ResumableDownloadCommand) produceDownloadTasks(producer parallel.Runner, errorsQueue *clientutils.ErrorsQueue) {
+	for _, file := range rdc.spec.Files {
+		file := file
+		if err := rdc.produceSpecFileTasks(&file, producer, errorsQueue); err != nil {
+			log.Error(err)
+			errorsQueue.AddError(err)
+		}
+	}
+}
+
+func (rdc *ResumableDownloadCommand) produceSpecFileTasks(file *spec.File, producer parallel.Runner, errorsQueue *clientutils.ErrorsQueue) (err error) {
+	searchParams, err := utils.GetSearchParams(file)
+	if err != nil {
+		return
+	}
+	flat, err := file.IsFlat(false)
+	if err != nil {
+		return
+	}
+	log.Info("Searching items to download...")
+	reader, err := rdc.servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	if rdc.progress != nil {
+		total, _ := reader.Length()
+		rdc.progress.IncGeneralProgressTotalBy(int64(total))
+	}
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		if item.Type == "folder" {
+			continue
+		}
+		target, placeholdersUsed, err := clientutils.BuildTargetPath(file.Pattern, item.GetItemRelativePath(), file.Target, true)
+		if err != nil {
+			return err
+		}
+		localPath, localFileName := fileutils.GetLocalPathAndFile(item.Name, item.Path, target, flat, placeholdersUsed)
+		downloadItem := *item
+		_, _ = producer.AddTaskWithError(func(threadId int) error {
+			return rdc.downloadFile(threadId, &downloadItem, filepath.Join(localPath, localFileName))
+		}, errorsQueue.AddError)
+	}
+	return reader.GetError()
+}
+
+func (rdc *ResumableDownloadCommand) downloadFile(threadId int, item *servicesutils.ResultItem, localFilePath string) (err error) {
+	logMsgPrefix := clientutils.GetLogMsgPrefix(threadId, false)
+	entry := &FileEntry{Source: item.GetItemRelativePath(), Target: localFilePath, Size: item.Size, Sha1: item.Actual_Sha1}
+	defer func() {
+		rdc.incrementGeneralProgress()
+		rdc.countResult(err, entry, item.Sha256)
+	}()
+	if rdc.journal.IsDone(entry) {
+		if fileInfo, statErr := os.Stat(localFilePath); statErr == nil && fileInfo.Size() == item.Size {
+			log.Debug(logMsgPrefix+"Skipping", entry.Source, "- it was already downloaded.")
+			return nil
+		}
+	}
+	isEqual, err := fileutils.IsEqualToLocalFile(localFilePath, item.Actual_Md5, item.Actual_Sha1)
+	if err != nil {
+		return
+	}
+	if isEqual {
+		log.Debug(logMsgPrefix+"File already exists locally:", localFilePath)
+		return rdc.journal.MarkDone(entry)
+	}
+	log.Info(logMsgPrefix+"Downloading", entry.Source)
+	downloadUrl, err := servicesutils.BuildArtifactoryUrl(rdc.serverDetails.ArtifactoryUrl, entry.Source, make(map[string]string))
+	if err != nil {
+		return
+	}
+	dataDir := rdc.journal.GetFileDataDir(entry)
+	if err = fileutils.CreateDirIfNotExist(dataDir); err != nil {
+		return
+	}
+	var progress ioutils.Progress
+	if rdc.progress != nil {
+		progress = rdc.progress.NewProgressReader(item.Size, "Downloading", entry.Source)
+		defer rdc.progress.RemoveProgress(progress.GetId())
+	}
+	var downloadedFilePath string
+	if rdc.shouldSplit(item.Size) && rdc.isAcceptRanges(downloadUrl) {
+		downloadedFilePath, err = rdc.downloadChunks(logMsgPrefix, downloadUrl, dataDir, entry, progress)
+	} else {
+		downloadedFilePath, err = rdc.downloadWholeFile(logMsgPrefix, downloadUrl, dataDir, entry, progress)
+	}
+	if err != nil {
+		return
+	}
+	if !rdc.configuration.SkipChecksum && item.Actual_Sha1 != "" {
+		if err = verifySha1(downloadedFilePath, item.Actual_Sha1); err != nil {
+			// The content is corrupted, so there's no point in resuming from it.
+			return errors.Join(err, errorutils.CheckError(os.RemoveAll(dataDir)))
+		}
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(localFilePath)); err != nil {
+		return
+	}
+	if err = fileutils.MoveFile(downloadedFilePath, localFilePath); err != nil {
+		return
+	}
+	if err = errorutils.CheckError(os.RemoveAll(dataDir)); err != nil {
+		return
+	}
+	return rdc.journal.MarkDone(entry)
+}
+
+// Files smaller than the split count are never split, since each chunk should contain at least one byte.
+func (rdc *ResumableDownloadCommand) shouldSplit(size int64) bool {
+	return rdc.configuration.SplitCount > 0 && int64(rdc.configuration.SplitCount) <= size &&
+		rdc.configuration.MinSplitSize >= 0 && rdc.configuration.MinSplitSize*1000 <= size
+}
+
+func (rdc *ResumableDownloadCommand) isAcceptRanges(downloadUrl string) bool {
+	httpClientDetails := rdc.createHttpClientDetails()
+	acceptRanges, _, err := rdc.servicesManager.Client().IsAcceptRanges(downloadUrl, &httpClientDetails)
+	if err != nil {
+		log.Debug("Couldn't determine whether the server accepts range requests:", err.Error())
+		return false
+	}
+	return acceptRanges
+}
+
+// Downloads the file into the 'partial' file in the provided data directory.
+// If a partial file was left by a previous run, only the missing bytes are requested.
+func (rdc *ResumableDownloadCommand) downloadWholeFile(logMsgPrefix, downloadUrl, dataDir string, entry *FileEntry, progress ioutils.Progress) (partialFilePath string, err error) {
+	partialFilePath = filepath.Join(dataDir, partialFileName)
+	var offset int64
+	if fileInfo, err := os.Stat(partialFilePath); err == nil && fileInfo.Size() < entry.Size {
+		offset = fileInfo.Size()
+	}
+	if offset > 0 {
+		log.Debug(logMsgPrefix+"Resuming the download of", entry.Source, "from byte", strconv.FormatInt(offset, 10))
+	}
+	body, isPartialContent, err := rdc.sendRangeRequest(logMsgPrefix, downloadUrl, offset, -1)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(body.Close()))
+	}()
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if isPartialContent {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	err = writeToFile(partialFilePath, flags, withProgress(body, progress))
+	return
+}
+
+// Downloads the file in chunks, each chunk is saved in the provided data directory and recorded in the journal once completed.
+// After all chunks are downloaded, they are merged into a single file.
+func (rdc *ResumableDownloadCommand) downloadChunks(logMsgPrefix, downloadUrl, dataDir string, entry *FileEntry, progress ioutils.Progress) (string, error) {
+	chunksCount := rdc.configuration.SplitCount
+	journalEntry := rdc.journal.GetEntry(entry)
+	if journalEntry == nil || journalEntry.ChunksCount != chunksCount {
+		journalEntry = entry
+	}
+	chunkSize := entry.Size / int64(chunksCount)
+	var wg sync.WaitGroup
+	chunksErrors := make([]error, chunksCount)
+	for i := 0; i < chunksCount; i++ {
+		if journalEntry.IsChunkCompleted(i) {
+			log.Debug(logMsgPrefix+"Skipping chunk", strconv.Itoa(i), "of", entry.Source, "- it was already downloaded.")
+			continue
+		}
+		start := chunkSize * int64(i)
+		end := start + chunkSize - 1
+		if i == chunksCount-1 {
+			end = entry.Size - 1
+		}
+		wg.Add(1)
+		go func(index int, start, end int64) {
+			defer wg.Done()
+			chunksErrors[index] = rdc.downloadChunk(logMsgPrefix, downloadUrl, dataDir, entry, chunksCount, index, start, end, progress)
+		}(i, start, end)
+	}
+	wg.Wait()
+	if err := errors.Join(chunksErrors...); err != nil {
+		return "", err
+	}
+	return mergeChunks(dataDir, chunksCount)
+}
+
+func (rdc *ResumableDownloadCommand) downloadChunk(logMsgPrefix, downloadUrl, dataDir string, entry *FileEntry, chunksCount, index int, start, end int64, progress ioutils.Progress) (err error) {
+	body, isPartialContent, err := rdc.sendRangeRequest(logMsgPrefix, downloadUrl, start, end)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(body.Close()))
+	}()
+	if !isPartialContent {
+		return errorutils.CheckErrorf("the server did not respond with a partial content to a range request for %s", entry.Source)
+	}
+	chunkPath := getChunkPath(dataDir, index)
+	if err = writeToFile(chunkPath+".tmp", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, withProgress(body, progress)); err != nil {
+		return
+	}
+	if err = errorutils.CheckError(os.Rename(chunkPath+".tmp", chunkPath)); err != nil {
+		return
+	}
+	return rdc.journal.MarkChunkDone(entry, chunksCount, index)
+}
+
+// Sends a GET request for the provided byte range. An end lower than 0 stands for the end of the file.
+// Returns the response body, and whether the server responded with a partial content.
+func (rdc *ResumableDownloadCommand) sendRangeRequest(logMsgPrefix, downloadUrl string, start, end int64) (io.ReadCloser, bool, error) {
+	httpClientDetails := rdc.createHttpClientDetails()
+	if start > 0 || end >= 0 {
+		rangeEnd := ""
+		if end >= 0 {
+			rangeEnd = strconv.FormatInt(end, 10)
+		}
+		servicesutils.AddHeader("Range", fmt.Sprintf("bytes=%d-%s", start, rangeEnd), &httpClientDetails.Headers)
+	}
+	resp, _, _, err := rdc.servicesManager.Client().Send(http.MethodGet, downloadUrl, nil, true, false, &httpClientDetails, logMsgPrefix)
+	if err != nil {
+		return nil, false, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, false, errors.Join(errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusPartialContent), errorutils.CheckError(resp.Body.Close()))
+	}
+	return resp.Body, resp.StatusCode == http.StatusPartialContent, nil
+}
+
+func getChunkPath(dataDir string, index int) string {
+	return filepath.Join(dataDir, strconv.Itoa(index))
+}
+
+func mergeChunks(dataDir string, chunksCount int) (mergedFilePath string, err error) {
+	mergedFilePath = filepath.Join(dataDir, mergedFileName)
+	mergedFile, err := os.Create(mergedFilePath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(mergedFile.Close()))
+	}()
+	for i := 0; i < chunksCount; i++ {
+		if err = appendFile(mergedFile, getChunkPath(dataDir, i)); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func appendFile(target io.Writer, sourcePath string) (err error) {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(source.Close()))
+	}()
+	_, err = io.Copy(target, source)
+	return errorutils.CheckError(err)
+}
+
+func writeToFile(path string, flags int, reader io.Reader) (err error) {
+	file, err := os.OpenFile(path, flags, 0600)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	_, err = io.Copy(file, reader)
+	return errorutils.CheckError(err)
+}
+
+func withProgress(reader io.Reader, progress ioutils.Progress) io.Reader {
+	if progress == nil {
+		return reader
+	}
+	return progress.ActionWithProgress(reader)
+}
+
+func verifySha1(filePath, expectedSha1 string) (err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	hash := sha1.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if actualSha1 := hex.EncodeToString(hash.Sum(nil)); actualSha1 != expectedSha1 {
+		return errorutils.CheckErrorf("checksum mismatch for %s: expected SHA-1 %s, but got %s", filePath, expectedSha1, actualSha1)
+	}
+	return nil
+}
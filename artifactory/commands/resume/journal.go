@@ -0,0 +1,214 @@
+package resume
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	JournalsDirName   = "journals"
+	journalFileSuffix = ".jsonl"
+)
+
+// A journal records the progress of an upload or a download command, so that an interrupted run can be resumed.
+// The journal is identified by the command name, the server URL and the file spec, and is stored as an append-only
+// JSON lines file under the JFrog CLI home directory. Each line is a single FileEntry, the last line of a file wins.
+// Split downloads keep their completed chunks in a directory next to the journal file.
+type Journal struct {
+	id      string
+	path    string
+	entries map[string]*FileEntry
+	file    *os.File
+	mutex   sync.Mutex
+}
+
+type FileEntry struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Size   int64  `json:"size"`
+	// The modification time of the local file (uploads) or the SHA-1 of the remote file (downloads),
+	// used to make sure the file did not change since it was recorded.
+	ModTime int64  `json:"modTime,omitempty"`
+	Sha1    string `json:"sha1,omitempty"`
+	Done    bool   `json:"done,omitempty"`
+	// The number of chunks a split download was divided into, and the indexes of the chunks that were completed.
+	ChunksCount     int   `json:"chunksCount,omitempty"`
+	CompletedChunks []int `json:"completedChunks,omitempty"`
+}
+
+func (fe *FileEntry) key() string {
+	return fe.Source + " -> " + fe.Target
+}
+
+// Returns true if the entry describes the same file version as the other entry.
+func (fe *FileEntry) isSameFile(other *FileEntry) bool {
+	return fe.Size == other.Size && fe.ModTime == other.ModTime && fe.Sha1 == other.Sha1
+}
+
+func (fe *FileEntry) IsChunkCompleted(index int) bool {
+	for _, completed := range fe.CompletedChunks {
+		if completed == index {
+			return true
+		}
+	}
+	return false
+}
+
+func GetJournalsDir() (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, JournalsDirName), nil
+}
+
+// Opens the journal with the provided ID, or creates a new one if it does not exist yet.
+func OpenJournal(id string) (*Journal, error) {
+	journalsDir, err := GetJournalsDir()
+	if err != nil {
+		return nil, err
+	}
+	return openJournalInDir(journalsDir, id)
+}
+
+func openJournalInDir(journalsDir, id string) (journal *Journal, err error) {
+	if err = fileutils.CreateDirIfNotExist(journalsDir); err != nil {
+		return
+	}
+	journal = &Journal{id: id, path: filepath.Join(journalsDir, id), entries: make(map[string]*FileEntry)}
+	if err = journal.load(); err != nil {
+		return
+	}
+	if len(journal.entries) > 0 {
+		log.Info("Resuming from the journal of a previous run:", journal.path+journalFileSuffix)
+	}
+	journal.file, err = os.OpenFile(journal.path+journalFileSuffix, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	return journal, errorutils.CheckError(err)
+}
+
+func (j *Journal) load() (err error) {
+	file, err := os.Open(j.path + journalFileSuffix)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry := new(FileEntry)
+		if json.Unmarshal(scanner.Bytes(), entry) != nil {
+			// The last line may be partially written if the previous run was killed while writing it.
+			log.Debug("Skipping a malformed journal line:", scanner.Text())
+			continue
+		}
+		j.entries[entry.key()] = entry
+	}
+	return errorutils.CheckError(scanner.Err())
+}
+
+func (j *Journal) Id() string {
+	return j.id
+}
+
+// Returns the journal entry of the provided file, or nil if the file was not recorded,
+// or was recorded with a different size, modification time or checksum.
+func (j *Journal) GetEntry(file *FileEntry) *FileEntry {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	entry, exists := j.entries[file.key()]
+	if !exists || !entry.isSameFile(file) {
+		return nil
+	}
+	copied := *entry
+	copied.CompletedChunks = append([]int{}, entry.CompletedChunks...)
+	return &copied
+}
+
+// Returns true if the provided file was already fully transferred.
+func (j *Journal) IsDone(file *FileEntry) bool {
+	entry := j.GetEntry(file)
+	return entry != nil && entry.Done
+}
+
+func (j *Journal) MarkDone(file *FileEntry) error {
+	entry := *file
+	entry.Done = true
+	entry.ChunksCount = 0
+	entry.CompletedChunks = nil
+	return j.write(&entry)
+}
+
+// Records a completed chunk of a split download.
+// The lock is held from reading the completed chunks until the entry is written, so that concurrently completed chunks aren't lost.
+func (j *Journal) MarkChunkDone(file *FileEntry, chunksCount, index int) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	entry := *file
+	if existing, exists := j.entries[file.key()]; exists && existing.isSameFile(file) && existing.ChunksCount == chunksCount {
+		entry.CompletedChunks = append([]int{}, existing.CompletedChunks...)
+	}
+	entry.ChunksCount = chunksCount
+	if !entry.IsChunkCompleted(index) {
+		entry.CompletedChunks = append(entry.CompletedChunks, index)
+	}
+	return j.writeLocked(&entry)
+}
+
+func (j *Journal) write(entry *FileEntry) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	return j.writeLocked(entry)
+}
+
+// Writes the entry to the journal file. The caller must hold the journal lock.
+func (j *Journal) writeLocked(entry *FileEntry) error {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if _, err = j.file.Write(append(content, '\n')); err != nil {
+		return errorutils.CheckError(err)
+	}
+	j.entries[entry.key()] = entry
+	return nil
+}
+
+// Returns the directory in which the chunks and partial content of the provided file are kept.
+// The directory is unique to the file version, so that partial content of a file which was since changed is never resumed from.
+func (j *Journal) GetFileDataDir(file *FileEntry) string {
+	checksum := sha1.Sum([]byte(file.key() + " " + strconv.FormatInt(file.Size, 10) + " " + file.Sha1))
+	return filepath.Join(j.path, hex.EncodeToString(checksum[:]))
+}
+
+// Closes the journal file. The journal is kept on the file system, so that a later run can resume from it.
+func (j *Journal) Close() error {
+	return errorutils.CheckError(j.file.Close())
+}
+
+// Closes and deletes the journal, after the command completed successfully.
+func (j *Journal) Delete() error {
+	if err := j.Close(); err != nil {
+		return err
+	}
+	if err := errorutils.CheckError(os.Remove(j.path + journalFileSuffix)); err != nil {
+		return err
+	}
+	return errorutils.CheckError(os.RemoveAll(j.path))
+}
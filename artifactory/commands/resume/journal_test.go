@@ -0,0 +1,92 @@
+package resume
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournalResume(t *testing.T) {
+	journalsDir := t.TempDir()
+	done := &FileEntry{Source: "repo/a/1.bin", Target: "a/1.bin", Size: 10, Sha1: "sha1-1"}
+	split := &FileEntry{Source: "repo/a/2.bin", Target: "a/2.bin", Size: 300, Sha1: "sha1-2"}
+
+	journal, err := openJournalInDir(journalsDir, "id")
+	assert.NoError(t, err)
+	assert.False(t, journal.IsDone(done))
+	assert.NoError(t, journal.MarkDone(done))
+	assert.NoError(t, journal.MarkChunkDone(split, 3, 0))
+	assert.NoError(t, journal.MarkChunkDone(split, 3, 2))
+	assert.NoError(t, journal.Close())
+
+	// Simulate a partially written line, as if the previous run was killed while writing.
+	journalFile, err := os.OpenFile(filepath.Join(journalsDir, "id"+journalFileSuffix), os.O_APPEND|os.O_WRONLY, 0600)
+	assert.NoError(t, err)
+	_, err = journalFile.WriteString(`{"source":"repo/a/3.bin","tar`)
+	assert.NoError(t, err)
+	assert.NoError(t, journalFile.Close())
+
+	journal, err = openJournalInDir(journalsDir, "id")
+	assert.NoError(t, err)
+	assert.True(t, journal.IsDone(done))
+	assert.False(t, journal.IsDone(split))
+	entry := journal.GetEntry(split)
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, 3, entry.ChunksCount)
+		assert.True(t, entry.IsChunkCompleted(0))
+		assert.False(t, entry.IsChunkCompleted(1))
+		assert.True(t, entry.IsChunkCompleted(2))
+	}
+
+	// A file which changed since it was recorded should be transferred again.
+	modified := *done
+	modified.Sha1 = "sha1-modified"
+	assert.False(t, journal.IsDone(&modified))
+	assert.Nil(t, journal.GetEntry(&modified))
+	// The partial content of a file which changed is kept apart.
+	modifiedSplit := *split
+	modifiedSplit.Sha1 = "sha1-modified"
+	assert.NotEqual(t, journal.GetFileDataDir(split), journal.GetFileDataDir(&modifiedSplit))
+
+	assert.NoError(t, os.MkdirAll(journal.GetFileDataDir(split), 0700))
+	assert.NoError(t, journal.Delete())
+	assert.NoFileExists(t, filepath.Join(journalsDir, "id"+journalFileSuffix))
+	assert.NoDirExists(t, filepath.Join(journalsDir, "id"))
+}
+
+func TestJournalConcurrentChunks(t *testing.T) {
+	journal, err := openJournalInDir(t.TempDir(), "id")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, journal.Close())
+	}()
+	split := &FileEntry{Source: "repo/a/2.bin", Target: "a/2.bin", Size: 300, Sha1: "sha1-2"}
+	const chunksCount = 50
+	var wg sync.WaitGroup
+	for i := 0; i < chunksCount; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			assert.NoError(t, journal.MarkChunkDone(split, chunksCount, index))
+		}(i)
+	}
+	wg.Wait()
+	entry := journal.GetEntry(split)
+	if assert.NotNil(t, entry) {
+		assert.Len(t, entry.CompletedChunks, chunksCount)
+	}
+}
+
+func TestShouldSplit(t *testing.T) {
+	rdc := NewResumableDownloadCommand().SetConfiguration(&utils.DownloadConfiguration{SplitCount: 3, MinSplitSize: 0})
+	assert.True(t, rdc.shouldSplit(3))
+	assert.False(t, rdc.shouldSplit(2))
+	assert.False(t, rdc.shouldSplit(0))
+	rdc.configuration.MinSplitSize = 1
+	assert.False(t, rdc.shouldSplit(999))
+	assert.True(t, rdc.shouldSplit(1000))
+}
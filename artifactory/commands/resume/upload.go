@@ -0,0 +1,196 @@
+package resume
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	minChecksumDeploySizeEnv = "JFROG_CLI_MIN_CHECKSUM_DEPLOY_SIZE_KB"
+	// In bytes, the same default as the upload command.
+	defaultMinChecksumDeploySize = 10240
+)
+
+// Uploads the files matching the spec, while recording the completed files in a journal.
+// Running the command again with the same spec skips the files that were already uploaded,
+// as long as they were not modified since.
+type ResumableUploadCommand struct {
+	transferCommand
+	configuration *utils.UploadConfiguration
+}
+
+func NewResumableUploadCommand() *ResumableUploadCommand {
+	return &ResumableUploadCommand{}
+}
+
+func (ruc *ResumableUploadCommand) SetServerDetails(serverDetails *config.ServerDetails) *ResumableUploadCommand {
+	ruc.serverDetails = serverDetails
+	return ruc
+}
+
+func (ruc *ResumableUploadCommand) SetSpec(spec *spec.SpecFiles) *ResumableUploadCommand {
+	ruc.spec = spec
+	return ruc
+}
+
+func (ruc *ResumableUploadCommand) SetUploadConfiguration(configuration *utils.UploadConfiguration) *ResumableUploadCommand {
+	ruc.configuration = configuration
+	return ruc
+}
+
+func (ruc *ResumableUploadCommand) SetRetries(retries int) *ResumableUploadCommand {
+	ruc.retries = retries
+	return ruc
+}
+
+func (ruc *ResumableUploadCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *ResumableUploadCommand {
+	ruc.retryWaitMilliSecs = retryWaitMilliSecs
+	return ruc
+}
+
+func (ruc *ResumableUploadCommand) CommandName() string {
+	return "rt_upload_resume"
+}
+
+func (ruc *ResumableUploadCommand) Run() (err error) {
+	ruc.configuration.MinChecksumDeploySize, err = getMinChecksumDeploySize()
+	if err != nil {
+		return
+	}
+	return ruc.run(ruc.CommandName(), ruc.configuration.Threads, ruc.produceUploadTasks)
+}
+
+func (ruc *ResumableUploadCommand) produceUploadTasks(producer parallel.Runner, errorsQueue *clientutils.ErrorsQueue) {
+	vcsCache := clientutils.NewVcsDetails()
+	for i := range ruc.spec.Files {
+		uploadParams, err := ruc.createUploadParams(ruc.spec.Get(i))
+		if err == nil {
+			err = services.CollectFilesForUpload(uploadParams, ruc.progress, vcsCache, func(data services.UploadData) {
+				if data.IsDir {
+					return
+				}
+				_, _ = producer.AddTaskWithError(func(threadId int) error {
+					return ruc.uploadFile(threadId, data)
+				}, errorsQueue.AddError)
+			})
+		}
+		if err != nil {
+			log.Error(err)
+			errorsQueue.AddError(err)
+		}
+	}
+}
+
+func (ruc *ResumableUploadCommand) createUploadParams(file *spec.File) (uploadParams services.UploadParams, err error) {
+	uploadParams = services.NewUploadParams()
+	file.TargetProps = clientutils.AddProps(file.TargetProps, file.Props)
+	if uploadParams.CommonParams, err = file.ToCommonParams(); err != nil {
+		return
+	}
+	uploadParams.MinChecksumDeploy = ruc.configuration.MinChecksumDeploySize
+	uploadParams.ChecksumsCalcEnabled = true
+	if uploadParams.Recursive, err = file.IsRecursive(true); err != nil {
+		return
+	}
+	if uploadParams.Regexp, err = file.IsRegexp(false); err != nil {
+		return
+	}
+	if uploadParams.Ant, err = file.IsAnt(false); err != nil {
+		return
+	}
+	uploadParams.Flat, err = file.IsFlat(true)
+	return
+}
+
+func (ruc *ResumableUploadCommand) uploadFile(threadId int, data services.UploadData) (err error) {
+	logMsgPrefix := clientutils.GetLogMsgPrefix(threadId, false)
+	entry := &FileEntry{Source: data.Artifact.LocalPath, Target: data.Artifact.TargetPath}
+	var sha256 string
+	defer func() {
+		ruc.incrementGeneralProgress()
+		ruc.countResult(err, entry, sha256)
+	}()
+	fileInfo, err := os.Stat(entry.Source)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	entry.Size, entry.ModTime = fileInfo.Size(), fileInfo.ModTime().UnixNano()
+	if ruc.journal.IsDone(entry) {
+		log.Debug(logMsgPrefix+"Skipping", entry.Source, "- it was already uploaded.")
+		return nil
+	}
+	log.Info(logMsgPrefix+"Uploading:", entry.Source)
+	targetUrl, err := servicesutils.BuildArtifactoryUrl(ruc.serverDetails.ArtifactoryUrl, entry.Target, make(map[string]string))
+	if err != nil {
+		return
+	}
+	if encodedProps := data.TargetProps.ToEncodedString(false); encodedProps != "" {
+		targetUrl = strings.Join([]string{targetUrl, encodedProps}, ";")
+	}
+	details, err := fileutils.GetFileDetails(entry.Source, true)
+	if err != nil {
+		return
+	}
+	httpClientDetails := ruc.createHttpClientDetails()
+	deployed := false
+	if entry.Size >= ruc.configuration.MinChecksumDeploySize {
+		if deployed, err = ruc.tryChecksumDeploy(targetUrl, details, httpClientDetails.Clone()); err != nil {
+			return
+		}
+	}
+	if !deployed {
+		serviceDetails := ruc.servicesManager.GetConfig().GetServiceDetails()
+		var resp *http.Response
+		var body []byte
+		resp, body, err = servicesutils.UploadFile(entry.Source, targetUrl, logMsgPrefix, &serviceDetails, details, httpClientDetails, ruc.servicesManager.Client(), true, ruc.progress)
+		if err != nil {
+			return
+		}
+		if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated, http.StatusAccepted); err != nil {
+			return
+		}
+		if sha256, err = clientutils.ExtractSha256FromResponseBody(body); err != nil {
+			return
+		}
+	}
+	if sha256 == "" {
+		sha256 = details.Checksum.Sha256
+	}
+	return ruc.journal.MarkDone(entry)
+}
+
+func (ruc *ResumableUploadCommand) tryChecksumDeploy(targetUrl string, details *fileutils.FileDetails, httpClientDetails *httputils.HttpClientDetails) (bool, error) {
+	servicesutils.AddHeader("X-Checksum-Deploy", "true", &httpClientDetails.Headers)
+	servicesutils.AddChecksumHeaders(httpClientDetails.Headers, details)
+	resp, _, err := ruc.servicesManager.Client().SendPut(targetUrl, nil, httpClientDetails)
+	if err != nil {
+		return false, err
+	}
+	return resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated, nil
+}
+
+func getMinChecksumDeploySize() (int64, error) {
+	minChecksumDeploySize := os.Getenv(minChecksumDeploySizeEnv)
+	if minChecksumDeploySize == "" {
+		return defaultMinChecksumDeploySize, nil
+	}
+	minSize, err := strconv.ParseInt(minChecksumDeploySize, 10, 64)
+	if err != nil {
+		return 0, errorutils.CheckError(err)
+	}
+	return minSize * 1000, nil
+}
@@ -0,0 +1,315 @@
+package searchoutput
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	commandsutils "github.com/jfrog/jfrog-cli/artifactory/commands/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+)
+
+const (
+	propsField       = "props"
+	propsFieldPrefix = propsField + "."
+	// Multiple values of the same property are joined with this separator in the CSV and table formats.
+	propValuesSeparator = ","
+)
+
+// The fields of a search result, in the order they appear in the JSON output.
+var resultFields = []string{"path", "type", "size", "created", "modified", "sha1", "sha256", "md5", "original_md5",
+	"modified_by", "updated", "created_by", "original_sha1", "depth", propsField}
+
+// The fields printed in the CSV and table formats, if no fields were selected.
+var defaultTabularFields = []string{"path", "type", "size", "modified", "sha1", "sha256", "md5"}
+
+// The output formats of the search results. The first format is the default one.
+var Formats = []commandsutils.Format{commandsutils.Json, commandsutils.Jsonl, commandsutils.Csv, commandsutils.Table}
+
+// Parses a comma separated list of fields, such as 'path,size,props.build.name'.
+func ParseFields(fields string) ([]string, error) {
+	if fields == "" {
+		return nil, nil
+	}
+	var parsed []string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if !isValidField(field) {
+			return nil, errorutils.CheckErrorf("unsupported field '%s'. Possible values are: %s, or %s<property key>", field, strings.Join(resultFields, ", "), propsFieldPrefix)
+		}
+		parsed = append(parsed, field)
+	}
+	return parsed, nil
+}
+
+func isValidField(field string) bool {
+	if strings.HasPrefix(field, propsFieldPrefix) {
+		return len(field) > len(propsFieldPrefix)
+	}
+	for _, resultField := range resultFields {
+		if field == resultField {
+			return true
+		}
+	}
+	return false
+}
+
+// Prints the search results in the provided format, with the selected fields only.
+// The results are read one by one from the reader and written to the writer, so large results are never loaded to memory.
+// The table format is the only exception, since the width of each column depends on all the rows.
+func PrintSearchResults(reader *content.ContentReader, format commandsutils.Format, fields []string, writer io.Writer) (err error) {
+	if len(fields) == 0 && (format == commandsutils.Csv || format == commandsutils.Table) {
+		fields = defaultTabularFields
+	}
+	bufferedWriter := bufio.NewWriter(writer)
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(bufferedWriter.Flush()))
+	}()
+	var resultWriter searchResultWriter
+	switch format {
+	case commandsutils.Jsonl:
+		resultWriter = &jsonlWriter{writer: bufferedWriter, fields: fields}
+	case commandsutils.Csv:
+		resultWriter = &csvWriter{writer: csv.NewWriter(bufferedWriter), fields: fields}
+	case commandsutils.Table:
+		resultWriter = &tableWriter{writer: tabwriter.NewWriter(bufferedWriter, 0, 0, 2, ' ', 0), fields: fields}
+	default:
+		resultWriter = &jsonWriter{writer: bufferedWriter, fields: fields}
+	}
+	if err = resultWriter.start(); err != nil {
+		return
+	}
+	for searchResult := new(utils.SearchResult); reader.NextRecord(searchResult) == nil; searchResult = new(utils.SearchResult) {
+		if err = resultWriter.write(searchResult); err != nil {
+			return
+		}
+	}
+	if err = reader.GetError(); err != nil {
+		return
+	}
+	reader.Reset()
+	return resultWriter.end()
+}
+
+type searchResultWriter interface {
+	start() error
+	write(result *utils.SearchResult) error
+	end() error
+}
+
+// Writes the results as an indented JSON array, similarly to the default search output.
+type jsonWriter struct {
+	writer  *bufio.Writer
+	fields  []string
+	written bool
+}
+
+func (w *jsonWriter) start() error {
+	_, err := w.writer.WriteString("[")
+	return errorutils.CheckError(err)
+}
+
+func (w *jsonWriter) write(result *utils.SearchResult) error {
+	data, err := marshalResult(result, w.fields)
+	if err != nil {
+		return err
+	}
+	separator := "\n  "
+	if w.written {
+		separator = ",\n  "
+	}
+	w.written = true
+	_, err = w.writer.WriteString(separator + clientutils.IndentJsonArray(data))
+	return errorutils.CheckError(err)
+}
+
+func (w *jsonWriter) end() (err error) {
+	if w.written {
+		_, err = w.writer.WriteString("\n]\n")
+	} else {
+		_, err = w.writer.WriteString("]\n")
+	}
+	return errorutils.CheckError(err)
+}
+
+// Writes each result as a single line JSON object.
+type jsonlWriter struct {
+	writer *bufio.Writer
+	fields []string
+}
+
+func (w *jsonlWriter) start() error {
+	return nil
+}
+
+func (w *jsonlWriter) write(result *utils.SearchResult) error {
+	data, err := marshalResult(result, w.fields)
+	if err != nil {
+		return err
+	}
+	_, err = w.writer.Write(append(data, '\n'))
+	return errorutils.CheckError(err)
+}
+
+func (w *jsonlWriter) end() error {
+	return nil
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	fields []string
+}
+
+func (w *csvWriter) start() error {
+	return errorutils.CheckError(w.writer.Write(w.fields))
+}
+
+func (w *csvWriter) write(result *utils.SearchResult) error {
+	return errorutils.CheckError(w.writer.Write(getFieldsValues(result, w.fields)))
+}
+
+func (w *csvWriter) end() error {
+	w.writer.Flush()
+	return errorutils.CheckError(w.writer.Error())
+}
+
+type tableWriter struct {
+	writer *tabwriter.Writer
+	fields []string
+}
+
+func (w *tableWriter) start() error {
+	header := make([]string, len(w.fields))
+	for i, field := range w.fields {
+		header[i] = strings.ToUpper(field)
+	}
+	return w.writeRow(header)
+}
+
+func (w *tableWriter) write(result *utils.SearchResult) error {
+	return w.writeRow(getFieldsValues(result, w.fields))
+}
+
+func (w *tableWriter) writeRow(values []string) error {
+	_, err := w.writer.Write([]byte(strings.Join(values, "\t") + "\n"))
+	return errorutils.CheckError(err)
+}
+
+func (w *tableWriter) end() error {
+	return errorutils.CheckError(w.writer.Flush())
+}
+
+// Marshals the result to JSON. If fields were selected, only these fields are included, in the order they were selected.
+func marshalResult(result *utils.SearchResult, fields []string) ([]byte, error) {
+	if len(fields) == 0 {
+		data, err := json.Marshal(result)
+		return data, errorutils.CheckError(err)
+	}
+	var builder strings.Builder
+	builder.WriteString("{")
+	for i, field := range fields {
+		key, err := json.Marshal(field)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		value, err := json.Marshal(getFieldValue(result, field))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.Write(key)
+		builder.WriteString(":")
+		builder.Write(value)
+	}
+	builder.WriteString("}")
+	return []byte(builder.String()), nil
+}
+
+func getFieldValue(result *utils.SearchResult, field string) interface{} {
+	switch field {
+	case "path":
+		return result.Path
+	case "type":
+		return result.Type
+	case "size":
+		return result.Size
+	case "created":
+		return result.Created
+	case "modified":
+		return result.Modified
+	case "sha1":
+		return result.Sha1
+	case "sha256":
+		return result.Sha256
+	case "md5":
+		return result.Md5
+	case "original_md5":
+		return result.OriginalMd5
+	case "modified_by":
+		return result.ModifiedBy
+	case "updated":
+		return result.Updated
+	case "created_by":
+		return result.CreatedBy
+	case "original_sha1":
+		return result.OriginalSha1
+	case "depth":
+		return result.Depth
+	case propsField:
+		if result.Props == nil {
+			return map[string][]string{}
+		}
+		return result.Props
+	default:
+		values := result.Props[strings.TrimPrefix(field, propsFieldPrefix)]
+		if values == nil {
+			return []string{}
+		}
+		return values
+	}
+}
+
+func getFieldsValues(result *utils.SearchResult, fields []string) []string {
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		values[i] = fieldValueToString(getFieldValue(result, field))
+	}
+	return values
+}
+
+func fieldValueToString(value interface{}) string {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue
+	case int64:
+		return strconv.FormatInt(typedValue, 10)
+	case int:
+		return strconv.Itoa(typedValue)
+	case []string:
+		return strings.Join(typedValue, propValuesSeparator)
+	case map[string][]string:
+		keys := make([]string, 0, len(typedValue))
+		for key := range typedValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		props := make([]string, len(keys))
+		for i, key := range keys {
+			props[i] = key + "=" + strings.Join(typedValue[key], propValuesSeparator)
+		}
+		return strings.Join(props, ";")
+	default:
+		return ""
+	}
+}
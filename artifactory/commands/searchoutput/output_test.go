@@ -0,0 +1,83 @@
+package searchoutput
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	commandsutils "github.com/jfrog/jfrog-cli/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+)
+
+func createSearchResultsReader(t *testing.T) *content.ContentReader {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	assert.NoError(t, err)
+	writer.Write(utils.SearchResult{Path: "repo/a.zip", Type: "file", Size: 10, Sha256: "abc", Props: map[string][]string{"build.name": {"b1"}}})
+	writer.Write(utils.SearchResult{Path: "repo/b, c.zip", Type: "file", Size: 20, Props: map[string][]string{"build.name": {"b1", "b2"}}})
+	assert.NoError(t, writer.Close())
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+}
+
+func TestPrintSearchResults(t *testing.T) {
+	tests := []struct {
+		format   commandsutils.Format
+		fields   string
+		expected string
+	}{
+		{commandsutils.Json, "path,props.build.name", `[
+  {
+    "path": "repo/a.zip",
+    "props.build.name": [
+      "b1"
+    ]
+  },
+  {
+    "path": "repo/b, c.zip",
+    "props.build.name": [
+      "b1",
+      "b2"
+    ]
+  }
+]
+`},
+		{commandsutils.Jsonl, "size,path", `{"size":10,"path":"repo/a.zip"}
+{"size":20,"path":"repo/b, c.zip"}
+`},
+		{commandsutils.Jsonl, "", `{"path":"repo/a.zip","type":"file","size":10,"sha256":"abc","props":{"build.name":["b1"]}}
+{"path":"repo/b, c.zip","type":"file","size":20,"props":{"build.name":["b1","b2"]}}
+`},
+		{commandsutils.Csv, "path,size,sha256,props.build.name", `path,size,sha256,props.build.name
+repo/a.zip,10,abc,b1
+"repo/b, c.zip",20,,"b1,b2"
+`},
+		{commandsutils.Table, "path,size", `PATH           SIZE
+repo/a.zip     10
+repo/b, c.zip  20
+`},
+	}
+	for _, test := range tests {
+		t.Run(string(test.format)+":"+test.fields, func(t *testing.T) {
+			reader := createSearchResultsReader(t)
+			defer func() {
+				assert.NoError(t, reader.Close())
+			}()
+			fields, err := ParseFields(test.fields)
+			assert.NoError(t, err)
+			var output bytes.Buffer
+			assert.NoError(t, PrintSearchResults(reader, test.format, fields, &output))
+			assert.Equal(t, test.expected, output.String())
+		})
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields("path, size,props.build.name")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"path", "size", "props.build.name"}, fields)
+
+	for _, invalid := range []string{"name", "props.", "path,,size"} {
+		_, err = ParseFields(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,182 @@
+package speclint
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	commandsutils "github.com/jfrog/jfrog-cli/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli/schema"
+	"github.com/jfrog/jfrog-cli/utils/spectemplate"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+)
+
+// A problem found in a File Spec. The line and column are 0 if the position of the problem is unknown.
+// The position in a File Spec template is the position in the rendered template.
+type Problem struct {
+	File     string   `json:"file" col-name:"File"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	Position string   `json:"-" col-name:"Position"`
+	Field    string   `json:"field,omitempty" col-name:"Field"`
+	Severity Severity `json:"severity" col-name:"Severity"`
+	Rule     string   `json:"rule" col-name:"Rule"`
+	Message  string   `json:"message" col-name:"Message"`
+}
+
+// Validates File Specs against the File Spec schema, and if linting, checks them for semantic problems.
+type SpecLintCommand struct {
+	specPaths []string
+	specVars  map[string]string
+	lint      bool
+	format    commandsutils.Format
+	problems  []Problem
+}
+
+func NewSpecLintCommand() *SpecLintCommand {
+	return &SpecLintCommand{}
+}
+
+func (slc *SpecLintCommand) SetSpecPaths(specPaths []string) *SpecLintCommand {
+	slc.specPaths = specPaths
+	return slc
+}
+
+func (slc *SpecLintCommand) SetSpecVars(specVars map[string]string) *SpecLintCommand {
+	slc.specVars = specVars
+	return slc
+}
+
+func (slc *SpecLintCommand) SetLint(lint bool) *SpecLintCommand {
+	slc.lint = lint
+	return slc
+}
+
+func (slc *SpecLintCommand) SetFormat(format commandsutils.Format) *SpecLintCommand {
+	slc.format = format
+	return slc
+}
+
+func (slc *SpecLintCommand) Problems() []Problem {
+	return slc.problems
+}
+
+func (slc *SpecLintCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (slc *SpecLintCommand) CommandName() string {
+	if slc.lint {
+		return "rt_spec_lint"
+	}
+	return "rt_spec_validate"
+}
+
+// Fails if any of the File Specs has a problem with the error severity. Warnings are printed only.
+func (slc *SpecLintCommand) Run() error {
+	slc.problems = []Problem{}
+	for _, specPath := range slc.specPaths {
+		slc.problems = append(slc.problems, checkSpec(specPath, slc.specVars, slc.lint)...)
+	}
+	if err := slc.print(); err != nil {
+		return err
+	}
+	errorsCount := 0
+	for _, problem := range slc.problems {
+		if problem.Severity == Error {
+			errorsCount++
+		}
+	}
+	if errorsCount > 0 {
+		return errorutils.CheckErrorf("found %d errors in the File Specs", errorsCount)
+	}
+	return nil
+}
+
+func (slc *SpecLintCommand) print() error {
+	return commandsutils.Print(slc.format, slc.problems, func() error {
+		return coreutils.PrintTable(slc.problems, "File Spec problems", "No problems were found", false)
+	})
+}
+
+// Returns the problems of a single File Spec.
+func checkSpec(specPath string, specVars map[string]string, lint bool) []Problem {
+	newProblem := func(fieldPosition position, field, rule, message string) Problem {
+		problem := Problem{File: specPath, Line: fieldPosition.line, Column: fieldPosition.column, Field: field, Severity: Error, Rule: rule, Message: message}
+		if problem.Line > 0 {
+			problem.Position = strconv.Itoa(problem.Line) + ":" + strconv.Itoa(problem.Column)
+		}
+		return problem
+	}
+	content, _, err := spectemplate.RenderTemplate(specPath, specVars)
+	if err != nil {
+		return []Problem{newProblem(position{}, "", "template", err.Error())}
+	}
+
+	var index positions
+	if spectemplate.IsYaml(specPath) {
+		if index, err = indexYaml(content); err != nil {
+			return []Problem{newProblem(position{}, "", "syntax", err.Error())}
+		}
+		if content, err = spectemplate.YamlToJson(content); err != nil {
+			return []Problem{newProblem(position{}, "", "syntax", err.Error())}
+		}
+	} else {
+		var errorPosition position
+		if index, errorPosition, err = indexJson(content); err != nil {
+			return []Problem{newProblem(errorPosition, "", "syntax", "The File Spec isn't a valid JSON: "+err.Error())}
+		}
+	}
+
+	var problems []Problem
+	schemaErrors, err := schema.GetFileSpecErrors(content)
+	if err != nil {
+		return []Problem{newProblem(position{}, "", "schema", err.Error())}
+	}
+	for _, schemaError := range schemaErrors {
+		field := getSchemaErrorField(schemaError)
+		problems = append(problems, newProblem(index.get(field), field, "schema", schemaError.Description()))
+	}
+	if !lint {
+		return problems
+	}
+
+	specFiles := new(spec.SpecFiles)
+	if err = json.Unmarshal(content, specFiles); err != nil {
+		// The schema validation reports the types which don't match the File Spec.
+		return problems
+	}
+	if len(specFiles.Files) == 0 {
+		problems = append(problems, newProblem(index.get("files"), "files", "missing-files", "The File Spec must include at least one file group."))
+	}
+	for i := range specFiles.Files {
+		for _, problem := range lintFile(&specFiles.Files[i], childField("files", strconv.Itoa(i))) {
+			lintProblem := newProblem(index.get(problem.Field), problem.Field, problem.Rule, problem.Message)
+			lintProblem.Severity = problem.Severity
+			problems = append(problems, lintProblem)
+		}
+	}
+	return problems
+}
+
+// An unexpected property is reported on the object containing it, but is more useful to point at.
+func getSchemaErrorField(schemaError gojsonschema.ResultError) string {
+	field := schemaError.Field()
+	if schemaError.Type() == "additional_property_not_allowed" {
+		if property, ok := schemaError.Details()["property"]; ok {
+			return childField(field, fmt.Sprint(property))
+		}
+	}
+	return field
+}
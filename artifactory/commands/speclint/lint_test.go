@@ -0,0 +1,80 @@
+package speclint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSpec(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func findProblem(problems []Problem, rule, field string) *Problem {
+	for i := range problems {
+		if problems[i].Rule == rule && problems[i].Field == field {
+			return &problems[i]
+		}
+	}
+	return nil
+}
+
+func TestCheckJsonSpec(t *testing.T) {
+	specPath := writeSpec(t, "spec.json", `{
+  "files": [
+    {
+      "pattern": "repo/(*).zip",
+      "target": "out/{2}/",
+      "flat": "true",
+      "explode": "true",
+      "unknown": "value"
+    },
+    {"aql": {"items.find": {"repo": "repo"}}, "exclusions": ["*.tmp"], "sortOrder": "asc"}
+  ]
+}`)
+
+	// Validation reports the schema problems only.
+	problems := checkSpec(specPath, nil, false)
+	require.Len(t, problems, 1)
+	assert.Equal(t, Problem{File: specPath, Line: 8, Column: 7, Position: "8:7", Field: "files.0.unknown", Severity: Error, Rule: "schema",
+		Message: "Additional property unknown is not allowed"}, problems[0])
+
+	problems = checkSpec(specPath, nil, true)
+	assert.Len(t, problems, 5)
+	if problem := findProblem(problems, "flat-with-explode", "files.0.flat"); assert.NotNil(t, problem) {
+		assert.Equal(t, Warning, problem.Severity)
+		assert.Equal(t, 6, problem.Line)
+	}
+	if problem := findProblem(problems, "unmatched-placeholder", "files.0.target"); assert.NotNil(t, problem) {
+		assert.Equal(t, 5, problem.Line)
+	}
+	if problem := findProblem(problems, "conflicting-options", "files.1.exclusions"); assert.NotNil(t, problem) {
+		assert.Equal(t, position{line: 10, column: 47}, position{line: problem.Line, column: problem.Column})
+	}
+	assert.NotNil(t, findProblem(problems, "missing-required-option", "files.1.sortOrder"))
+}
+
+func TestCheckYamlSpec(t *testing.T) {
+	specPath := writeSpec(t, "spec.yaml", "files:\n  - pattern: repo/*.zip\n    regexp: true\n    ant: maybe\n")
+	problems := checkSpec(specPath, nil, true)
+	require.Len(t, problems, 1)
+	assert.Equal(t, "files.0.ant", problems[0].Field)
+	assert.Equal(t, "schema", problems[0].Rule)
+	assert.Equal(t, "4:5", problems[0].Position)
+}
+
+func TestCheckInvalidSpec(t *testing.T) {
+	problems := checkSpec(writeSpec(t, "spec.json", "{\"files\": [\n  {\"pattern\": \"repo/*\",}\n]}"), nil, true)
+	require.Len(t, problems, 1)
+	assert.Equal(t, "syntax", problems[0].Rule)
+	assert.Equal(t, 2, problems[0].Line)
+
+	problems = checkSpec(writeSpec(t, "spec.json", `{"files": [{"pattern": "{{ .missing }}"}]}`), nil, true)
+	require.Len(t, problems, 1)
+	assert.Equal(t, "template", problems[0].Rule)
+}
@@ -0,0 +1,159 @@
+package speclint
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v3"
+)
+
+// The field of the File Spec's root, as named by the schema validation.
+const rootField = "(root)"
+
+type position struct {
+	line   int
+	column int
+}
+
+// Maps the fields of a File Spec, in the form used by the schema validation ("files.0.pattern"), to their positions in the File Spec.
+// The position of an object member is the position of its key.
+type positions map[string]position
+
+// Returns the position of the field, or of its closest parent if the field doesn't exist in the File Spec.
+func (p positions) get(field string) position {
+	for {
+		if fieldPosition, exists := p[field]; exists {
+			return fieldPosition
+		}
+		separator := strings.LastIndex(field, ".")
+		if separator < 0 {
+			return p[rootField]
+		}
+		field = field[:separator]
+	}
+}
+
+func childField(parent, key string) string {
+	if parent == rootField {
+		return key
+	}
+	return parent + "." + key
+}
+
+// Returns the positions of the fields in a JSON File Spec.
+// If the File Spec isn't a valid JSON, returns the position of the syntax error together with the error.
+func indexJson(content []byte) (positions, position, error) {
+	index := positions{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	err := indexJsonValue(decoder, content, rootField, index)
+	if err == nil {
+		// Anything following the File Spec's root is invalid.
+		if _, err = decoder.Token(); errors.Is(err, io.EOF) {
+			return index, position{}, nil
+		}
+		if err == nil {
+			err = errors.New("invalid content after the top-level value")
+		}
+	}
+	var syntaxError *json.SyntaxError
+	offset := int(decoder.InputOffset())
+	if errors.As(err, &syntaxError) {
+		offset = int(syntaxError.Offset)
+	}
+	return index, offsetToPosition(content, offset), errorutils.CheckError(err)
+}
+
+func indexJsonValue(decoder *json.Decoder, content []byte, field string, index positions) error {
+	if _, exists := index[field]; !exists {
+		index[field] = offsetToPosition(content, nextTokenOffset(content, int(decoder.InputOffset())))
+	}
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil
+	}
+	switch delim {
+	case '{':
+		for decoder.More() {
+			keyOffset := nextTokenOffset(content, int(decoder.InputOffset()))
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			child := childField(field, key.(string))
+			index[child] = offsetToPosition(content, keyOffset)
+			if err = indexJsonValue(decoder, content, child, index); err != nil {
+				return err
+			}
+		}
+	case '[':
+		for i := 0; decoder.More(); i++ {
+			if err = indexJsonValue(decoder, content, childField(field, strconv.Itoa(i)), index); err != nil {
+				return err
+			}
+		}
+	}
+	// Reads the closing delimiter.
+	_, err = decoder.Token()
+	return err
+}
+
+// The decoder's offset is the end of the previous token, which may be followed by spaces and separators.
+func nextTokenOffset(content []byte, offset int) int {
+	for offset < len(content) && bytes.IndexByte([]byte(" \t\r\n,:"), content[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+func offsetToPosition(content []byte, offset int) position {
+	if offset > len(content) {
+		offset = len(content)
+	}
+	lineStart := bytes.LastIndexByte(content[:offset], '\n') + 1
+	return position{line: bytes.Count(content[:offset], []byte("\n")) + 1, column: len([]rune(string(content[lineStart:offset]))) + 1}
+}
+
+// Returns the positions of the fields in a YAML File Spec.
+func indexYaml(content []byte) (positions, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	index := positions{}
+	if len(document.Content) > 0 {
+		indexYamlNode(document.Content[0], rootField, index)
+	}
+	return index, nil
+}
+
+func indexYamlNode(node *yaml.Node, field string, index positions) {
+	if _, exists := index[field]; !exists {
+		index[field] = position{line: node.Line, column: node.Column}
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			child := childField(field, key.Value)
+			index[child] = position{line: key.Line, column: key.Column}
+			indexYamlNode(node.Content[i+1], child, index)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			indexYamlNode(item, childField(field, strconv.Itoa(i)), index)
+		}
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			indexYamlNode(node.Alias, field, index)
+		}
+	}
+}
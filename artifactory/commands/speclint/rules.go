@@ -0,0 +1,129 @@
+package speclint
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+)
+
+var placeholderRegexp = regexp.MustCompile(`\{(\d+)\}`)
+
+// The options of a file group which are used by the rules, keyed by their names in the File Spec.
+type fileOptions map[string]bool
+
+func getFileOptions(file *spec.File) fileOptions {
+	isTrue := func(value string) bool {
+		return strings.EqualFold(value, "true")
+	}
+	return fileOptions{
+		"aql":                     file.Aql.ItemsFind != "",
+		"pattern":                 file.Pattern != "",
+		"exclusions":              len(file.Exclusions) > 0 && file.Exclusions[0] != "",
+		"excludeProps":            file.ExcludeProps != "",
+		"build":                   file.Build != "",
+		"bundle":                  file.Bundle != "",
+		"offset":                  file.Offset > 0,
+		"limit":                   file.Limit > 0,
+		"sortBy":                  len(file.SortBy) > 0,
+		"sortOrder":               file.SortOrder != "",
+		"transitive":              isTrue(file.Transitive),
+		"regexp":                  isTrue(file.Regexp),
+		"ant":                     isTrue(file.Ant),
+		"flat":                    isTrue(file.Flat),
+		"explode":                 isTrue(file.Explode),
+		"archive":                 file.Archive != "",
+		"symlinks":                isTrue(file.Symlinks),
+		"excludeArtifacts":        isTrue(file.ExcludeArtifacts),
+		"includeDeps":             isTrue(file.IncludeDeps),
+		"gpg-key":                 file.PublicGpgKey != "",
+		"bypassArchiveInspection": isTrue(file.BypassArchiveInspection),
+	}
+}
+
+// Options which can't be used together in the same file group. The second option is reported.
+var conflictingOptions = [][2]string{
+	{"aql", "pattern"},
+	{"aql", "exclusions"},
+	{"aql", "excludeProps"},
+	{"build", "bundle"},
+	{"build", "offset"},
+	{"bundle", "offset"},
+	{"build", "limit"},
+	{"bundle", "limit"},
+	{"transitive", "offset"},
+	{"transitive", "sortBy"},
+	{"regexp", "ant"},
+}
+
+// Options which are ignored or rejected without another option. The first option is reported.
+var requiredOptions = [][2]string{
+	{"sortOrder", "sortBy"},
+	{"excludeArtifacts", "build"},
+	{"includeDeps", "build"},
+	{"gpg-key", "bundle"},
+	{"bypassArchiveInspection", "explode"},
+}
+
+// Returns the semantic problems of a file group, which the commands only detect when they run, or don't detect at all.
+func lintFile(file *spec.File, field string) []Problem {
+	var problems []Problem
+	addProblem := func(severity Severity, rule, option, message string, args ...interface{}) {
+		optionField := field
+		if option != "" {
+			optionField = childField(field, option)
+		}
+		problems = append(problems, Problem{Field: optionField, Severity: severity, Rule: rule, Message: fmt.Sprintf(message, args...)})
+	}
+	options := getFileOptions(file)
+	if !options["aql"] && !options["pattern"] && !options["build"] && !options["bundle"] {
+		addProblem(Error, "missing-source", "", "The file group must include either 'aql', 'pattern', 'build' or 'bundle'.")
+	}
+	for _, conflict := range conflictingOptions {
+		if options[conflict[0]] && options[conflict[1]] {
+			addProblem(Error, "conflicting-options", conflict[1], "'%s' cannot be used together with '%s'.", conflict[1], conflict[0])
+		}
+	}
+	for _, requirement := range requiredOptions {
+		if options[requirement[0]] && !options[requirement[1]] {
+			addProblem(Error, "missing-required-option", requirement[0], "'%s' can only be used together with '%s'.", requirement[0], requirement[1])
+		}
+	}
+	if options["archive"] && options["symlinks"] && options["explode"] {
+		addProblem(Error, "exploded-symlinks", "symlinks", "Symlinks cannot be stored in an archive which is exploded in Artifactory.")
+	}
+	if options["flat"] && options["explode"] {
+		addProblem(Warning, "flat-with-explode", "flat", "'flat' only applies to the archive. The files extracted from the archive keep the hierarchy of the archive.")
+	}
+	if options["regexp"] {
+		if _, err := regexp.Compile(file.Pattern); err != nil {
+			addProblem(Error, "invalid-regexp", "pattern", "'%s' isn't a valid regular expression: %s", file.Pattern, err.Error())
+		}
+		for i, exclusion := range file.Exclusions {
+			if _, err := regexp.Compile(exclusion); err != nil {
+				addProblem(Error, "invalid-regexp", childField("exclusions", strconv.Itoa(i)), "'%s' isn't a valid regular expression: %s", exclusion, err.Error())
+			}
+		}
+	}
+	if options["pattern"] && file.Target != "" {
+		groups := countGroups(file.Pattern, options["regexp"])
+		for _, match := range placeholderRegexp.FindAllStringSubmatch(file.Target, -1) {
+			if placeholder, _ := strconv.Atoi(match[1]); placeholder > groups {
+				addProblem(Error, "unmatched-placeholder", "target", "The target refers to the placeholder %s, but the pattern has %d parenthesized groups.", match[0], groups)
+			}
+		}
+	}
+	return problems
+}
+
+// Returns the number of groups in the pattern, which the placeholders of the target refer to.
+func countGroups(pattern string, isRegexp bool) int {
+	if isRegexp {
+		if compiled, err := regexp.Compile(pattern); err == nil {
+			return compiled.NumSubexp()
+		}
+	}
+	return strings.Count(pattern, "(")
+}
@@ -0,0 +1,461 @@
+package stat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/xray/commands/utils"
+	commandsutils "github.com/jfrog/jfrog-cli/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	xrayservices "github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+const storageRestApi = "api/storage/"
+
+// The metadata of a single artifact, aggregated from the storage, properties and statistics APIs,
+// the builds which produced and consumed the artifact, and its Xray summary.
+type ArtifactStat struct {
+	Path         string              `json:"path"`
+	IsFolder     bool                `json:"isFolder,omitempty"`
+	DownloadUri  string              `json:"downloadUri,omitempty"`
+	Size         int64               `json:"size"`
+	MimeType     string              `json:"mimeType,omitempty"`
+	Created      string              `json:"created,omitempty"`
+	CreatedBy    string              `json:"createdBy,omitempty"`
+	LastModified string              `json:"lastModified,omitempty"`
+	ModifiedBy   string              `json:"modifiedBy,omitempty"`
+	LastUpdated  string              `json:"lastUpdated,omitempty"`
+	Checksums    Checksums           `json:"checksums"`
+	Properties   map[string][]string `json:"properties,omitempty"`
+	Downloads    *DownloadStats      `json:"downloads,omitempty"`
+	ProducedBy   []Build             `json:"producedBy,omitempty"`
+	ConsumedBy   []Build             `json:"consumedBy,omitempty"`
+	Xray         *XraySummary        `json:"xray,omitempty"`
+}
+
+type Checksums struct {
+	Sha1   string `json:"sha1,omitempty"`
+	Md5    string `json:"md5,omitempty"`
+	Sha256 string `json:"sha256,omitempty"`
+}
+
+type DownloadStats struct {
+	DownloadCount        int    `json:"downloadCount"`
+	LastDownloaded       string `json:"lastDownloaded,omitempty"`
+	LastDownloadedBy     string `json:"lastDownloadedBy,omitempty"`
+	RemoteDownloadCount  int    `json:"remoteDownloadCount,omitempty"`
+	RemoteLastDownloaded string `json:"remoteLastDownloaded,omitempty"`
+}
+
+type Build struct {
+	Name   string `json:"name"`
+	Number string `json:"number"`
+}
+
+// The issues Xray found in the artifact, counted by their severity, and the licenses of its components.
+// If the summary couldn't be retrieved, for example since the artifact wasn't indexed by Xray, the reason is reported as the error.
+type XraySummary struct {
+	Issues   map[string]int `json:"issues,omitempty"`
+	Licenses []string       `json:"licenses,omitempty"`
+	Error    string         `json:"error,omitempty"`
+}
+
+// The response of the storage API. The size is returned as a string.
+type storageInfo struct {
+	DownloadUri  string            `json:"downloadUri,omitempty"`
+	Size         string            `json:"size,omitempty"`
+	MimeType     string            `json:"mimeType,omitempty"`
+	Created      string            `json:"created,omitempty"`
+	CreatedBy    string            `json:"createdBy,omitempty"`
+	LastModified string            `json:"lastModified,omitempty"`
+	ModifiedBy   string            `json:"modifiedBy,omitempty"`
+	LastUpdated  string            `json:"lastUpdated,omitempty"`
+	Checksums    Checksums         `json:"checksums,omitempty"`
+	Children     []json.RawMessage `json:"children,omitempty"`
+}
+
+// The response of the statistics API. The times are in milliseconds since the epoch.
+type statsResponse struct {
+	DownloadCount        int    `json:"downloadCount"`
+	LastDownloaded       int64  `json:"lastDownloaded"`
+	LastDownloadedBy     string `json:"lastDownloadedBy"`
+	RemoteDownloadCount  int    `json:"remoteDownloadCount"`
+	RemoteLastDownloaded int64  `json:"remoteLastDownloaded"`
+}
+
+// An item of an AQL search, which includes the builds of the artifacts and dependencies matching the item.
+type buildsResultItem struct {
+	Artifacts    []buildsResultModules `json:"artifacts,omitempty"`
+	Dependencies []buildsResultModules `json:"dependencies,omitempty"`
+}
+
+type buildsResultModules struct {
+	Modules []struct {
+		Builds []struct {
+			Name   string `json:"build.name"`
+			Number string `json:"build.number"`
+		} `json:"builds,omitempty"`
+	} `json:"modules,omitempty"`
+}
+
+type StatCommand struct {
+	serverDetails      *config.ServerDetails
+	artifactPath       string
+	format             commandsutils.Format
+	retries            int
+	retryWaitMilliSecs int
+	stat               *ArtifactStat
+}
+
+func NewStatCommand() *StatCommand {
+	return &StatCommand{}
+}
+
+func (sc *StatCommand) SetServerDetails(serverDetails *config.ServerDetails) *StatCommand {
+	sc.serverDetails = serverDetails
+	return sc
+}
+
+// The path of the artifact in Artifactory, in the following format: <repository name>/<repository path>.
+func (sc *StatCommand) SetArtifactPath(artifactPath string) *StatCommand {
+	sc.artifactPath = strings.Trim(artifactPath, "/")
+	return sc
+}
+
+func (sc *StatCommand) SetFormat(format commandsutils.Format) *StatCommand {
+	sc.format = format
+	return sc
+}
+
+func (sc *StatCommand) SetRetries(retries int) *StatCommand {
+	sc.retries = retries
+	return sc
+}
+
+func (sc *StatCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *StatCommand {
+	sc.retryWaitMilliSecs = retryWaitMilliSecs
+	return sc
+}
+
+func (sc *StatCommand) ServerDetails() (*config.ServerDetails, error) {
+	return sc.serverDetails, nil
+}
+
+func (sc *StatCommand) Stat() *ArtifactStat {
+	return sc.stat
+}
+
+func (sc *StatCommand) CommandName() string {
+	return "rt_stat"
+}
+
+func (sc *StatCommand) Run() (err error) {
+	servicesManager, err := utils.CreateServiceManager(sc.serverDetails, sc.retries, sc.retryWaitMilliSecs, false)
+	if err != nil {
+		return
+	}
+	if sc.stat, err = getStorageInfo(servicesManager, sc.artifactPath); err != nil {
+		return
+	}
+	itemProperties, err := servicesManager.GetItemProps(sc.artifactPath)
+	if err != nil {
+		return
+	}
+	if itemProperties != nil {
+		sc.stat.Properties = itemProperties.Properties
+	}
+	if !sc.stat.IsFolder {
+		if err = sc.addArtifactDetails(servicesManager); err != nil {
+			return
+		}
+	}
+	return sc.print()
+}
+
+// Adds the details which only exist for files - the download statistics, the builds and the Xray summary.
+func (sc *StatCommand) addArtifactDetails(servicesManager artifactory.ArtifactoryServicesManager) (err error) {
+	if sc.stat.Downloads, err = getDownloadStats(servicesManager, sc.artifactPath); err != nil {
+		return
+	}
+	if sc.stat.ProducedBy, sc.stat.ConsumedBy, err = searchBuilds(servicesManager, sc.artifactPath); err != nil {
+		return
+	}
+	// Xray is queried only if its URL is configured for the server.
+	if sc.serverDetails.XrayUrl != "" && sc.stat.Checksums.Sha256 != "" {
+		sc.stat.Xray = getXraySummary(sc.serverDetails, sc.stat.Checksums.Sha256)
+	}
+	return
+}
+
+func getStorageApi(servicesManager artifactory.ArtifactoryServicesManager, artifactPath, query string, result interface{}) error {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	storageUrl, err := servicesutils.BuildArtifactoryUrl(serviceDetails.GetUrl(), storageRestApi+artifactPath, make(map[string]string))
+	if err != nil {
+		return err
+	}
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(storageUrl+query, true, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errorutils.CheckErrorf("'%s' doesn't exist in Artifactory", artifactPath)
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return err
+	}
+	return errorutils.CheckError(json.Unmarshal(body, result))
+}
+
+func getStorageInfo(servicesManager artifactory.ArtifactoryServicesManager, artifactPath string) (*ArtifactStat, error) {
+	info := new(storageInfo)
+	if err := getStorageApi(servicesManager, artifactPath, "", info); err != nil {
+		return nil, err
+	}
+	stat := &ArtifactStat{Path: artifactPath, IsFolder: info.Children != nil, DownloadUri: info.DownloadUri, MimeType: info.MimeType,
+		Created: info.Created, CreatedBy: info.CreatedBy, LastModified: info.LastModified, ModifiedBy: info.ModifiedBy,
+		LastUpdated: info.LastUpdated, Checksums: info.Checksums}
+	if info.Size != "" {
+		size, err := strconv.ParseInt(info.Size, 10, 64)
+		if err != nil {
+			return nil, errorutils.CheckErrorf("unexpected size of '%s': %s", artifactPath, info.Size)
+		}
+		stat.Size = size
+	}
+	return stat, nil
+}
+
+func getDownloadStats(servicesManager artifactory.ArtifactoryServicesManager, artifactPath string) (*DownloadStats, error) {
+	stats := new(statsResponse)
+	if err := getStorageApi(servicesManager, artifactPath, "?stats", stats); err != nil {
+		return nil, err
+	}
+	return &DownloadStats{DownloadCount: stats.DownloadCount, LastDownloaded: formatMillis(stats.LastDownloaded), LastDownloadedBy: stats.LastDownloadedBy,
+		RemoteDownloadCount: stats.RemoteDownloadCount, RemoteLastDownloaded: formatMillis(stats.RemoteLastDownloaded)}, nil
+}
+
+func formatMillis(millis int64) string {
+	if millis <= 0 {
+		return ""
+	}
+	return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+}
+
+// Searches the builds which include the artifact as an artifact or as a dependency.
+func searchBuilds(servicesManager artifactory.ArtifactoryServicesManager, artifactPath string) (producedBy, consumedBy []Build, err error) {
+	aql := createBuildsAql(artifactPath)
+	log.Debug("Searching Artifactory using AQL query:", aql)
+	stream, err := servicesManager.Aql(aql)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(stream.Close()))
+	}()
+	body, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	result := new(struct {
+		Results []buildsResultItem `json:"results,omitempty"`
+	})
+	if err = json.Unmarshal(body, result); err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	var artifacts, dependencies []buildsResultModules
+	for _, item := range result.Results {
+		artifacts = append(artifacts, item.Artifacts...)
+		dependencies = append(dependencies, item.Dependencies...)
+	}
+	return collectBuilds(artifacts), collectBuilds(dependencies), nil
+}
+
+func createBuildsAql(artifactPath string) string {
+	repo, pathInRepo, _ := strings.Cut(artifactPath, "/")
+	dir, name := path.Split(pathInRepo)
+	dir = strings.TrimSuffix(dir, "/")
+	if dir == "" {
+		dir = "."
+	}
+	return fmt.Sprintf(`items.find({"repo":%q,"path":%q,"name":%q}).include("artifact.module.build.name","artifact.module.build.number","dependency.module.build.name","dependency.module.build.number")`,
+		repo, dir, name)
+}
+
+// Returns the distinct builds of the modules, sorted by their names and numbers.
+func collectBuilds(modules []buildsResultModules) []Build {
+	var builds []Build
+	found := make(map[Build]bool)
+	for _, artifact := range modules {
+		for _, module := range artifact.Modules {
+			for _, resultBuild := range module.Builds {
+				build := Build{Name: resultBuild.Name, Number: resultBuild.Number}
+				if !found[build] {
+					found[build] = true
+					builds = append(builds, build)
+				}
+			}
+		}
+	}
+	sort.Slice(builds, func(i, j int) bool {
+		if builds[i].Name != builds[j].Name {
+			return builds[i].Name < builds[j].Name
+		}
+		return builds[i].Number < builds[j].Number
+	})
+	return builds
+}
+
+// Returns the Xray summary of the artifact. Xray failures don't fail the command, and are reported in the summary.
+func getXraySummary(serverDetails *config.ServerDetails, sha256 string) *XraySummary {
+	xrayManager, err := xrayutils.CreateXrayServiceManager(serverDetails)
+	if err != nil {
+		return &XraySummary{Error: err.Error()}
+	}
+	response, err := xrayManager.ArtifactSummary(xrayservices.ArtifactSummaryParams{Checksums: []string{sha256}})
+	if err != nil {
+		return &XraySummary{Error: err.Error()}
+	}
+	return newXraySummary(response)
+}
+
+func newXraySummary(response *xrayservices.ArtifactSummaryResponse) *XraySummary {
+	summary := &XraySummary{Issues: make(map[string]int)}
+	licenses := make(map[string]bool)
+	for _, artifact := range response.Artifacts {
+		for _, issue := range artifact.Issues {
+			summary.Issues[issue.Severity]++
+		}
+		for _, license := range artifact.Licenses {
+			if !licenses[license.Name] {
+				licenses[license.Name] = true
+				summary.Licenses = append(summary.Licenses, license.Name)
+			}
+		}
+	}
+	sort.Strings(summary.Licenses)
+	return summary
+}
+
+func (sc *StatCommand) print() error {
+	return commandsutils.Print(sc.format, sc.stat, func() error {
+		log.Output(sc.stat.String())
+		return nil
+	})
+}
+
+// Returns the metadata as aligned "name: value" lines.
+func (stat *ArtifactStat) String() string {
+	var output strings.Builder
+	writer := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	row := func(name, value string) {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\n", name, value)
+	}
+	line := func(name, value string) {
+		if value != "" {
+			row(name+":", value)
+		}
+	}
+	line("Path", stat.Path)
+	if stat.IsFolder {
+		line("Type", "folder")
+	} else {
+		line("Type", "file")
+		line("Size", fmt.Sprintf("%s (%d bytes)", utils.ConvertIntToStorageSizeString(stat.Size), stat.Size))
+		line("MIME type", stat.MimeType)
+	}
+	line("Created", joinNonEmpty(stat.Created, "by", stat.CreatedBy))
+	line("Modified", joinNonEmpty(stat.LastModified, "by", stat.ModifiedBy))
+	line("Updated", stat.LastUpdated)
+	line("SHA-256", stat.Checksums.Sha256)
+	line("SHA-1", stat.Checksums.Sha1)
+	line("MD5", stat.Checksums.Md5)
+	line("Download URI", stat.DownloadUri)
+	if stat.Downloads != nil {
+		line("Downloads", formatDownloads(stat.Downloads.DownloadCount, stat.Downloads.LastDownloaded, stat.Downloads.LastDownloadedBy))
+		if stat.Downloads.RemoteDownloadCount > 0 {
+			line("Remote downloads", formatDownloads(stat.Downloads.RemoteDownloadCount, stat.Downloads.RemoteLastDownloaded, ""))
+		}
+	}
+	keys := make([]string, 0, len(stat.Properties))
+	for key := range stat.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for i, key := range keys {
+		name := ""
+		if i == 0 {
+			name = "Properties:"
+		}
+		row(name, key+"="+strings.Join(stat.Properties[key], ","))
+	}
+	line("Produced by", formatBuilds(stat.ProducedBy))
+	line("Consumed by", formatBuilds(stat.ConsumedBy))
+	if stat.Xray != nil {
+		line("Xray", stat.Xray.String())
+	}
+	_ = writer.Flush()
+	return strings.TrimSuffix(output.String(), "\n")
+}
+
+// Joins "value (separator detail)", omitting the detail if it's empty.
+func joinNonEmpty(value, separator, detail string) string {
+	if value == "" || detail == "" {
+		return value
+	}
+	return fmt.Sprintf("%s (%s %s)", value, separator, detail)
+}
+
+func formatDownloads(count int, lastDownloaded, lastDownloadedBy string) string {
+	result := strconv.Itoa(count)
+	if lastDownloaded == "" {
+		return result
+	}
+	result += " (last " + lastDownloaded
+	if lastDownloadedBy != "" {
+		result += " by " + lastDownloadedBy
+	}
+	return result + ")"
+}
+
+func formatBuilds(builds []Build) string {
+	names := make([]string, len(builds))
+	for i, build := range builds {
+		names[i] = build.Name + "/" + build.Number
+	}
+	return strings.Join(names, ", ")
+}
+
+func (summary *XraySummary) String() string {
+	if summary.Error != "" {
+		return "unavailable: " + summary.Error
+	}
+	total := 0
+	var severities []string
+	for severity, count := range summary.Issues {
+		total += count
+		severities = append(severities, fmt.Sprintf("%s: %d", severity, count))
+	}
+	sort.Strings(severities)
+	result := "no issues"
+	if total > 0 {
+		result = fmt.Sprintf("%d issues (%s)", total, strings.Join(severities, ", "))
+	}
+	if len(summary.Licenses) > 0 {
+		result += "; licenses: " + strings.Join(summary.Licenses, ", ")
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+package stat
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	xrayservices "github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response string
+		switch {
+		case r.URL.Path == "/api/storage/repo/libs/app.jar" && r.URL.RawQuery == "":
+			response = `{"repo":"repo","path":"/libs/app.jar","created":"2023-06-01T10:00:00.000Z","createdBy":"ci","size":"2048",
+				"mimeType":"application/java-archive","downloadUri":"http://localhost/repo/libs/app.jar","checksums":{"sha1":"1","md5":"2","sha256":"3"}}`
+		case r.URL.Path == "/api/storage/repo/libs/app.jar" && r.URL.RawQuery == "properties":
+			response = `{"properties":{"build.name":["app"],"qa":["passed","signed"]}}`
+		case r.URL.Path == "/api/storage/repo/libs/app.jar" && r.URL.RawQuery == "stats":
+			response = `{"downloadCount":3,"lastDownloaded":1685613600000,"lastDownloadedBy":"deployer"}`
+		case r.URL.Path == "/api/search/aql":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Contains(t, string(body), `items.find({"repo":"repo","path":"libs","name":"app.jar"})`)
+			response = `{"results":[{"artifacts":[{"modules":[{"builds":[{"build.name":"app","build.number":"2"},{"build.name":"app","build.number":"1"}]}]}],
+				"dependencies":[{"modules":[{"builds":[{"build.name":"service","build.number":"7"}]},{"builds":[{"build.name":"service","build.number":"7"}]}]}]}]}`
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	statCommand := NewStatCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).SetArtifactPath("repo/libs/app.jar")
+	require.NoError(t, statCommand.Run())
+	stat := statCommand.Stat()
+	assert.Equal(t, int64(2048), stat.Size)
+	assert.Equal(t, Checksums{Sha1: "1", Md5: "2", Sha256: "3"}, stat.Checksums)
+	assert.Equal(t, []string{"passed", "signed"}, stat.Properties["qa"])
+	assert.Equal(t, &DownloadStats{DownloadCount: 3, LastDownloaded: "2023-06-01T10:00:00Z", LastDownloadedBy: "deployer"}, stat.Downloads)
+	assert.Equal(t, []Build{{Name: "app", Number: "1"}, {Name: "app", Number: "2"}}, stat.ProducedBy)
+	assert.Equal(t, []Build{{Name: "service", Number: "7"}}, stat.ConsumedBy)
+	assert.Nil(t, stat.Xray)
+
+	output := stat.String()
+	assert.Contains(t, output, "Size:          2.0KB (2048 bytes)\n")
+	assert.Contains(t, output, "Created:       2023-06-01T10:00:00.000Z (by ci)\n")
+	assert.Contains(t, output, "Downloads:     3 (last 2023-06-01T10:00:00Z by deployer)\n")
+	assert.Contains(t, output, "Properties:    build.name=app\n               qa=passed,signed\n")
+	assert.Contains(t, output, "Produced by:   app/1, app/2\n")
+
+	err := NewStatCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).SetArtifactPath("repo/missing").Run()
+	assert.ErrorContains(t, err, "'repo/missing' doesn't exist in Artifactory")
+}
+
+func TestNewXraySummary(t *testing.T) {
+	summary := newXraySummary(&xrayservices.ArtifactSummaryResponse{Artifacts: []xrayservices.Artifact{{
+		Issues:   []xrayservices.Issue{{Severity: "High"}, {Severity: "Low"}, {Severity: "High"}},
+		Licenses: []xrayservices.SummaryLicense{{Name: "MIT"}, {Name: "Apache-2.0"}, {Name: "MIT"}},
+	}}})
+	assert.Equal(t, &XraySummary{Issues: map[string]int{"High": 2, "Low": 1}, Licenses: []string{"Apache-2.0", "MIT"}}, summary)
+	assert.Equal(t, "3 issues (High: 2, Low: 1); licenses: Apache-2.0, MIT", summary.String())
+	assert.Equal(t, "no issues", (&XraySummary{}).String())
+}
@@ -0,0 +1,114 @@
+package streamupload
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioutils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	ArchiveTarGz  = "tar.gz"
+	ArchiveTarZst = "tar.zst"
+)
+
+// Returns true if the archive type is packed and streamed by this package, rather than by the upload service.
+func IsStreamingArchive(archive string) bool {
+	return archive == ArchiveTarGz || archive == ArchiveTarZst
+}
+
+// Wraps the writer with the compressor of the archive type.
+func newCompressor(archive string, writer io.Writer) (io.WriteCloser, error) {
+	switch archive {
+	case ArchiveTarGz:
+		return gzip.NewWriter(writer), nil
+	case ArchiveTarZst:
+		encoder, err := zstd.NewWriter(writer)
+		return encoder, errorutils.CheckError(err)
+	default:
+		return nil, errorutils.CheckErrorf("unsupported archive type '%s'", archive)
+	}
+}
+
+// Writes the files as a compressed tar archive to the writer.
+// The entries are named the same way as the entries of a ZIP archive created by the upload command.
+func writeArchive(writer io.Writer, archive string, files []services.UploadData, flat, symlink bool, progress ioutils.ProgressMgr) (err error) {
+	compressor, err := newCompressor(archive, writer)
+	if err != nil {
+		return
+	}
+	tarWriter := tar.NewWriter(compressor)
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(tarWriter.Close()), errorutils.CheckError(compressor.Close()))
+	}()
+	for i := range files {
+		if err = addFileToTar(tarWriter, &files[i].Artifact, flat, symlink, progress); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func addFileToTar(tarWriter *tar.Writer, artifact *clientutils.Artifact, flat, symlink bool, progress ioutils.ProgressMgr) (err error) {
+	localPath := artifact.LocalPath
+	// In case of a symlink there are 2 options:
+	// 1. symlink == true : the symlink is added to the archive as a symlink.
+	// 2. symlink == false : the symlink's target is added to the archive.
+	if artifact.SymlinkTargetPath != "" && !symlink {
+		localPath = artifact.SymlinkTargetPath
+	}
+	info, err := os.Lstat(localPath)
+	if errorutils.CheckError(err) != nil {
+		return
+	}
+	linkTarget := ""
+	if artifact.SymlinkTargetPath != "" && symlink {
+		linkTarget = filepath.ToSlash(artifact.SymlinkTargetPath)
+	}
+	header, err := tar.FileInfoHeader(info, linkTarget)
+	if errorutils.CheckError(err) != nil {
+		return
+	}
+	header.Name = getEntryName(artifact, localPath, flat, info.IsDir())
+	if err = errorutils.CheckError(tarWriter.WriteHeader(header)); err != nil || header.Typeflag != tar.TypeReg {
+		return
+	}
+	file, err := os.Open(localPath)
+	if errorutils.CheckError(err) != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	var reader io.Reader = file
+	if progress != nil {
+		progressReader := progress.NewProgressReader(info.Size(), "Archiving", localPath)
+		reader = progressReader.ActionWithProgress(file)
+		defer progress.RemoveProgress(progressReader.GetId())
+	}
+	_, err = io.Copy(tarWriter, reader)
+	return errorutils.CheckError(err)
+}
+
+func getEntryName(artifact *clientutils.Artifact, localPath string, flat, isDir bool) string {
+	name := filepath.Base(localPath)
+	if !flat {
+		name = clientutils.TrimPath(localPath)
+	}
+	if artifact.TargetPathInArchive != "" {
+		name = artifact.TargetPathInArchive
+	}
+	name = filepath.ToSlash(name)
+	if isDir {
+		name += "/"
+	}
+	return name
+}
@@ -0,0 +1,141 @@
+package streamupload
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteArchive(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("aaa"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("bb"), 0644))
+	files := []services.UploadData{
+		{Artifact: clientutils.Artifact{LocalPath: filepath.Join(dir, "a.txt")}},
+		{Artifact: clientutils.Artifact{LocalPath: filepath.Join(dir, "b.txt"), TargetPathInArchive: "nested/b.txt"}},
+	}
+	for _, archive := range []string{ArchiveTarGz, ArchiveTarZst} {
+		t.Run(archive, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, writeArchive(&buf, archive, files, true, false, nil))
+			assert.Equal(t, map[string]string{"a.txt": "aaa", "nested/b.txt": "bb"}, readArchive(t, archive, &buf))
+		})
+	}
+}
+
+func readArchive(t *testing.T, archive string, reader io.Reader) map[string]string {
+	var decompressed io.Reader
+	switch archive {
+	case ArchiveTarGz:
+		gzipReader, err := gzip.NewReader(reader)
+		require.NoError(t, err)
+		decompressed = gzipReader
+	case ArchiveTarZst:
+		zstdReader, err := zstd.NewReader(reader)
+		require.NoError(t, err)
+		defer zstdReader.Close()
+		decompressed = zstdReader
+	}
+	entries := make(map[string]string)
+	tarReader := tar.NewReader(decompressed)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return entries
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tarReader)
+		require.NoError(t, err)
+		entries[header.Name] = string(content)
+	}
+}
+
+func TestGetEntryName(t *testing.T) {
+	artifact := &clientutils.Artifact{}
+	assert.Equal(t, "c.txt", getEntryName(artifact, "a/b/c.txt", true, false))
+	assert.Equal(t, "a/b/c.txt", getEntryName(artifact, "./a/b/c.txt", false, false))
+	assert.Equal(t, "a/b/", getEntryName(artifact, "a/b", false, true))
+	artifact.TargetPathInArchive = "x/y.txt"
+	assert.Equal(t, "x/y.txt", getEntryName(artifact, "a/b/c.txt", true, false))
+}
+
+func TestChecksumsWriterVerify(t *testing.T) {
+	checksums := newChecksumsWriter()
+	_, err := checksums.Write([]byte("content"))
+	require.NoError(t, err)
+	sha256 := "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73"
+
+	actual, err := checksums.verify("repo/file", []byte(`{"checksums":{"sha256":"`+sha256+`","sha1":"040f06fd774092478d450774f5ba30c5da78acc8"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, sha256, actual)
+
+	_, err = checksums.verify("repo/file", []byte(`{"checksums":{"sha1":"0000000000000000000000000000000000000000"}}`))
+	assert.ErrorContains(t, err, "SHA-1")
+}
+
+func TestValidateSpec(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []spec.File
+		err   string
+	}{
+		{"stdin", []spec.File{{Pattern: StdinSource, Target: "repo/file.tar.gz"}}, ""},
+		{"archive", []spec.File{{Pattern: "dir/*", Target: "repo/dir.tar.zst", Archive: ArchiveTarZst}}, ""},
+		{"stdin to a folder", []spec.File{{Pattern: StdinSource, Target: "repo/dir/"}}, "must be a file path"},
+		{"archive to a folder", []spec.File{{Pattern: "dir/*", Target: "repo/dir/", Archive: ArchiveTarGz}}, "cannot be a directory"},
+		{"stdin as archive", []spec.File{{Pattern: StdinSource, Target: "repo/file", Archive: ArchiveTarGz}}, "cannot be uploaded as an archive"},
+		{"stdin twice", []spec.File{{Pattern: StdinSource, Target: "repo/a"}, {Pattern: StdinSource, Target: "repo/b"}}, "only be uploaded once"},
+		{"mixed", []spec.File{{Pattern: StdinSource, Target: "repo/a"}, {Pattern: "dir/*", Target: "repo/"}}, "cannot be combined"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateSpec(&spec.SpecFiles{Files: test.files})
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestUploadArchiveRetry(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("aaa"), 0644))
+	// Fails the first upload, after reading the whole archive.
+	var uploads int
+	var uploaded []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		uploads++
+		if uploads == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		uploaded = body
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	uploadSpec := spec.NewBuilder().Pattern(filepath.Join(dir, "*")).Target("repo/dir.tar.gz").Archive(ArchiveTarGz).Flat(true).BuildSpec()
+	command := NewStreamUploadCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).SetSpec(uploadSpec).SetRetries(1)
+	require.NoError(t, command.Run())
+	assert.Equal(t, 2, uploads)
+	assert.Equal(t, 1, command.Result().SuccessCount())
+	assert.Equal(t, map[string]string{"a.txt": "aaa"}, readArchive(t, ArchiveTarGz, bytes.NewReader(uploaded)))
+	require.NoError(t, command.Result().Reader().Close())
+}
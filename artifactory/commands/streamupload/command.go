@@ -0,0 +1,434 @@
+package streamupload
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/gofrog/parallel"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioutils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The source pattern used to upload the standard input.
+	StdinSource   = "-"
+	tasksCapacity = 10000
+)
+
+// Uploads the standard input, or the files matching the spec packed as tar.gz or tar.zst archives.
+// The content is streamed to Artifactory as it is read or packed, without creating a temporary file,
+// and its checksums are verified against the checksums returned by Artifactory.
+// Failed uploads of archives are retried by packing the archives again. The standard input can't be read again,
+// so its upload is never retried.
+type StreamUploadCommand struct {
+	serverDetails      *config.ServerDetails
+	spec               *spec.SpecFiles
+	threads            int
+	retries            int
+	retryWaitMilliSecs int
+	stdin              io.Reader
+	progress           ioutils.ProgressMgr
+	result             *commandsutils.Result
+	servicesManager    artifactory.ArtifactoryServicesManager
+	resultsWriter      *content.ContentWriter
+	successCount       int
+	failCount          int
+	countersMutex      sync.Mutex
+}
+
+// The files to pack into a single archive.
+type archiveData struct {
+	target  string
+	archive string
+	props   *servicesutils.Properties
+	flat    bool
+	symlink bool
+	explode bool
+	files   []services.UploadData
+}
+
+func NewStreamUploadCommand() *StreamUploadCommand {
+	return &StreamUploadCommand{stdin: os.Stdin, threads: 1}
+}
+
+func (suc *StreamUploadCommand) SetServerDetails(serverDetails *config.ServerDetails) *StreamUploadCommand {
+	suc.serverDetails = serverDetails
+	return suc
+}
+
+func (suc *StreamUploadCommand) SetSpec(spec *spec.SpecFiles) *StreamUploadCommand {
+	suc.spec = spec
+	return suc
+}
+
+func (suc *StreamUploadCommand) SetThreads(threads int) *StreamUploadCommand {
+	suc.threads = threads
+	return suc
+}
+
+func (suc *StreamUploadCommand) SetRetries(retries int) *StreamUploadCommand {
+	suc.retries = retries
+	return suc
+}
+
+func (suc *StreamUploadCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *StreamUploadCommand {
+	suc.retryWaitMilliSecs = retryWaitMilliSecs
+	return suc
+}
+
+func (suc *StreamUploadCommand) SetStdin(stdin io.Reader) *StreamUploadCommand {
+	suc.stdin = stdin
+	return suc
+}
+
+func (suc *StreamUploadCommand) SetProgress(progress ioutils.ProgressMgr) {
+	suc.progress = progress
+}
+
+func (suc *StreamUploadCommand) ServerDetails() (*config.ServerDetails, error) {
+	return suc.serverDetails, nil
+}
+
+func (suc *StreamUploadCommand) Result() *commandsutils.Result {
+	return suc.result
+}
+
+func (suc *StreamUploadCommand) CommandName() string {
+	return "rt_upload_stream"
+}
+
+func (suc *StreamUploadCommand) Run() (err error) {
+	suc.result = new(commandsutils.Result)
+	if suc.progress != nil {
+		suc.progress.InitProgressReaders()
+	}
+	suc.servicesManager, err = utils.CreateServiceManager(suc.serverDetails, suc.retries, suc.retryWaitMilliSecs, false)
+	if err != nil {
+		return
+	}
+	if suc.resultsWriter, err = content.NewContentWriter(content.DefaultKey, true, false); err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, suc.resultsWriter.Close())
+		suc.result.SetReader(content.NewContentReader(suc.resultsWriter.GetFilePath(), content.DefaultKey))
+		suc.result.SetSuccessCount(suc.successCount)
+		suc.result.SetFailCount(suc.failCount)
+	}()
+
+	var archives []*archiveData
+	for i := range suc.spec.Files {
+		file := suc.spec.Get(i)
+		if file.Pattern == StdinSource {
+			suc.uploadStdin(file)
+			continue
+		}
+		if archives, err = suc.collectArchiveFiles(file, archives); err != nil {
+			return
+		}
+	}
+	return suc.uploadArchives(archives)
+}
+
+func (suc *StreamUploadCommand) uploadStdin(file *spec.File) {
+	log.Info("Uploading the standard input to:", file.Target)
+	suc.incGeneralProgressTotal()
+	props, err := servicesutils.ParseProperties(clientutils.AddProps(file.TargetProps, file.Props))
+	var explode bool
+	if err == nil {
+		explode, err = file.IsExplode(false)
+	}
+	var sha256 string
+	if err == nil {
+		sha256, err = suc.upload(suc.stdin, file.Target, props, explode)
+	}
+	suc.countResult(err, file.Target, []string{StdinSource}, sha256)
+}
+
+// Collects the files matching the spec file, and adds them to the archive of their target.
+func (suc *StreamUploadCommand) collectArchiveFiles(file *spec.File, archives []*archiveData) ([]*archiveData, error) {
+	uploadParams, err := createUploadParams(file)
+	if err != nil {
+		return nil, err
+	}
+	var conflictErr error
+	err = services.CollectFilesForUpload(uploadParams, suc.progress, clientutils.NewVcsDetails(), func(data services.UploadData) {
+		for _, archive := range archives {
+			if archive.target != data.Artifact.TargetPath {
+				continue
+			}
+			if archive.archive != file.Archive && conflictErr == nil {
+				conflictErr = errorutils.CheckErrorf("the archive '%s' cannot be created as both %s and %s", archive.target, archive.archive, file.Archive)
+			}
+			archive.props = servicesutils.MergeProperties([]*servicesutils.Properties{archive.props, data.TargetProps})
+			archive.files = append(archive.files, data)
+			return
+		}
+		archives = append(archives, &archiveData{
+			target:  data.Artifact.TargetPath,
+			archive: file.Archive,
+			props:   servicesutils.MergeProperties([]*servicesutils.Properties{data.TargetProps}),
+			flat:    uploadParams.Flat,
+			symlink: uploadParams.Symlink,
+			explode: uploadParams.ExplodeArchive,
+			files:   []services.UploadData{data},
+		})
+	})
+	return archives, errors.Join(err, conflictErr)
+}
+
+func createUploadParams(file *spec.File) (uploadParams services.UploadParams, err error) {
+	uploadParams = services.NewUploadParams()
+	file.TargetProps = clientutils.AddProps(file.TargetProps, file.Props)
+	if uploadParams.CommonParams, err = file.ToCommonParams(); err != nil {
+		return
+	}
+	// The archive type is only used to tell the upload service to collect the files into a single target.
+	uploadParams.Archive = file.Archive
+	uploadParams.TargetPathInArchive = file.TargetPathInArchive
+	if uploadParams.Recursive, err = file.IsRecursive(true); err != nil {
+		return
+	}
+	if uploadParams.Regexp, err = file.IsRegexp(false); err != nil {
+		return
+	}
+	if uploadParams.Ant, err = file.IsAnt(false); err != nil {
+		return
+	}
+	if uploadParams.IncludeDirs, err = file.IsIncludeDirs(false); err != nil {
+		return
+	}
+	if uploadParams.Flat, err = file.IsFlat(true); err != nil {
+		return
+	}
+	if uploadParams.ExplodeArchive, err = file.IsExplode(false); err != nil {
+		return
+	}
+	uploadParams.Symlink, err = file.IsSymlinks(false)
+	return
+}
+
+func (suc *StreamUploadCommand) uploadArchives(archives []*archiveData) error {
+	errorsQueue := clientutils.NewErrorsQueue(1)
+	producerConsumer := parallel.NewRunner(suc.threads, tasksCapacity, false)
+	go func() {
+		defer producerConsumer.Done()
+		for _, archive := range archives {
+			archive := archive
+			suc.incGeneralProgressTotal()
+			_, _ = producerConsumer.AddTaskWithError(func(threadId int) error {
+				return suc.uploadArchive(threadId, archive)
+			}, errorsQueue.AddError)
+		}
+	}()
+	producerConsumer.Run()
+	return errorsQueue.GetError()
+}
+
+// Packs the files into an archive, while the archive is being uploaded.
+// Unlike the standard input, the archive can be packed again, so a failed upload is retried.
+func (suc *StreamUploadCommand) uploadArchive(threadId int, archive *archiveData) (err error) {
+	logMsgPrefix := clientutils.GetLogMsgPrefix(threadId, false)
+	log.Info(logMsgPrefix+"Uploading artifact:", archive.target)
+	localPaths := make([]string, len(archive.files))
+	for i := range archive.files {
+		localPaths[i] = archive.files[i].Artifact.LocalPath
+	}
+	var sha256 string
+	defer func() {
+		suc.countResult(err, archive.target, localPaths, sha256)
+	}()
+	retryExecutor := clientutils.RetryExecutor{
+		MaxRetries:               suc.retries,
+		RetriesIntervalMilliSecs: suc.retryWaitMilliSecs,
+		ErrorMessage:             "Failed uploading " + archive.target,
+		LogMsgPrefix:             logMsgPrefix,
+		ExecutionHandler: func() (bool, error) {
+			var uploadErr, archiveErr error
+			sha256, uploadErr, archiveErr = suc.packAndUpload(archive)
+			// Failures to pack the archive are caused by the local files, so they aren't retried.
+			return uploadErr != nil && archiveErr == nil, errors.Join(uploadErr, archiveErr)
+		},
+	}
+	return retryExecutor.Execute()
+}
+
+// Packs the archive into a pipe which is uploaded. Returns the error of the upload and the error of the packing separately.
+func (suc *StreamUploadCommand) packAndUpload(archive *archiveData) (sha256 string, uploadErr, archiveErr error) {
+	pipeReader, pipeWriter := io.Pipe()
+	archiveErrChan := make(chan error, 1)
+	go func() {
+		e := writeArchive(pipeWriter, archive.archive, archive.files, archive.flat, archive.symlink, suc.progress)
+		_ = pipeWriter.CloseWithError(e)
+		archiveErrChan <- e
+	}()
+	sha256, uploadErr = suc.upload(pipeReader, archive.target, archive.props, archive.explode)
+	// Stop the archiving, in case the upload failed before the whole archive was read.
+	_ = pipeReader.Close()
+	if e := <-archiveErrChan; e != nil && !errors.Is(e, io.ErrClosedPipe) {
+		archiveErr = e
+	}
+	return
+}
+
+// Streams the reader to the target, and verifies the checksums of the uploaded content.
+// Returns the SHA-256 of the uploaded content.
+func (suc *StreamUploadCommand) upload(reader io.Reader, target string, props *servicesutils.Properties, explode bool) (string, error) {
+	targetUrl, err := servicesutils.BuildArtifactoryUrl(suc.serverDetails.ArtifactoryUrl, target, make(map[string]string))
+	if err != nil {
+		return "", err
+	}
+	if encodedProps := props.ToEncodedString(false); encodedProps != "" {
+		targetUrl = strings.Join([]string{targetUrl, encodedProps}, ";")
+	}
+	httpClientDetails := suc.createHttpClientDetails()
+	if explode {
+		servicesutils.AddHeader("X-Explode-Archive", "true", &httpClientDetails.Headers)
+	}
+	checksums := newChecksumsWriter()
+	_, body, err := suc.servicesManager.Client().UploadFileFromReader(io.TeeReader(reader, checksums), targetUrl, &httpClientDetails, -1)
+	if err != nil {
+		return "", err
+	}
+	if explode {
+		// The response of an exploded archive doesn't describe the uploaded archive.
+		return "", nil
+	}
+	return checksums.verify(target, body)
+}
+
+func (suc *StreamUploadCommand) createHttpClientDetails() httputils.HttpClientDetails {
+	httpClientDetails := suc.servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	if httpClientDetails.Headers == nil {
+		httpClientDetails.Headers = make(map[string]string)
+	}
+	servicesutils.AddAuthHeaders(httpClientDetails.Headers, suc.servicesManager.GetConfig().GetServiceDetails())
+	return httpClientDetails
+}
+
+func (suc *StreamUploadCommand) incGeneralProgressTotal() {
+	if suc.progress != nil {
+		suc.progress.IncGeneralProgressTotalBy(1)
+	}
+}
+
+// Counts the uploaded artifact as succeeded or failed, and adds its sources to the command result.
+func (suc *StreamUploadCommand) countResult(err error, target string, sources []string, sha256 string) {
+	suc.countersMutex.Lock()
+	defer suc.countersMutex.Unlock()
+	if suc.progress != nil {
+		suc.progress.IncrementGeneralProgress()
+	}
+	if err != nil {
+		log.Error("Failed uploading", target+":", err.Error())
+		suc.failCount++
+		return
+	}
+	suc.successCount++
+	for _, source := range sources {
+		suc.resultsWriter.Write(clientutils.FileTransferDetails{
+			SourcePath: source,
+			TargetPath: target,
+			RtUrl:      suc.serverDetails.ArtifactoryUrl,
+			Sha256:     sha256,
+		})
+	}
+}
+
+// Calculates the checksums of the content written to it.
+type checksumsWriter struct {
+	io.Writer
+	sha1   hash.Hash
+	md5    hash.Hash
+	sha256 hash.Hash
+}
+
+func newChecksumsWriter() *checksumsWriter {
+	cw := &checksumsWriter{sha1: sha1.New(), md5: md5.New(), sha256: sha256.New()}
+	cw.Writer = io.MultiWriter(cw.sha1, cw.md5, cw.sha256)
+	return cw
+}
+
+// Compares the calculated checksums with the checksums in the upload response body.
+// Returns the SHA-256 of the content.
+func (cw *checksumsWriter) verify(target string, responseBody []byte) (string, error) {
+	actualSha256 := hex.EncodeToString(cw.sha256.Sum(nil))
+	if len(responseBody) == 0 {
+		return actualSha256, nil
+	}
+	response := new(clientutils.UploadResponseBody)
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	for _, checksum := range []struct {
+		name, expected, actual string
+	}{
+		{"SHA-1", response.Checksums.Sha1, hex.EncodeToString(cw.sha1.Sum(nil))},
+		{"MD5", response.Checksums.Md5, hex.EncodeToString(cw.md5.Sum(nil))},
+		{"SHA-256", response.Checksums.Sha256, actualSha256},
+	} {
+		if checksum.expected != "" && !strings.EqualFold(checksum.expected, checksum.actual) {
+			return "", errorutils.CheckErrorf("the %s checksum of '%s' in Artifactory (%s) doesn't match the %s checksum of the uploaded content (%s)",
+				checksum.name, target, checksum.expected, checksum.name, checksum.actual)
+		}
+	}
+	return actualSha256, nil
+}
+
+// Returns true if the spec uploads the standard input or tar archives, which are uploaded by this command.
+func IsStreamingSpec(uploadSpec *spec.SpecFiles) bool {
+	for _, file := range uploadSpec.Files {
+		if file.Pattern == StdinSource || IsStreamingArchive(file.Archive) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validates a spec which uploads the standard input or tar archives.
+func ValidateSpec(uploadSpec *spec.SpecFiles) error {
+	files := make([]spec.File, len(uploadSpec.Files))
+	stdinCount := 0
+	for i, file := range uploadSpec.Files {
+		if file.Pattern == StdinSource {
+			stdinCount++
+			if file.Archive != "" {
+				return errorutils.CheckErrorf("the standard input cannot be uploaded as an archive")
+			}
+			if !strings.Contains(file.Target, "/") || strings.HasSuffix(file.Target, "/") {
+				return errorutils.CheckErrorf("when uploading the standard input, the target must be a file path in the following format: <repository name>/<repository path>")
+			}
+		} else if !IsStreamingArchive(file.Archive) {
+			return errorutils.CheckErrorf("uploading the standard input or %s and %s archives cannot be combined with other uploads in the same command", ArchiveTarGz, ArchiveTarZst)
+		} else if strings.HasSuffix(file.Target, "/") {
+			return errorutils.CheckErrorf("an archive's target cannot be a directory")
+		}
+		files[i] = file
+		// The upload service only supports ZIP archives, so the archive type is validated here.
+		files[i].Archive = ""
+	}
+	if stdinCount > 1 {
+		return errorutils.CheckErrorf("the standard input can only be uploaded once")
+	}
+	return spec.ValidateSpec(files, true, false)
+}
@@ -0,0 +1,122 @@
+package syncdir
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type Policy string
+
+const (
+	// Conflicting files are reported and left untouched on both sides.
+	ReportPolicy Policy = "report"
+	// Conflicting files are pushed from the local directory to Artifactory.
+	LocalWinsPolicy Policy = "local-wins"
+	// Conflicting files are pulled from Artifactory to the local directory.
+	RemoteWinsPolicy Policy = "remote-wins"
+	// Conflicting files are transferred from the side on which they were modified last.
+	NewerWinsPolicy Policy = "newer-wins"
+)
+
+func GetPolicy(policy string) (Policy, error) {
+	switch Policy(policy) {
+	case ReportPolicy, LocalWinsPolicy, RemoteWinsPolicy, NewerWinsPolicy:
+		return Policy(policy), nil
+	case "":
+		return ReportPolicy, nil
+	default:
+		return "", errorutils.CheckErrorf("unsupported sync policy '%s'. Possible values are: %s, %s, %s and %s", policy, ReportPolicy, LocalWinsPolicy, RemoteWinsPolicy, NewerWinsPolicy)
+	}
+}
+
+type Action string
+
+const (
+	Push     Action = "push"
+	Pull     Action = "pull"
+	Conflict Action = "conflict"
+)
+
+type localFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+type remoteFile struct {
+	path     string
+	size     int64
+	sha1     string
+	md5      string
+	modified time.Time
+}
+
+// A single row of the sync plan. The path is relative to both the local directory and the repository path.
+type PlanEntry struct {
+	Path   string `json:"path" col-name:"Path"`
+	Action Action `json:"action" col-name:"Action"`
+	Reason string `json:"reason" col-name:"Reason"`
+}
+
+type Plan []PlanEntry
+
+func (p Plan) filter(action Action) (paths []string) {
+	for _, entry := range p {
+		if entry.Action == action {
+			paths = append(paths, entry.Path)
+		}
+	}
+	return
+}
+
+// Creates the sync plan of the files found on both sides.
+// Files that exist on a single side are copied to the other side. Files that exist on both sides with different
+// content are conflicts, resolved according to the policy. Identical files are not included in the plan.
+// isIdentical is called only for files that exist on both sides, since it may need to calculate the local file's checksum.
+func createPlan(localFiles map[string]*localFile, remoteFiles map[string]*remoteFile, policy Policy, isIdentical func(*localFile, *remoteFile) (bool, error)) (Plan, error) {
+	plan := Plan{}
+	for path, local := range localFiles {
+		remote, exists := remoteFiles[path]
+		if !exists {
+			plan = append(plan, PlanEntry{Path: path, Action: Push, Reason: "missing in Artifactory"})
+			continue
+		}
+		identical, err := isIdentical(local, remote)
+		if err != nil {
+			return nil, err
+		}
+		if !identical {
+			plan = append(plan, resolveConflict(path, local, remote, policy))
+		}
+	}
+	for path := range remoteFiles {
+		if _, exists := localFiles[path]; !exists {
+			plan = append(plan, PlanEntry{Path: path, Action: Pull, Reason: "missing locally"})
+		}
+	}
+	sort.Slice(plan, func(i, j int) bool {
+		return plan[i].Path < plan[j].Path
+	})
+	return plan, nil
+}
+
+func resolveConflict(path string, local *localFile, remote *remoteFile, policy Policy) PlanEntry {
+	switch policy {
+	case LocalWinsPolicy:
+		return PlanEntry{Path: path, Action: Push, Reason: "checksums differ, the local file wins"}
+	case RemoteWinsPolicy:
+		return PlanEntry{Path: path, Action: Pull, Reason: "checksums differ, the remote file wins"}
+	case NewerWinsPolicy:
+		if local.modTime.After(remote.modified) {
+			return PlanEntry{Path: path, Action: Push, Reason: "checksums differ, the local file is newer"}
+		}
+		if remote.modified.After(local.modTime) {
+			return PlanEntry{Path: path, Action: Pull, Reason: "checksums differ, the remote file is newer"}
+		}
+		return PlanEntry{Path: path, Action: Conflict, Reason: "checksums differ, both files were modified at the same time"}
+	default:
+		return PlanEntry{Path: path, Action: Conflict, Reason: "checksums differ"}
+	}
+}
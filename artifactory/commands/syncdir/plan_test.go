@@ -0,0 +1,99 @@
+package syncdir
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPolicy(t *testing.T) {
+	policy, err := GetPolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, ReportPolicy, policy)
+
+	policy, err = GetPolicy("newer-wins")
+	assert.NoError(t, err)
+	assert.Equal(t, NewerWinsPolicy, policy)
+
+	_, err = GetPolicy("always")
+	assert.Error(t, err)
+}
+
+func TestCreatePlan(t *testing.T) {
+	older := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	localFiles := map[string]*localFile{
+		"local-only.txt":   {path: "local-only.txt", modTime: older},
+		"same.txt":         {path: "same.txt", modTime: older},
+		"a/local-new.txt":  {path: "a/local-new.txt", modTime: newer},
+		"a/remote-new.txt": {path: "a/remote-new.txt", modTime: older},
+	}
+	remoteFiles := map[string]*remoteFile{
+		"remote-only.txt":  {path: "repo/remote-only.txt", modified: older},
+		"same.txt":         {path: "repo/same.txt", modified: older},
+		"a/local-new.txt":  {path: "repo/a/local-new.txt", modified: older},
+		"a/remote-new.txt": {path: "repo/a/remote-new.txt", modified: newer},
+	}
+	isIdentical := func(local *localFile, _ *remoteFile) (bool, error) {
+		return local.path == "same.txt", nil
+	}
+
+	tests := []struct {
+		policy   Policy
+		expected []Action
+	}{
+		{ReportPolicy, []Action{Conflict, Conflict, Push, Pull}},
+		{LocalWinsPolicy, []Action{Push, Push, Push, Pull}},
+		{RemoteWinsPolicy, []Action{Pull, Pull, Push, Pull}},
+		{NewerWinsPolicy, []Action{Push, Pull, Push, Pull}},
+	}
+	for _, test := range tests {
+		t.Run(string(test.policy), func(t *testing.T) {
+			plan, err := createPlan(localFiles, remoteFiles, test.policy, isIdentical)
+			assert.NoError(t, err)
+			// The plan is sorted by path, and identical files are omitted.
+			var paths []string
+			var actions []Action
+			for _, entry := range plan {
+				paths = append(paths, entry.Path)
+				actions = append(actions, entry.Action)
+			}
+			assert.Equal(t, []string{"a/local-new.txt", "a/remote-new.txt", "local-only.txt", "remote-only.txt"}, paths)
+			assert.Equal(t, test.expected, actions)
+		})
+	}
+}
+
+func TestCreatePullSpec(t *testing.T) {
+	syncCommand := NewSyncCommand().SetLocalDir("local").SetRepoPath("repo/")
+	pullSpec := syncCommand.createPullSpec([]string{"a*b.txt", "dir/(c).txt", "dir/d?.txt"})
+	require.Len(t, pullSpec.Files, 2)
+	// The files of each directory are searched by a single query, which matches their names literally.
+	assert.Equal(t, `{"type":"file","repo":"repo","path":".","$or":[{"name":"a*b.txt"}]}`, pullSpec.Files[0].Aql.ItemsFind)
+	assert.Equal(t, "local"+string(filepath.Separator), pullSpec.Files[0].Target)
+	assert.Equal(t, `{"type":"file","repo":"repo","path":"dir","$or":[{"name":"(c).txt"},{"name":"d?.txt"}]}`, pullSpec.Files[1].Aql.ItemsFind)
+	assert.Equal(t, filepath.Join("local", "dir")+string(filepath.Separator), pullSpec.Files[1].Target)
+
+	var relativePaths []string
+	for i := 0; i < aqlBatchSize+1; i++ {
+		relativePaths = append(relativePaths, fmt.Sprintf("file%d.txt", i))
+	}
+	assert.Len(t, syncCommand.createPullSpec(relativePaths).Files, 2)
+}
+
+func TestCreatePushSpec(t *testing.T) {
+	localDir := t.TempDir()
+	syncCommand := NewSyncCommand().SetLocalDir(localDir).SetRepoPath("repo/sub")
+	pushSpec, skippedPaths := syncCommand.createPushSpec([]string{"a.txt", "dir/a*b.txt", "(c)*.txt"})
+	assert.Equal(t, []string{"(c)*.txt"}, skippedPaths)
+	require.Len(t, pushSpec.Files, 2)
+	assert.Equal(t, "repo/sub/a.txt", pushSpec.Files[0].Target)
+	assert.Equal(t, "false", pushSpec.Files[0].Regexp)
+	// A path containing a wildcard is given as a regular expression, so that the upload takes it as a path.
+	assert.Equal(t, "repo/sub/dir/a*b.txt", pushSpec.Files[1].Target)
+	assert.Equal(t, "true", pushSpec.Files[1].Regexp)
+}
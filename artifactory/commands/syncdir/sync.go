@@ -0,0 +1,336 @@
+package syncdir
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	clientioutils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The number of files searched by a single AQL query of the pull.
+const aqlBatchSize = 500
+
+// Synchronizes a local directory with a repository path in both directions.
+// Files missing on one side are copied from the other side, and files which exist on both sides
+// with different checksums are resolved according to the sync policy.
+type SyncCommand struct {
+	serverDetails      *config.ServerDetails
+	localDir           string
+	repoPath           string
+	policy             Policy
+	threads            int
+	retries            int
+	retryWaitMilliSecs int
+	dryRun             bool
+	progress           clientioutils.ProgressMgr
+	plan               Plan
+	result             *commandsutils.Result
+}
+
+func NewSyncCommand() *SyncCommand {
+	return &SyncCommand{policy: ReportPolicy, threads: 3, result: new(commandsutils.Result)}
+}
+
+func (sc *SyncCommand) SetServerDetails(serverDetails *config.ServerDetails) *SyncCommand {
+	sc.serverDetails = serverDetails
+	return sc
+}
+
+func (sc *SyncCommand) SetLocalDir(localDir string) *SyncCommand {
+	sc.localDir = localDir
+	return sc
+}
+
+func (sc *SyncCommand) SetRepoPath(repoPath string) *SyncCommand {
+	sc.repoPath = strings.Trim(repoPath, "/")
+	return sc
+}
+
+func (sc *SyncCommand) SetPolicy(policy Policy) *SyncCommand {
+	sc.policy = policy
+	return sc
+}
+
+func (sc *SyncCommand) SetThreads(threads int) *SyncCommand {
+	sc.threads = threads
+	return sc
+}
+
+func (sc *SyncCommand) SetRetries(retries int) *SyncCommand {
+	sc.retries = retries
+	return sc
+}
+
+func (sc *SyncCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *SyncCommand {
+	sc.retryWaitMilliSecs = retryWaitMilliSecs
+	return sc
+}
+
+func (sc *SyncCommand) SetDryRun(dryRun bool) *SyncCommand {
+	sc.dryRun = dryRun
+	return sc
+}
+
+func (sc *SyncCommand) SetProgress(progress clientioutils.ProgressMgr) {
+	sc.progress = &initOnceProgressMgr{ProgressMgr: progress}
+}
+
+func (sc *SyncCommand) ServerDetails() (*config.ServerDetails, error) {
+	return sc.serverDetails, nil
+}
+
+func (sc *SyncCommand) Plan() Plan {
+	return sc.plan
+}
+
+func (sc *SyncCommand) Result() *commandsutils.Result {
+	return sc.result
+}
+
+func (sc *SyncCommand) CommandName() string {
+	return "rt_sync"
+}
+
+func (sc *SyncCommand) Run() (err error) {
+	isLocalDir, err := isDir(sc.localDir)
+	if err != nil {
+		return
+	}
+	if !isLocalDir {
+		return errorutils.CheckErrorf("'%s' is not a directory", sc.localDir)
+	}
+	localFiles, err := sc.collectLocalFiles()
+	if err != nil {
+		return
+	}
+	remoteFiles, err := sc.collectRemoteFiles()
+	if err != nil {
+		return
+	}
+	sc.plan, err = createPlan(localFiles, remoteFiles, sc.policy, func(local *localFile, remote *remoteFile) (bool, error) {
+		if local.size != remote.size {
+			return false, nil
+		}
+		return fileutils.IsEqualToLocalFile(local.path, remote.md5, remote.sha1)
+	})
+	if err != nil {
+		return
+	}
+	pushPaths, pullPaths, conflicts := sc.plan.filter(Push), sc.plan.filter(Pull), sc.plan.filter(Conflict)
+	if sc.dryRun {
+		// Nothing is transferred by a dry run, so the planned files are reported by the plan rather than by the result.
+		log.Info(fmt.Sprintf("Sync plan: %d files to push, %d files to pull and %d conflicting files.", len(pushPaths), len(pullPaths), len(conflicts)))
+		return coreutils.PrintTable(sc.plan, "Sync plan", "The local directory and the repository path are in sync", false)
+	}
+	if len(conflicts) > 0 {
+		log.Warn(strconv.Itoa(len(conflicts)), "conflicting files were left untouched. Use the --policy option to resolve them:\n  "+strings.Join(conflicts, "\n  "))
+	}
+	var readers []*content.ContentReader
+	if len(pushPaths) > 0 {
+		log.Info("Pushing", strconv.Itoa(len(pushPaths)), "files to", sc.repoPath+"...")
+		pushSpec, skippedPaths := sc.createPushSpec(pushPaths)
+		if len(skippedPaths) > 0 {
+			log.Warn(strconv.Itoa(len(skippedPaths)), "files whose paths contain both '*' and parentheses can't be pushed, and were left untouched:\n  "+strings.Join(skippedPaths, "\n  "))
+		}
+		uploadCommand := generic.NewUploadCommand()
+		uploadCommand.SetUploadConfiguration(&utils.UploadConfiguration{Threads: sc.threads}).SetSpec(pushSpec).
+			SetServerDetails(sc.serverDetails).SetDetailedSummary(true).SetRetries(sc.retries).SetRetryWaitMilliSecs(sc.retryWaitMilliSecs)
+		uploadCommand.SetProgress(sc.progress)
+		err = uploadCommand.Run()
+		readers = sc.addResult(uploadCommand.Result(), readers)
+	}
+	if len(pullPaths) > 0 {
+		log.Info("Pulling", strconv.Itoa(len(pullPaths)), "files to", sc.localDir+"...")
+		downloadCommand := generic.NewDownloadCommand()
+		downloadCommand.SetConfiguration(&utils.DownloadConfiguration{Threads: sc.threads, SplitCount: 0, MinSplitSize: -1, Symlink: true}).
+			SetSpec(sc.createPullSpec(pullPaths)).SetServerDetails(sc.serverDetails).SetDetailedSummary(true).
+			SetRetries(sc.retries).SetRetryWaitMilliSecs(sc.retryWaitMilliSecs)
+		downloadCommand.SetProgress(sc.progress)
+		err = errors.Join(err, downloadCommand.Run())
+		readers = sc.addResult(downloadCommand.Result(), readers)
+	}
+	if len(readers) > 0 {
+		mergedReader, mergeErr := content.MergeReaders(readers, content.DefaultKey)
+		err = errors.Join(err, mergeErr)
+		for _, reader := range readers {
+			err = errors.Join(err, reader.Close())
+		}
+		sc.result.SetReader(mergedReader)
+	}
+	return
+}
+
+// Adds the counters of the upload or download result to the sync result, and returns the readers to merge.
+func (sc *SyncCommand) addResult(result *commandsutils.Result, readers []*content.ContentReader) []*content.ContentReader {
+	sc.result.SetSuccessCount(sc.result.SuccessCount() + result.SuccessCount())
+	sc.result.SetFailCount(sc.result.FailCount() + result.FailCount())
+	if result.Reader() != nil {
+		readers = append(readers, result.Reader())
+	}
+	return readers
+}
+
+// Returns the local files, mapped by their path relative to the local directory, in the Artifactory path format.
+func (sc *SyncCommand) collectLocalFiles() (map[string]*localFile, error) {
+	localFiles := make(map[string]*localFile)
+	err := filepath.WalkDir(sc.localDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		fileInfo, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(sc.localDir, filePath)
+		if err != nil {
+			return err
+		}
+		localFiles[filepath.ToSlash(relativePath)] = &localFile{path: filePath, size: fileInfo.Size(), modTime: fileInfo.ModTime()}
+		return nil
+	})
+	return localFiles, errorutils.CheckError(err)
+}
+
+// Returns the files under the repository path, mapped by their path relative to the repository path.
+func (sc *SyncCommand) collectRemoteFiles() (remoteFiles map[string]*remoteFile, err error) {
+	servicesManager, err := utils.CreateServiceManager(sc.serverDetails, sc.retries, sc.retryWaitMilliSecs, false)
+	if err != nil {
+		return
+	}
+	searchParams := services.NewSearchParams()
+	searchParams.CommonParams = &servicesutils.CommonParams{Pattern: sc.repoPath + "/"}
+	searchParams.Recursive = true
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	remoteFiles = make(map[string]*remoteFile)
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		itemPath := item.GetItemRelativePath()
+		modified, _ := time.Parse(time.RFC3339, item.Modified)
+		remoteFiles[strings.TrimPrefix(itemPath, sc.repoPath+"/")] = &remoteFile{path: itemPath, size: item.Size, sha1: item.Actual_Sha1, md5: item.Actual_Md5, modified: modified}
+	}
+	return remoteFiles, reader.GetError()
+}
+
+// Creates the spec which pushes the files. Each file is pushed by its own spec file, which the upload resolves by a single
+// local lookup. The upload treats the pattern as a path only up to its first wildcard section, so a path containing '*' is
+// given as a regular expression, which is treated as a path up to its first section containing parentheses.
+// Paths containing both can't be given literally, so they are skipped.
+func (sc *SyncCommand) createPushSpec(relativePaths []string) (pushSpec *spec.SpecFiles, skippedPaths []string) {
+	pushSpec = new(spec.SpecFiles)
+	for _, relativePath := range relativePaths {
+		pattern := filepath.ToSlash(filepath.Join(sc.localDir, filepath.FromSlash(relativePath)))
+		isRegexp := strings.Contains(pattern, "*")
+		if isRegexp && strings.ContainsAny(pattern, "()") {
+			skippedPaths = append(skippedPaths, relativePath)
+			continue
+		}
+		if coreutils.IsWindows() {
+			pattern = ioutils.UnixToWinPathSeparator(pattern)
+		}
+		pushSpec.Files = append(pushSpec.Files, spec.File{Pattern: pattern, Target: path.Join(sc.repoPath, relativePath), Flat: "true",
+			Recursive: "false", Regexp: strconv.FormatBool(isRegexp)})
+	}
+	return
+}
+
+// Creates the spec which pulls the files. The files are searched by AQL queries which match their exact paths, so that
+// wildcards and parentheses in the paths are taken literally. Each query searches for up to aqlBatchSize files of a
+// single directory, which are downloaded flat to the matching local directory.
+func (sc *SyncCommand) createPullSpec(relativePaths []string) *spec.SpecFiles {
+	pullSpec := new(spec.SpecFiles)
+	namesByDir := make(map[string][]string)
+	var dirs []string
+	for _, relativePath := range relativePaths {
+		dir, name := path.Split(relativePath)
+		if _, exists := namesByDir[dir]; !exists {
+			dirs = append(dirs, dir)
+		}
+		namesByDir[dir] = append(namesByDir[dir], name)
+	}
+	for _, dir := range dirs {
+		repo, repoDir := splitRepoPath(path.Join(sc.repoPath, dir))
+		target := filepath.Join(sc.localDir, filepath.FromSlash(dir)) + string(filepath.Separator)
+		names := namesByDir[dir]
+		for start := 0; start < len(names); start += aqlBatchSize {
+			end := start + aqlBatchSize
+			if end > len(names) {
+				end = len(names)
+			}
+			pullSpec.Files = append(pullSpec.Files, spec.File{Aql: servicesutils.Aql{ItemsFind: createNamesAql(repo, repoDir, names[start:end])},
+				Target: target, Flat: "true"})
+		}
+	}
+	return pullSpec
+}
+
+// Splits a path in Artifactory to its repository and the path in the repository, in the AQL format.
+func splitRepoPath(repoPath string) (repo, pathInRepo string) {
+	repo, pathInRepo, _ = strings.Cut(repoPath, "/")
+	if pathInRepo == "" {
+		pathInRepo = "."
+	}
+	return
+}
+
+func createNamesAql(repo, pathInRepo string, names []string) string {
+	conditions := make([]string, len(names))
+	for i, name := range names {
+		conditions[i] = `{"name":` + quote(name) + `}`
+	}
+	return `{"type":"file","repo":` + quote(repo) + `,"path":` + quote(pathInRepo) + `,"$or":[` + strings.Join(conditions, ",") + `]}`
+}
+
+// Returns the string as a JSON string.
+func quote(value string) string {
+	// Marshaling a string never fails.
+	content, _ := json.Marshal(value)
+	return string(content)
+}
+
+// The sync runs both an upload and a download, which should share the same progress bars.
+type initOnceProgressMgr struct {
+	clientioutils.ProgressMgr
+	once sync.Once
+}
+
+func (p *initOnceProgressMgr) InitProgressReaders() {
+	p.once.Do(p.ProgressMgr.InitProgressReaders)
+}
+
+func isDir(path string) (bool, error) {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	return fileInfo.IsDir(), nil
+}
@@ -0,0 +1,316 @@
+package undo
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const tasksCapacity = 10000
+
+// Deletes artifacts like generic.DeleteCommand. If a backup repository is set, the artifacts are copied to the
+// backup repository and recorded as an operation before they are deleted, so that they can be restored later.
+type UndoableDeleteCommand struct {
+	*generic.DeleteCommand
+	backupOptions
+}
+
+func NewUndoableDeleteCommand(deleteCommand *generic.DeleteCommand) *UndoableDeleteCommand {
+	return &UndoableDeleteCommand{DeleteCommand: deleteCommand}
+}
+
+func (udc *UndoableDeleteCommand) SetBackupRepo(backupRepo string) *UndoableDeleteCommand {
+	udc.backupRepo = backupRepo
+	return udc
+}
+
+func (udc *UndoableDeleteCommand) SetBackupRetries(retries, retryWaitMilliSecs int) *UndoableDeleteCommand {
+	udc.retries, udc.retryWaitMilliSecs = retries, retryWaitMilliSecs
+	return udc
+}
+
+func (udc *UndoableDeleteCommand) Run() (err error) {
+	if udc.backupRepo == "" || udc.DryRun() {
+		return udc.DeleteCommand.Run()
+	}
+	reader, err := udc.GetPathsToDelete()
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	if !udc.Quiet() {
+		allowDelete, err := utils.ConfirmDelete(reader)
+		if err != nil || !allowDelete {
+			return err
+		}
+	}
+	serverDetails, err := udc.ServerDetails()
+	if err != nil {
+		return
+	}
+	udc.threads = udc.Threads()
+	// The paths which are deleted are the ones backed up, so that paths created after the search aren't deleted without a backup.
+	udc.operation, err = backupArtifacts(serverDetails, DeleteOperation, &udc.backupOptions, func(_ artifactory.ArtifactoryServicesManager, operation *Operation) ([]Item, error) {
+		return collectDeleteItems(operation, reader)
+	})
+	if err != nil {
+		return
+	}
+	defer logRestoreHint(udc.operation)
+	successCount, failedCount, err := udc.DeleteFiles(reader)
+	udc.Result().SetSuccessCount(successCount)
+	udc.Result().SetFailCount(failedCount)
+	return
+}
+
+// Moves artifacts like generic.MoveCommand. If a backup repository is set, the artifacts are copied to the
+// backup repository and recorded as an operation before they are moved, so that they can be restored later.
+// In that case, exactly the recorded artifacts are moved, each to the target the move command would move it to.
+type UndoableMoveCommand struct {
+	*generic.MoveCommand
+	backupOptions
+}
+
+func NewUndoableMoveCommand(moveCommand *generic.MoveCommand) *UndoableMoveCommand {
+	return &UndoableMoveCommand{MoveCommand: moveCommand}
+}
+
+func (umc *UndoableMoveCommand) SetBackupRepo(backupRepo string) *UndoableMoveCommand {
+	umc.backupRepo = backupRepo
+	return umc
+}
+
+func (umc *UndoableMoveCommand) SetBackupRetries(retries, retryWaitMilliSecs int) *UndoableMoveCommand {
+	umc.retries, umc.retryWaitMilliSecs = retries, retryWaitMilliSecs
+	return umc
+}
+
+func (umc *UndoableMoveCommand) Run() (err error) {
+	if umc.backupRepo == "" || umc.DryRun() {
+		return umc.MoveCommand.Run()
+	}
+	serverDetails, err := umc.ServerDetails()
+	if err != nil {
+		return
+	}
+	umc.threads = umc.Threads()
+	umc.operation, err = backupArtifacts(serverDetails, MoveOperation, &umc.backupOptions, func(servicesManager artifactory.ArtifactoryServicesManager, operation *Operation) ([]Item, error) {
+		return collectItems(servicesManager, operation, umc.Spec())
+	})
+	if err != nil {
+		return
+	}
+	defer logRestoreHint(umc.operation)
+	servicesManager, err := utils.CreateServiceManager(serverDetails, umc.retries, umc.retryWaitMilliSecs, false)
+	if err != nil {
+		return
+	}
+	// The artifacts which are moved are the ones backed up, so that artifacts matching the spec after the search aren't moved without a backup.
+	log.Info("Moving", strconv.Itoa(len(umc.operation.Items)), "artifacts...")
+	failedCount := runOnItems(umc.operation.Items, umc.threads, func(item Item) error {
+		if e := sendMoveCopyRequest(servicesManager, "move", item.Path, item.Target); e != nil {
+			log.Error("Failed moving", item.Path, "to", item.Target+":", e.Error())
+			return e
+		}
+		log.Debug("Moved", item.Path, "to", item.Target)
+		return nil
+	})
+	umc.Result().SetSuccessCount(len(umc.operation.Items) - failedCount)
+	umc.Result().SetFailCount(failedCount)
+	return
+}
+
+type backupOptions struct {
+	backupRepo         string
+	threads            int
+	retries            int
+	retryWaitMilliSecs int
+	operation          *Operation
+}
+
+// Returns the recorded operation, or nil if no backup repository was set.
+func (bo *backupOptions) Operation() *Operation {
+	return bo.operation
+}
+
+func logRestoreHint(operation *Operation) {
+	if len(operation.Items) == 0 {
+		return
+	}
+	log.Info("The affected artifacts were backed up to " + operation.BackupDir() + ". To restore them, run: jf rt restore " + operation.Id)
+}
+
+// Collects the artifacts affected by the operation, copies them to the backup repository and saves the operation.
+// If any of the artifacts can't be backed up, an error is returned and the operation shouldn't be performed.
+func backupArtifacts(serverDetails *config.ServerDetails, operationType OperationType, options *backupOptions,
+	collect func(artifactory.ArtifactoryServicesManager, *Operation) ([]Item, error)) (*Operation, error) {
+	operation, err := NewOperation(operationType, serverDetails.ArtifactoryUrl, strings.Trim(options.backupRepo, "/"))
+	if err != nil {
+		return nil, err
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, options.retries, options.retryWaitMilliSecs, false)
+	if err != nil {
+		return nil, err
+	}
+	if operation.Items, err = collect(servicesManager, operation); err != nil {
+		return nil, err
+	}
+	if len(operation.Items) == 0 {
+		return operation, nil
+	}
+	log.Info("Backing up", strconv.Itoa(len(operation.Items)), "artifacts to", operation.BackupDir()+"...")
+	failedCount := runOnItems(operation.Items, options.threads, func(item Item) error {
+		e := sendMoveCopyRequest(servicesManager, "copy", item.Path, item.BackupPath)
+		if e != nil {
+			log.Error("Failed backing up", item.Path+":", e.Error())
+		}
+		return e
+	})
+	if failedCount > 0 {
+		return nil, errorutils.CheckErrorf("failed backing up %d out of %d artifacts, so the %s operation was not performed. The artifacts which were backed up can be found under %s", failedCount, len(operation.Items), operationType, operation.BackupDir())
+	}
+	return operation, operation.Save()
+}
+
+// Runs the action on the items in parallel, and returns the number of items for which it failed.
+func runOnItems(items []Item, threads int, action func(item Item) error) (failedCount int) {
+	if threads < 1 {
+		threads = 1
+	}
+	var countersMutex sync.Mutex
+	producerConsumer := parallel.NewRunner(threads, tasksCapacity, false)
+	go func() {
+		defer producerConsumer.Done()
+		for _, item := range items {
+			item := item
+			_, _ = producerConsumer.AddTask(func(int) error {
+				if action(item) != nil {
+					countersMutex.Lock()
+					failedCount++
+					countersMutex.Unlock()
+				}
+				return nil
+			})
+		}
+	}()
+	producerConsumer.Run()
+	return
+}
+
+func collectItems(servicesManager artifactory.ArtifactoryServicesManager, operation *Operation, fileSpec *spec.SpecFiles) ([]Item, error) {
+	var items []Item
+	collected := make(map[string]bool)
+	for _, file := range fileSpec.Files {
+		file := file
+		searchParams, err := utils.GetSearchParams(&file)
+		if err != nil {
+			return nil, err
+		}
+		reader, err := servicesManager.SearchFiles(searchParams)
+		if err != nil {
+			return nil, err
+		}
+		for resultItem := new(servicesutils.ResultItem); reader.NextRecord(resultItem) == nil; resultItem = new(servicesutils.ResultItem) {
+			itemPath := resultItem.GetItemRelativePath()
+			if resultItem.Type == "folder" || collected[itemPath] {
+				continue
+			}
+			collected[itemPath] = true
+			item := Item{Path: itemPath, BackupPath: operation.BackupDir() + "/" + itemPath, Sha256: resultItem.Sha256, Props: toPropsMap(resultItem.Properties)}
+			if operation.Type == MoveOperation {
+				if item.Target, err = getMoveTarget(&file, resultItem); err != nil {
+					return nil, errors.Join(err, reader.Close())
+				}
+			}
+			items = append(items, item)
+		}
+		if err = errors.Join(reader.GetError(), reader.Close()); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// Returns the paths to delete, read from the reader the delete command deletes them by. Deleted folders are backed up
+// with their content. The reader is reset, so that it can be read again by the delete command.
+func collectDeleteItems(operation *Operation, reader *content.ContentReader) ([]Item, error) {
+	var items []Item
+	for resultItem := new(servicesutils.ResultItem); reader.NextRecord(resultItem) == nil; resultItem = new(servicesutils.ResultItem) {
+		// The path of a folder ends with a slash, which would make Artifactory copy the folder into its backup path.
+		itemPath := strings.TrimSuffix(resultItem.GetItemRelativePath(), "/")
+		items = append(items, Item{Path: itemPath, BackupPath: operation.BackupDir() + "/" + itemPath, Sha256: resultItem.Sha256, Props: toPropsMap(resultItem.Properties)})
+	}
+	if err := reader.GetError(); err != nil {
+		return nil, err
+	}
+	reader.Reset()
+	return items, nil
+}
+
+// Returns the path an artifact is moved to, the same way the move command determines it.
+func getMoveTarget(file *spec.File, resultItem *servicesutils.ResultItem) (string, error) {
+	flat, err := file.IsFlat(false)
+	if err != nil {
+		return "", err
+	}
+	target, placeholdersUsed, err := clientutils.BuildTargetPath(file.Pattern, resultItem.GetItemRelativePath(), file.Target, true)
+	if err != nil {
+		return "", err
+	}
+	// When placeholders are used, the path of the artifact isn't taken into account, as if flat was true.
+	if !flat && !placeholdersUsed {
+		if strings.Contains(file.Target, "/") {
+			fileName, dir := fileutils.GetFileAndDirFromPath(file.Target)
+			target = clientutils.TrimPath(dir + "/" + resultItem.Path + "/" + fileName)
+		} else {
+			target = clientutils.TrimPath(file.Target + "/" + resultItem.Path + "/")
+		}
+	}
+	if strings.HasSuffix(target, "/") {
+		target += resultItem.Name
+	}
+	return target, nil
+}
+
+func toPropsMap(properties []servicesutils.Property) map[string][]string {
+	if len(properties) == 0 {
+		return nil
+	}
+	props := make(map[string][]string)
+	for _, property := range properties {
+		props[property.Key] = append(props[property.Key], property.Value)
+	}
+	return props
+}
+
+// Copies or moves a single artifact, using the Artifactory copy or move REST API.
+func sendMoveCopyRequest(servicesManager artifactory.ArtifactoryServicesManager, action, sourcePath, targetPath string) error {
+	requestUrl, err := servicesutils.BuildArtifactoryUrl(servicesManager.GetConfig().GetServiceDetails().GetUrl(), path.Join("api", action, sourcePath), map[string]string{"to": targetPath})
+	if err != nil {
+		return err
+	}
+	httpClientDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendPost(requestUrl, nil, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
+}
@@ -0,0 +1,130 @@
+package undo
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+type OperationType string
+
+const (
+	DeleteOperation OperationType = "delete"
+	MoveOperation   OperationType = "move"
+
+	OperationsDirName = "operations"
+	operationIdLayout = "20060102-150405"
+)
+
+var operationIdRegexp = regexp.MustCompile(`^\d{8}-\d{6}-[0-9a-f]{8}$`)
+
+// A delete or move operation, recorded before it was performed, so that it can be restored.
+// The operation is stored as a JSON file under the JFrog CLI home directory, named after the operation ID.
+type Operation struct {
+	Id         string        `json:"id"`
+	Type       OperationType `json:"type"`
+	ServerUrl  string        `json:"serverUrl"`
+	BackupRepo string        `json:"backupRepo"`
+	Created    time.Time     `json:"created"`
+	Items      []Item        `json:"items"`
+}
+
+// An artifact deleted or moved by the operation. A deleted folder is an item too, and is backed up with its content.
+type Item struct {
+	// The original path of the artifact, in the form of <repository>/<path>.
+	Path string `json:"path"`
+	// The path of the artifact's copy in the backup repository.
+	BackupPath string `json:"backupPath"`
+	// The path the artifact was moved to. Empty for deleted artifacts.
+	Target string              `json:"target,omitempty"`
+	Sha256 string              `json:"sha256,omitempty"`
+	Props  map[string][]string `json:"props,omitempty"`
+}
+
+func NewOperation(operationType OperationType, serverUrl, backupRepo string) (*Operation, error) {
+	id, err := createOperationId()
+	if err != nil {
+		return nil, err
+	}
+	return &Operation{Id: id, Type: operationType, ServerUrl: serverUrl, BackupRepo: backupRepo, Created: time.Now()}, nil
+}
+
+// Creates a unique operation ID, starting with the creation time, such as 20230715-143005-9f86d081.
+func createOperationId() (string, error) {
+	randomBytes := make([]byte, 4)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return time.Now().Format(operationIdLayout) + "-" + hex.EncodeToString(randomBytes), nil
+}
+
+func getOperationsDir() (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, OperationsDirName), nil
+}
+
+func getOperationPath(id string) (string, error) {
+	if !operationIdRegexp.MatchString(id) {
+		return "", errorutils.CheckErrorf("invalid operation ID '%s'", id)
+	}
+	operationsDir, err := getOperationsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(operationsDir, id+".json"), nil
+}
+
+func LoadOperation(id string) (*Operation, error) {
+	operationPath, err := getOperationPath(id)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(operationPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errorutils.CheckErrorf("operation '%s' was not found. It may have been restored already", id)
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	operation := new(Operation)
+	return operation, errorutils.CheckError(json.Unmarshal(content, operation))
+}
+
+func (o *Operation) Save() error {
+	operationPath, err := getOperationPath(o.Id)
+	if err != nil {
+		return err
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(operationPath)); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(o, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(operationPath, content, 0600))
+}
+
+func (o *Operation) Delete() error {
+	operationPath, err := getOperationPath(o.Id)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckError(os.Remove(operationPath))
+}
+
+// Returns the directory in the backup repository, containing the copies of the operation's artifacts.
+func (o *Operation) BackupDir() string {
+	return o.BackupRepo + "/" + o.Id
+}
@@ -0,0 +1,120 @@
+package undo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationSaveAndLoad(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	operation, err := NewOperation(DeleteOperation, "https://acme.jfrog.io/artifactory/", "trash")
+	assert.NoError(t, err)
+	assert.Regexp(t, operationIdRegexp, operation.Id)
+	operation.Items = []Item{{Path: "libs/a/b.jar", BackupPath: operation.BackupDir() + "/libs/a/b.jar", Props: map[string][]string{"k": {"v1", "v2"}}}}
+	assert.NoError(t, operation.Save())
+
+	loaded, err := LoadOperation(operation.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, operation.Items, loaded.Items)
+	assert.Equal(t, "trash/"+operation.Id, loaded.BackupDir())
+
+	assert.NoError(t, loaded.Delete())
+	_, err = LoadOperation(operation.Id)
+	assert.ErrorContains(t, err, "was not found")
+	_, err = LoadOperation("../config")
+	assert.ErrorContains(t, err, "invalid operation ID")
+}
+
+func TestGetMoveTarget(t *testing.T) {
+	resultItem := &servicesutils.ResultItem{Repo: "libs", Path: "a/b", Name: "c.jar"}
+	tests := []struct {
+		pattern  string
+		target   string
+		flat     string
+		expected string
+	}{
+		{"libs/a/*", "other/", "", "other/a/b/c.jar"},
+		{"libs/a/*", "other/x/", "true", "other/x/c.jar"},
+		{"libs/a/*", "other/x/renamed.jar", "", "other/x/a/b/renamed.jar"},
+		{"libs/(*)/b/(*)", "other/{1}/{2}", "", "other/a/c.jar"},
+		{"libs/a/*", "other", "", "other/a/b/c.jar"},
+	}
+	for _, test := range tests {
+		file := &spec.File{Pattern: test.pattern, Target: test.target, Flat: test.flat}
+		target, err := getMoveTarget(file, resultItem)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, target, test.pattern+" -> "+test.target)
+	}
+}
+
+func TestCollectDeleteItems(t *testing.T) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	require.NoError(t, err)
+	writer.Write(servicesutils.ResultItem{Repo: "libs", Path: "a", Name: "b.jar", Type: "file", Sha256: "1"})
+	writer.Write(servicesutils.ResultItem{Repo: "libs", Path: "a", Name: "c", Type: "folder"})
+	require.NoError(t, writer.Close())
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+
+	operation := &Operation{Id: "20230715-143005-9f86d081", BackupRepo: "trash"}
+	items, err := collectDeleteItems(operation, reader)
+	require.NoError(t, err)
+	assert.Equal(t, []Item{
+		{Path: "libs/a/b.jar", BackupPath: "trash/20230715-143005-9f86d081/libs/a/b.jar", Sha256: "1"},
+		{Path: "libs/a/c", BackupPath: "trash/20230715-143005-9f86d081/libs/a/c"},
+	}, items)
+	// The reader is reset, so that the same paths are deleted.
+	length, err := reader.Length()
+	require.NoError(t, err)
+	assert.Equal(t, 2, length)
+	assert.NoError(t, reader.NextRecord(new(servicesutils.ResultItem)))
+}
+
+func TestUndoableMove(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	var requestsMutex sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/system/version":
+			_, _ = w.Write([]byte(`{"version":"7.60.0"}`))
+			return
+		case "/api/search/aql":
+			_, _ = w.Write([]byte(`{"results":[{"repo":"libs","path":"a","name":"b.jar","type":"file"},{"repo":"libs","path":"a","name":"c.jar","type":"file"}]}`))
+			return
+		}
+		requestsMutex.Lock()
+		requests = append(requests, r.URL.Path+"?to="+r.URL.Query().Get("to"))
+		requestsMutex.Unlock()
+	}))
+	defer server.Close()
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/"}
+	moveSpec := spec.NewBuilder().Pattern("libs/a/*").Target("other/").Flat(true).BuildSpec()
+	moveCommand := generic.NewMoveCommand()
+	moveCommand.SetThreads(2).SetServerDetails(serverDetails).SetSpec(moveSpec)
+	command := NewUndoableMoveCommand(moveCommand).SetBackupRepo("trash")
+	require.NoError(t, command.Run())
+
+	assert.Equal(t, 2, command.Result().SuccessCount())
+	backupDir := command.Operation().BackupDir()
+	// The backed up artifacts are moved, without searching them again.
+	assert.ElementsMatch(t, []string{
+		"/api/copy/libs/a/b.jar?to=" + backupDir + "/libs/a/b.jar",
+		"/api/copy/libs/a/c.jar?to=" + backupDir + "/libs/a/c.jar",
+		"/api/move/libs/a/b.jar?to=other/b.jar",
+		"/api/move/libs/a/c.jar?to=other/c.jar",
+	}, requests)
+}
@@ -0,0 +1,171 @@
+package undo
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+	"sync"
+
+	"github.com/jfrog/gofrog/parallel"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Restores the artifacts of a recorded delete or move operation to their original paths, and sets their recorded properties.
+// Deleted artifacts are moved back from the backup repository. Moved artifacts are moved back from their target paths,
+// or from the backup repository if they no longer exist in their target paths.
+// Once all the artifacts are restored, the backup copies and the recorded operation are removed.
+// Otherwise, the operation is updated to include only the artifacts which weren't restored, so that the command can be run again.
+type RestoreCommand struct {
+	serverDetails      *config.ServerDetails
+	operationId        string
+	threads            int
+	retries            int
+	retryWaitMilliSecs int
+	result             *commandsutils.Result
+}
+
+func NewRestoreCommand() *RestoreCommand {
+	return &RestoreCommand{threads: 3, result: new(commandsutils.Result)}
+}
+
+func (rc *RestoreCommand) SetServerDetails(serverDetails *config.ServerDetails) *RestoreCommand {
+	rc.serverDetails = serverDetails
+	return rc
+}
+
+func (rc *RestoreCommand) SetOperationId(operationId string) *RestoreCommand {
+	rc.operationId = operationId
+	return rc
+}
+
+func (rc *RestoreCommand) SetThreads(threads int) *RestoreCommand {
+	rc.threads = threads
+	return rc
+}
+
+func (rc *RestoreCommand) SetRetries(retries int) *RestoreCommand {
+	rc.retries = retries
+	return rc
+}
+
+func (rc *RestoreCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *RestoreCommand {
+	rc.retryWaitMilliSecs = retryWaitMilliSecs
+	return rc
+}
+
+func (rc *RestoreCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rc.serverDetails, nil
+}
+
+func (rc *RestoreCommand) Result() *commandsutils.Result {
+	return rc.result
+}
+
+func (rc *RestoreCommand) CommandName() string {
+	return "rt_restore"
+}
+
+func (rc *RestoreCommand) Run() error {
+	operation, err := LoadOperation(rc.operationId)
+	if err != nil {
+		return err
+	}
+	if operation.ServerUrl != rc.serverDetails.ArtifactoryUrl {
+		return errorutils.CheckErrorf("operation '%s' was performed on %s, but the current server is %s. Use the --server-id option to select the server", operation.Id, operation.ServerUrl, rc.serverDetails.ArtifactoryUrl)
+	}
+	servicesManager, err := utils.CreateServiceManager(rc.serverDetails, rc.retries, rc.retryWaitMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	log.Info("Restoring", strconv.Itoa(len(operation.Items)), "artifacts of the", string(operation.Type), "operation", operation.Id+"...")
+	var failedItems []Item
+	var resultMutex sync.Mutex
+	producerConsumer := parallel.NewRunner(rc.threads, tasksCapacity, false)
+	go func() {
+		defer producerConsumer.Done()
+		for _, item := range operation.Items {
+			item := item
+			_, _ = producerConsumer.AddTask(func(int) error {
+				e := restoreItem(servicesManager, operation.Type, &item)
+				resultMutex.Lock()
+				defer resultMutex.Unlock()
+				if e != nil {
+					log.Error("Failed restoring", item.Path+":", e.Error())
+					failedItems = append(failedItems, item)
+					return nil
+				}
+				rc.result.SetSuccessCount(rc.result.SuccessCount() + 1)
+				return nil
+			})
+		}
+	}()
+	producerConsumer.Run()
+	rc.result.SetFailCount(len(failedItems))
+	if len(failedItems) > 0 {
+		operation.Items = failedItems
+		return errors.Join(errorutils.CheckErrorf("failed restoring %d artifacts. Run the command again to retry restoring them", len(failedItems)), operation.Save())
+	}
+	if err = deleteBackupDir(servicesManager, operation); err != nil {
+		log.Warn("The artifacts were restored, but their backup copies couldn't be removed from", operation.BackupDir()+":", err.Error())
+	}
+	return operation.Delete()
+}
+
+func restoreItem(servicesManager artifactory.ArtifactoryServicesManager, operationType OperationType, item *Item) error {
+	log.Info("Restoring", item.Path)
+	var err error
+	if operationType == MoveOperation {
+		if err = sendMoveCopyRequest(servicesManager, "move", item.Target, item.Path); err == nil {
+			return setProps(servicesManager, item)
+		}
+		log.Debug("Couldn't move", item.Target, "back to", item.Path+", restoring it from the backup repository:", err.Error())
+	}
+	if err = sendMoveCopyRequest(servicesManager, "move", item.BackupPath, item.Path); err != nil {
+		return err
+	}
+	return setProps(servicesManager, item)
+}
+
+// Sets the recorded properties, in case they were modified after the operation was performed.
+func setProps(servicesManager artifactory.ArtifactoryServicesManager, item *Item) error {
+	if len(item.Props) == 0 {
+		return nil
+	}
+	props := servicesutils.NewProperties()
+	for key, values := range item.Props {
+		for _, value := range values {
+			props.AddProperty(key, value)
+		}
+	}
+	requestUrl, err := servicesutils.BuildArtifactoryUrl(servicesManager.GetConfig().GetServiceDetails().GetUrl(), path.Join("api", "storage", item.Path), make(map[string]string))
+	if err != nil {
+		return err
+	}
+	requestUrl += "?properties=" + props.ToEncodedString(true) + "&recursive=0"
+	httpClientDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendPut(requestUrl, nil, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusNoContent)
+}
+
+func deleteBackupDir(servicesManager artifactory.ArtifactoryServicesManager, operation *Operation) error {
+	requestUrl, err := servicesutils.BuildArtifactoryUrl(servicesManager.GetConfig().GetServiceDetails().GetUrl(), operation.BackupDir(), make(map[string]string))
+	if err != nil {
+		return err
+	}
+	httpClientDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendDelete(requestUrl, nil, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
@@ -0,0 +1,85 @@
+package upstream
+
+import (
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Records references to the upstream builds in the locally collected build-info. The upstream builds must be
+// published, and a LATEST build number is recorded as the number of the latest published build.
+type BuildAddUpstreamCommand struct {
+	buildConfiguration *utils.BuildConfiguration
+	serverDetails      *config.ServerDetails
+	upstreamBuilds     []Build
+}
+
+func NewBuildAddUpstreamCommand() *BuildAddUpstreamCommand {
+	return &BuildAddUpstreamCommand{}
+}
+
+func (bauc *BuildAddUpstreamCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *BuildAddUpstreamCommand {
+	bauc.buildConfiguration = buildConfiguration
+	return bauc
+}
+
+func (bauc *BuildAddUpstreamCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildAddUpstreamCommand {
+	bauc.serverDetails = serverDetails
+	return bauc
+}
+
+func (bauc *BuildAddUpstreamCommand) SetUpstreamBuilds(upstreamBuilds []Build) *BuildAddUpstreamCommand {
+	bauc.upstreamBuilds = upstreamBuilds
+	return bauc
+}
+
+func (bauc *BuildAddUpstreamCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bauc.serverDetails, nil
+}
+
+func (bauc *BuildAddUpstreamCommand) CommandName() string {
+	return "rt_build_add_upstream"
+}
+
+func (bauc *BuildAddUpstreamCommand) Run() error {
+	buildName, err := bauc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bauc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(bauc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	properties := make(buildinfo.Env, len(bauc.upstreamBuilds))
+	var recordedBuilds []Build
+	for _, upstreamBuild := range bauc.upstreamBuilds {
+		if upstreamBuild.Name == buildName {
+			return errorutils.CheckErrorf("build %s cannot be an upstream build of a build with the same name", upstreamBuild)
+		}
+		upstreamBuildInfo, err := getPublishedBuildInfo(servicesManager, upstreamBuild, bauc.buildConfiguration.GetProject())
+		if err != nil {
+			return err
+		}
+		properties[upstreamPropertyPrefix+upstreamBuild.Name] = upstreamBuildInfo.Number
+		recordedBuilds = append(recordedBuilds, Build{Name: upstreamBuild.Name, Number: upstreamBuildInfo.Number})
+	}
+	if err = utils.SaveBuildGeneralDetails(buildName, buildNumber, bauc.buildConfiguration.GetProject()); err != nil {
+		return err
+	}
+	populateFunc := func(partial *buildinfo.Partial) {
+		partial.Env = properties
+	}
+	if err = utils.SavePartialBuildInfo(buildName, buildNumber, bauc.buildConfiguration.GetProject(), populateFunc); err != nil {
+		return err
+	}
+	for _, recordedBuild := range recordedBuilds {
+		log.Info("Recorded build", recordedBuild.String(), "as an upstream build of", buildName+"/"+buildNumber)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package upstream
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Promotes a build together with its upstream chain: the upstream builds recorded in its build-info, their own
+// upstream builds, and so on. All the builds are promoted with the same promotion parameters.
+type BuildPromoteChainCommand struct {
+	services.PromotionParams
+	buildConfiguration *utils.BuildConfiguration
+	serverDetails      *config.ServerDetails
+	dryRun             bool
+	promotedBuilds     []Build
+}
+
+func NewBuildPromoteChainCommand() *BuildPromoteChainCommand {
+	return &BuildPromoteChainCommand{}
+}
+
+func (bpcc *BuildPromoteChainCommand) SetDryRun(dryRun bool) *BuildPromoteChainCommand {
+	bpcc.dryRun = dryRun
+	return bpcc
+}
+
+func (bpcc *BuildPromoteChainCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildPromoteChainCommand {
+	bpcc.serverDetails = serverDetails
+	return bpcc
+}
+
+func (bpcc *BuildPromoteChainCommand) SetPromotionParams(params services.PromotionParams) *BuildPromoteChainCommand {
+	bpcc.PromotionParams = params
+	return bpcc
+}
+
+func (bpcc *BuildPromoteChainCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *BuildPromoteChainCommand {
+	bpcc.buildConfiguration = buildConfiguration
+	return bpcc
+}
+
+// Returns the builds which were promoted, starting with the build itself.
+func (bpcc *BuildPromoteChainCommand) PromotedBuilds() []Build {
+	return bpcc.promotedBuilds
+}
+
+func (bpcc *BuildPromoteChainCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bpcc.serverDetails, nil
+}
+
+func (bpcc *BuildPromoteChainCommand) CommandName() string {
+	return "rt_build_promote_chain"
+}
+
+func (bpcc *BuildPromoteChainCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(bpcc.serverDetails, -1, 0, bpcc.dryRun)
+	if err != nil {
+		return err
+	}
+	buildName, err := bpcc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bpcc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	// The whole chain is resolved before promoting, so that nothing is promoted if an upstream build is missing.
+	chain, err := resolveChain(servicesManager, Build{Name: buildName, Number: buildNumber}, bpcc.buildConfiguration.GetProject())
+	if err != nil {
+		return err
+	}
+	log.Info("Promoting build", chain[0].String(), "with", len(chain)-1, "upstream builds...")
+	bpcc.promotedBuilds = nil
+	for _, build := range chain {
+		params := bpcc.PromotionParams
+		params.BuildName, params.BuildNumber, params.ProjectKey = build.Name, build.Number, bpcc.buildConfiguration.GetProject()
+		if err = servicesManager.PromoteBuild(params); err != nil {
+			return err
+		}
+		bpcc.promotedBuilds = append(bpcc.promotedBuilds, build)
+	}
+	return nil
+}
+
+// Returns the build and all of its upstream builds, in breadth-first order. Each build appears once, even if
+// several builds in the chain share it as an upstream build, or the recorded references form a cycle.
+func resolveChain(servicesManager artifactory.ArtifactoryServicesManager, build Build, project string) ([]Build, error) {
+	var chain []Build
+	visited := make(map[Build]bool)
+	queue := []Build{build}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		buildInfo, err := getPublishedBuildInfo(servicesManager, current, project)
+		if err != nil {
+			return nil, err
+		}
+		// A LATEST build number is resolved to the number of the latest published build.
+		current.Number = buildInfo.Number
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		chain = append(chain, current)
+		for _, upstreamBuild := range GetUpstreamBuilds(buildInfo) {
+			if !visited[upstreamBuild] {
+				log.Debug("Build", current.String(), "has the upstream build", upstreamBuild.String())
+				queue = append(queue, upstreamBuild)
+			}
+		}
+	}
+	return chain, nil
+}
@@ -0,0 +1,80 @@
+package upstream
+
+import (
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The upstream builds are recorded as build-info properties, with the upstream build name appended to the prefix and
+// the upstream build number as the value. Unlike the collected environment variables, these properties aren't
+// filtered by the env-include and env-exclude options of the build-publish command.
+const upstreamPropertyPrefix = "buildInfo.upstream."
+
+// A published build which another build was built from, such as the build of a previous pipeline stage.
+type Build struct {
+	Name   string
+	Number string
+}
+
+func (b Build) String() string {
+	return b.Name + "/" + b.Number
+}
+
+// Parses a build reference in the following format: <build name>/<build number>.
+// The build name may contain slashes, so the reference is split at its last slash.
+func ParseBuild(reference string) (Build, error) {
+	separatorIndex := strings.LastIndex(reference, "/")
+	if separatorIndex <= 0 || separatorIndex == len(reference)-1 {
+		return Build{}, errorutils.CheckErrorf("the upstream build '%s' should be in the following format: <build name>/<build number>", reference)
+	}
+	return Build{Name: reference[:separatorIndex], Number: reference[separatorIndex+1:]}, nil
+}
+
+// Parses a list of build references separated by semicolons.
+func ParseBuilds(references string) ([]Build, error) {
+	var builds []Build
+	for _, reference := range strings.Split(references, ";") {
+		if reference = strings.TrimSpace(reference); reference == "" {
+			continue
+		}
+		build, err := ParseBuild(reference)
+		if err != nil {
+			return nil, err
+		}
+		builds = append(builds, build)
+	}
+	if len(builds) == 0 {
+		return nil, errorutils.CheckErrorf("at least one upstream build is expected")
+	}
+	return builds, nil
+}
+
+// Returns the upstream builds recorded in the build-info, sorted by name.
+func GetUpstreamBuilds(buildInfo *buildinfo.BuildInfo) []Build {
+	var builds []Build
+	for key, value := range buildInfo.Properties {
+		if name := strings.TrimPrefix(key, upstreamPropertyPrefix); name != key && name != "" && value != "" {
+			builds = append(builds, Build{Name: name, Number: value})
+		}
+	}
+	sort.Slice(builds, func(i, j int) bool {
+		return builds[i].Name < builds[j].Name
+	})
+	return builds
+}
+
+func getPublishedBuildInfo(servicesManager artifactory.ArtifactoryServicesManager, build Build, project string) (*buildinfo.BuildInfo, error) {
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: build.Name, BuildNumber: build.Number, ProjectKey: project})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckErrorf("build %s was not found in Artifactory", build)
+	}
+	return &publishedBuildInfo.BuildInfo, nil
+}
@@ -0,0 +1,113 @@
+package upstream
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBuilds(t *testing.T) {
+	builds, err := ParseBuilds("stage/build/12; other/LATEST;")
+	assert.NoError(t, err)
+	assert.Equal(t, []Build{{Name: "stage/build", Number: "12"}, {Name: "other", Number: "LATEST"}}, builds)
+	for _, references := range []string{"", "name", "/12", "name/", "a/1;b"} {
+		_, err = ParseBuilds(references)
+		assert.Error(t, err, references)
+	}
+}
+
+func TestGetUpstreamBuilds(t *testing.T) {
+	buildInfo := &buildinfo.BuildInfo{Properties: buildinfo.Env{
+		"buildInfo.upstream.b":   "2",
+		"buildInfo.upstream.a/x": "1",
+		"buildInfo.env.PATH":     "/bin",
+		"buildInfo.upstream.":    "3",
+	}}
+	assert.Equal(t, []Build{{Name: "a/x", Number: "1"}, {Name: "b", Number: "2"}}, GetUpstreamBuilds(buildInfo))
+}
+
+// Serves the published builds, and records the promoted builds.
+func createServer(t *testing.T, builds map[string]buildinfo.Env, promoted *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/api/build/promote/") {
+			*promoted = append(*promoted, strings.TrimPrefix(r.URL.Path, "/api/build/promote/"))
+			_, _ = w.Write([]byte("{}"))
+			return
+		}
+		reference := strings.TrimPrefix(r.URL.Path, "/api/build/")
+		properties, ok := builds[reference]
+		if r.Method != http.MethodGet || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		separatorIndex := strings.LastIndex(reference, "/")
+		content, err := json.Marshal(buildinfo.PublishedBuildInfo{BuildInfo: buildinfo.BuildInfo{Name: reference[:separatorIndex],
+			Number: reference[separatorIndex+1:], Properties: properties}})
+		require.NoError(t, err)
+		_, _ = w.Write(content)
+	}))
+}
+
+func TestBuildPromoteChain(t *testing.T) {
+	var promoted []string
+	server := createServer(t, map[string]buildinfo.Env{
+		"deploy/3": {"buildInfo.upstream.test": "2", "buildInfo.upstream.compile": "1"},
+		"test/2":   {"buildInfo.upstream.compile": "1"},
+		// A cycle, which is promoted once.
+		"compile/1": {"buildInfo.upstream.deploy": "3"},
+	}, &promoted)
+	defer server.Close()
+
+	promoteCommand := NewBuildPromoteChainCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).
+		SetBuildConfiguration(utils.NewBuildConfiguration("deploy", "3", "", "")).
+		SetPromotionParams(services.PromotionParams{TargetRepo: "release", Status: "released"})
+	require.NoError(t, promoteCommand.Run())
+	assert.Equal(t, []string{"deploy/3", "compile/1", "test/2"}, promoted)
+	assert.Equal(t, []Build{{"deploy", "3"}, {"compile", "1"}, {"test", "2"}}, promoteCommand.PromotedBuilds())
+}
+
+func TestBuildPromoteChainMissingUpstream(t *testing.T) {
+	var promoted []string
+	server := createServer(t, map[string]buildinfo.Env{"deploy/3": {"buildInfo.upstream.test": "2"}}, &promoted)
+	defer server.Close()
+
+	promoteCommand := NewBuildPromoteChainCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).
+		SetBuildConfiguration(utils.NewBuildConfiguration("deploy", "3", "", ""))
+	assert.ErrorContains(t, promoteCommand.Run(), "build test/2 was not found")
+	// Nothing is promoted if the chain can't be resolved.
+	assert.Empty(t, promoted)
+}
+
+func TestBuildAddUpstream(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	var promoted []string
+	server := createServer(t, map[string]buildinfo.Env{"compile/1": nil}, &promoted)
+	defer server.Close()
+
+	buildConfiguration := utils.NewBuildConfiguration("deploy", "3", "", "")
+	addCommand := NewBuildAddUpstreamCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).
+		SetBuildConfiguration(buildConfiguration).SetUpstreamBuilds([]Build{{Name: "compile", Number: "1"}})
+	require.NoError(t, addCommand.Run())
+	defer func() {
+		assert.NoError(t, utils.RemoveBuildDir("deploy", "3", ""))
+	}()
+	partials, err := utils.ReadPartialBuildInfoFiles("deploy", "3", "")
+	require.NoError(t, err)
+	require.Len(t, partials, 1)
+	assert.Equal(t, buildinfo.Env{"buildInfo.upstream.compile": "1"}, partials[0].Env)
+
+	addCommand.SetUpstreamBuilds([]Build{{Name: "missing", Number: "1"}})
+	assert.ErrorContains(t, addCommand.Run(), "build missing/1 was not found")
+	addCommand.SetUpstreamBuilds([]Build{{Name: "deploy", Number: "2"}})
+	assert.ErrorContains(t, addCommand.Run(), "same name")
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The output format of a command.
+type Format string
+
+const (
+	Table Format = "table"
+	Json  Format = "json"
+	Jsonl Format = "jsonl"
+	Csv   Format = "csv"
+)
+
+// Returns the output format, which should be one of the formats supported by the command.
+// If no formats are given, the table and JSON formats are supported. An empty format is the first supported format.
+func GetFormat(format string, supportedFormats ...Format) (Format, error) {
+	if len(supportedFormats) == 0 {
+		supportedFormats = []Format{Table, Json}
+	}
+	if format == "" {
+		return supportedFormats[0], nil
+	}
+	for _, supportedFormat := range supportedFormats {
+		if Format(format) == supportedFormat {
+			return supportedFormat, nil
+		}
+	}
+	return "", errorutils.CheckErrorf("unsupported output format '%s'. Possible values are: %s", format, joinFormats(supportedFormats))
+}
+
+// Joins the formats in the form of "a, b and c".
+func joinFormats(formats []Format) string {
+	names := make([]string, len(formats))
+	for i, format := range formats {
+		names[i] = string(format)
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// Prints the value as indented JSON.
+func PrintJson(value interface{}) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(string(content))
+	return nil
+}
+
+// Prints the value as indented JSON if the format is JSON, and by printTable otherwise.
+func Print(format Format, value interface{}, printTable func() error) error {
+	if format == Json {
+		return PrintJson(value)
+	}
+	return printTable()
+}
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFormat(t *testing.T) {
+	format, err := GetFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, Table, format)
+
+	format, err = GetFormat("json")
+	assert.NoError(t, err)
+	assert.Equal(t, Json, format)
+
+	_, err = GetFormat("csv")
+	assert.EqualError(t, err, "unsupported output format 'csv'. Possible values are: table and json")
+
+	format, err = GetFormat("csv", Table, Json, Csv)
+	assert.NoError(t, err)
+	assert.Equal(t, Csv, format)
+
+	format, err = GetFormat("", Json, Jsonl)
+	assert.NoError(t, err)
+	assert.Equal(t, Json, format)
+
+	_, err = GetFormat("xml", Table, Json, Csv)
+	assert.EqualError(t, err, "unsupported output format 'xml'. Possible values are: table, json and csv")
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Creates an ID unique to the command, the server and the File Spec, such as the ID of a resumable download journal.
+func CreateSpecId(commandName, serverUrl string, fileSpec *spec.SpecFiles) (string, error) {
+	content, err := json.Marshal(struct {
+		Command   string          `json:"command"`
+		ServerUrl string          `json:"serverUrl"`
+		Spec      *spec.SpecFiles `json:"spec"`
+	}{commandName, serverUrl, fileSpec})
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	checksum := sha1.Sum(content)
+	return hex.EncodeToString(checksum[:]), nil
+}
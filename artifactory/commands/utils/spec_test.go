@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSpecId(t *testing.T) {
+	firstSpec := spec.NewBuilder().Pattern("repo/a/*").Target("a/").BuildSpec()
+	secondSpec := spec.NewBuilder().Pattern("repo/b/*").Target("a/").BuildSpec()
+
+	firstId, err := CreateSpecId("rt_download_resume", "https://acme.jfrog.io/artifactory/", firstSpec)
+	assert.NoError(t, err)
+	sameId, err := CreateSpecId("rt_download_resume", "https://acme.jfrog.io/artifactory/", firstSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, firstId, sameId)
+
+	for _, otherId := range []func() (string, error){
+		func() (string, error) {
+			return CreateSpecId("rt_download_resume", "https://acme.jfrog.io/artifactory/", secondSpec)
+		},
+		func() (string, error) {
+			return CreateSpecId("rt_upload_resume", "https://acme.jfrog.io/artifactory/", firstSpec)
+		},
+		func() (string, error) {
+			return CreateSpecId("rt_download_resume", "https://other.jfrog.io/artifactory/", firstSpec)
+		},
+	} {
+		id, err := otherId()
+		assert.NoError(t, err)
+		assert.NotEqual(t, firstId, id)
+	}
+}
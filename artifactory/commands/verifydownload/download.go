@@ -0,0 +1,336 @@
+package verifydownload
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const tasksCapacity = 10000
+
+// Downloads files like generic.DownloadCommand, and verifies them against their detached signatures,
+// or against a signed checksum manifest stored in their folder in Artifactory.
+// The files are downloaded to a staging directory, and are moved to their local paths only if all of them were verified.
+type VerifiedDownloadCommand struct {
+	*generic.DownloadCommand
+	verifier           Verifier
+	manifestName       string
+	retries            int
+	retryWaitMilliSecs int
+	servicesManager    artifactory.ArtifactoryServicesManager
+	manifests          map[string]*manifestResult
+	manifestsMutex     sync.Mutex
+}
+
+// A file downloaded to the staging directory.
+type stagedFile struct {
+	clientutils.FileTransferDetails
+	localPath string
+}
+
+type manifestResult struct {
+	manifest checksumManifest
+	err      error
+}
+
+func NewVerifiedDownloadCommand(downloadCommand *generic.DownloadCommand) *VerifiedDownloadCommand {
+	return &VerifiedDownloadCommand{DownloadCommand: downloadCommand, manifests: make(map[string]*manifestResult)}
+}
+
+func (vdc *VerifiedDownloadCommand) SetVerifier(verifier Verifier) *VerifiedDownloadCommand {
+	vdc.verifier = verifier
+	return vdc
+}
+
+// Sets the name of the checksum manifest. If set, the files are verified against the manifest in their folder,
+// and only the manifest is verified against its signature.
+func (vdc *VerifiedDownloadCommand) SetManifestName(manifestName string) *VerifiedDownloadCommand {
+	vdc.manifestName = manifestName
+	return vdc
+}
+
+func (vdc *VerifiedDownloadCommand) SetVerificationRetries(retries, retryWaitMilliSecs int) *VerifiedDownloadCommand {
+	vdc.retries, vdc.retryWaitMilliSecs = retries, retryWaitMilliSecs
+	return vdc
+}
+
+func (vdc *VerifiedDownloadCommand) Run() (err error) {
+	if vdc.DryRun() {
+		return vdc.DownloadCommand.Run()
+	}
+	stagingDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(stagingDir))
+	}()
+	originalSpec, detailedSummary := vdc.Spec(), vdc.DetailedSummary()
+	stagedSpec, volumes, err := createStagedSpec(originalSpec, stagingDir)
+	if err != nil {
+		return
+	}
+	// The detailed summary provides the local paths of the downloaded files.
+	vdc.SetSpec(stagedSpec).SetDetailedSummary(true)
+	defer func() {
+		vdc.SetSpec(originalSpec).SetDetailedSummary(detailedSummary)
+	}()
+	err = vdc.DownloadCommand.Run()
+	files, readErr := readStagedFiles(vdc.Result(), stagingDir, volumes)
+	if err = errors.Join(err, readErr); err != nil {
+		return
+	}
+	serverDetails, err := vdc.ServerDetails()
+	if err != nil {
+		return
+	}
+	if vdc.servicesManager, err = utils.CreateServiceManager(serverDetails, vdc.retries, vdc.retryWaitMilliSecs, false); err != nil {
+		return
+	}
+	if err = vdc.verifyFiles(files); err != nil {
+		vdc.Result().SetFailCount(vdc.Result().FailCount() + vdc.Result().SuccessCount())
+		vdc.Result().SetSuccessCount(0)
+		return
+	}
+	return moveFiles(files, vdc.Result(), detailedSummary)
+}
+
+// Creates a copy of the spec, which downloads the files to the staging directory instead of their local paths.
+// The targets are made absolute, and each spec file gets its own staging directory, so that the local path of
+// a staged file is its path relative to the directory of its spec file, prefixed by the volume of the original target.
+func createStagedSpec(originalSpec *spec.SpecFiles, stagingDir string) (*spec.SpecFiles, []string, error) {
+	stagedSpec := &spec.SpecFiles{Files: make([]spec.File, len(originalSpec.Files))}
+	volumes := make([]string, len(originalSpec.Files))
+	for i, file := range originalSpec.Files {
+		isDir := file.Target == "" || strings.HasSuffix(file.Target, "/") || strings.HasSuffix(file.Target, "\\")
+		absTarget, err := filepath.Abs(file.Target)
+		if err != nil {
+			return nil, nil, errorutils.CheckError(err)
+		}
+		volumes[i] = filepath.VolumeName(absTarget)
+		stagedTarget := filepath.Join(stagingDir, strconv.Itoa(i), strings.TrimPrefix(absTarget, volumes[i]))
+		file.Target = filepath.ToSlash(stagedTarget)
+		if isDir {
+			file.Target += "/"
+		}
+		stagedSpec.Files[i] = file
+	}
+	return stagedSpec, volumes, nil
+}
+
+// Reads the downloaded files from the detailed summary of the download, and resolves their local paths.
+func readStagedFiles(result *commandsutils.Result, stagingDir string, volumes []string) (files []stagedFile, err error) {
+	reader := result.Reader()
+	if reader == nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+		result.SetReader(nil)
+	}()
+	for details := new(clientutils.FileTransferDetails); reader.NextRecord(details) == nil; details = new(clientutils.FileTransferDetails) {
+		relativePath, err := filepath.Rel(stagingDir, details.TargetPath)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		specIndex, pathInVolume, _ := strings.Cut(relativePath, string(filepath.Separator))
+		index, err := strconv.Atoi(specIndex)
+		if err != nil || index < 0 || index >= len(volumes) {
+			return nil, errorutils.CheckErrorf("unexpected local path of a downloaded file: %s", details.TargetPath)
+		}
+		files = append(files, stagedFile{FileTransferDetails: *details, localPath: volumes[index] + string(filepath.Separator) + pathInVolume})
+	}
+	return files, reader.GetError()
+}
+
+// Verifies all the downloaded files, and returns an error if any of them failed the verification.
+// Signatures and checksum manifests, which are downloaded when their folder is downloaded, aren't verified themselves.
+func (vdc *VerifiedDownloadCommand) verifyFiles(files []stagedFile) error {
+	var payloadFiles []stagedFile
+	for _, file := range files {
+		if !vdc.isVerificationFile(file.SourcePath) {
+			payloadFiles = append(payloadFiles, file)
+		}
+	}
+	log.Info("Verifying", strconv.Itoa(len(payloadFiles)), "downloaded files...")
+	failed := 0
+	var failedMutex sync.Mutex
+	producerConsumer := parallel.NewRunner(vdc.Configuration().Threads, tasksCapacity, false)
+	go func() {
+		defer producerConsumer.Done()
+		for _, file := range payloadFiles {
+			file := file
+			_, _ = producerConsumer.AddTask(func(int) error {
+				if err := vdc.verifyFile(&file); err != nil {
+					log.Error("Verification failed for", file.SourcePath+":", err.Error())
+					failedMutex.Lock()
+					failed++
+					failedMutex.Unlock()
+				}
+				return nil
+			})
+		}
+	}()
+	producerConsumer.Run()
+	if failed > 0 {
+		return errorutils.CheckErrorf("%d of %d downloaded files failed the verification. None of the files were moved to their local paths", failed, len(payloadFiles))
+	}
+	return nil
+}
+
+// Returns true if the file is a signature or a checksum manifest, used to verify the other files.
+func (vdc *VerifiedDownloadCommand) isVerificationFile(sourcePath string) bool {
+	if vdc.manifestName != "" && path.Base(sourcePath) == vdc.manifestName {
+		return true
+	}
+	for _, suffix := range vdc.verifier.SignatureSuffixes() {
+		if strings.HasSuffix(sourcePath, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (vdc *VerifiedDownloadCommand) verifyFile(file *stagedFile) (err error) {
+	if vdc.manifestName != "" {
+		manifest, err := vdc.getManifest(path.Dir(file.SourcePath))
+		if err != nil {
+			return err
+		}
+		details, err := fileutils.GetFileDetails(file.TargetPath, true)
+		if err != nil {
+			return err
+		}
+		return manifest.verify(path.Base(file.SourcePath), details.Checksum.Sha256)
+	}
+	signature, err := vdc.downloadSignature(file.SourcePath)
+	if err != nil {
+		return
+	}
+	content, err := os.Open(file.TargetPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(content.Close()))
+	}()
+	return vdc.verifier.Verify(content, signature)
+}
+
+// Downloads and verifies the checksum manifest of the folder. The result is cached, so each manifest is downloaded once.
+func (vdc *VerifiedDownloadCommand) getManifest(folder string) (checksumManifest, error) {
+	vdc.manifestsMutex.Lock()
+	defer vdc.manifestsMutex.Unlock()
+	if result, ok := vdc.manifests[folder]; ok {
+		return result.manifest, result.err
+	}
+	result := new(manifestResult)
+	result.manifest, result.err = vdc.downloadManifest(path.Join(folder, vdc.manifestName))
+	vdc.manifests[folder] = result
+	return result.manifest, result.err
+}
+
+func (vdc *VerifiedDownloadCommand) downloadManifest(manifestPath string) (checksumManifest, error) {
+	content, found, err := vdc.downloadContent(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckErrorf("the checksum manifest '%s' was not found", manifestPath)
+	}
+	signature, err := vdc.downloadSignature(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = vdc.verifier.Verify(bytes.NewReader(content), signature); err != nil {
+		return nil, errorutils.CheckErrorf("the checksum manifest '%s' failed the verification: %s", manifestPath, err.Error())
+	}
+	return parseChecksumManifest(content)
+}
+
+// Downloads the signature stored next to the file, trying each of the signature suffixes of the verifier.
+func (vdc *VerifiedDownloadCommand) downloadSignature(signedPath string) ([]byte, error) {
+	suffixes := vdc.verifier.SignatureSuffixes()
+	for _, suffix := range suffixes {
+		signature, found, err := vdc.downloadContent(signedPath + suffix)
+		if err != nil || found {
+			return signature, err
+		}
+	}
+	return nil, errorutils.CheckErrorf("no signature was found for '%s'. Looked for '%s'", signedPath, signedPath+strings.Join(suffixes, "' and '"+signedPath))
+}
+
+func (vdc *VerifiedDownloadCommand) downloadContent(artifactPath string) (body []byte, found bool, err error) {
+	artifactUrl, err := servicesutils.BuildArtifactoryUrl(vdc.servicesManager.GetConfig().GetServiceDetails().GetUrl(), artifactPath, make(map[string]string))
+	if err != nil {
+		return
+	}
+	httpClientDetails := vdc.servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, _, err := vdc.servicesManager.Client().SendGet(artifactUrl, true, &httpClientDetails)
+	if err != nil {
+		return
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return
+	}
+	return body, true, nil
+}
+
+// Moves the verified files from the staging directory to their local paths.
+// If a detailed summary was requested, it's replaced by a summary with the local paths.
+func moveFiles(files []stagedFile, result *commandsutils.Result, detailedSummary bool) (err error) {
+	var resultsWriter *content.ContentWriter
+	if detailedSummary {
+		if resultsWriter, err = content.NewContentWriter(content.DefaultKey, true, false); err != nil {
+			return
+		}
+		defer func() {
+			err = errors.Join(err, resultsWriter.Close())
+			result.SetReader(content.NewContentReader(resultsWriter.GetFilePath(), content.DefaultKey))
+		}()
+	}
+	for _, file := range files {
+		if err = moveFile(file.TargetPath, file.localPath); err != nil {
+			return
+		}
+		if resultsWriter != nil {
+			details := file.FileTransferDetails
+			details.TargetPath = file.localPath
+			resultsWriter.Write(details)
+		}
+	}
+	return
+}
+
+func moveFile(sourcePath, destPath string) error {
+	if err := os.MkdirAll(filepath.Dir(destPath), os.ModePerm); err != nil {
+		return errorutils.CheckError(err)
+	}
+	// Renaming fails if the staging directory is on a different device, so the file is copied instead.
+	if os.Rename(sourcePath, destPath) == nil {
+		return nil
+	}
+	return fileutils.MoveFile(sourcePath, destPath)
+}
@@ -0,0 +1,45 @@
+package verifydownload
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// A checksum manifest lists the SHA-256 checksums of the files in its folder, in the format of the sha256sum tool:
+// a checksum, followed by a space and a file name, which may be prefixed by '*' (binary mode) or './'.
+// Empty lines and lines starting with '#' are ignored.
+type checksumManifest map[string]string
+
+func parseChecksumManifest(content []byte) (checksumManifest, error) {
+	manifest := make(checksumManifest)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		checksum, name, found := strings.Cut(line, " ")
+		name = strings.TrimPrefix(strings.TrimPrefix(strings.TrimLeft(name, " "), "*"), "./")
+		if decoded, err := hex.DecodeString(checksum); !found || name == "" || err != nil || len(decoded) != 32 {
+			return nil, errorutils.CheckErrorf("line %d of the checksum manifest is not in the format '<sha256> <file name>'", lineNumber)
+		}
+		manifest[name] = strings.ToLower(checksum)
+	}
+	return manifest, errorutils.CheckError(scanner.Err())
+}
+
+// Returns an error if the file isn't listed in the manifest, or is listed with a different checksum.
+func (cm checksumManifest) verify(name, sha256 string) error {
+	expected, ok := cm[name]
+	if !ok {
+		return errorutils.CheckErrorf("'%s' is not listed in the checksum manifest", name)
+	}
+	if !strings.EqualFold(expected, sha256) {
+		return errorutils.CheckErrorf("the SHA-256 checksum of '%s' (%s) doesn't match the checksum in the manifest (%s)", name, sha256, expected)
+	}
+	return nil
+}
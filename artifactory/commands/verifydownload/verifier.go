@@ -0,0 +1,135 @@
+package verifydownload
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"os"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	pgpPublicKeyBlock = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+	pgpSignatureBlock = "-----BEGIN PGP SIGNATURE-----"
+	pemPublicKeyBlock = "PUBLIC KEY"
+)
+
+// Verifies detached signatures of files.
+type Verifier interface {
+	// Returns an error if the signature isn't a valid signature of the content.
+	Verify(content io.Reader, signature []byte) error
+	// The suffixes of the signature files stored next to the signed files, in the order they should be looked for.
+	SignatureSuffixes() []string
+}
+
+// Loads a verifier from a public key file.
+// The file may contain a GPG public key, armored or binary, or a PEM encoded public key as used by cosign.
+func LoadVerifier(keyPath string) (Verifier, error) {
+	key, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if block, _ := pem.Decode(key); block != nil && block.Type == pemPublicKeyBlock {
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errorutils.CheckErrorf("failed parsing the public key in '%s': %s", keyPath, err.Error())
+		}
+		switch publicKey.(type) {
+		case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+			return &publicKeyVerifier{publicKey: publicKey}, nil
+		default:
+			return nil, errorutils.CheckErrorf("the public key in '%s' is of an unsupported type", keyPath)
+		}
+	}
+	var keyRing openpgp.EntityList
+	if bytes.Contains(key, []byte(pgpPublicKeyBlock)) {
+		keyRing, err = openpgp.ReadArmoredKeyRing(bytes.NewReader(key))
+	} else {
+		keyRing, err = openpgp.ReadKeyRing(bytes.NewReader(key))
+	}
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed reading '%s' as a GPG public key or a PEM encoded public key: %s", keyPath, err.Error())
+	}
+	return &gpgVerifier{keyRing: keyRing}, nil
+}
+
+// Verifies GPG detached signatures, armored or binary.
+type gpgVerifier struct {
+	keyRing openpgp.EntityList
+}
+
+func (gv *gpgVerifier) Verify(content io.Reader, signature []byte) (err error) {
+	if bytes.Contains(signature, []byte(pgpSignatureBlock)) {
+		_, err = openpgp.CheckArmoredDetachedSignature(gv.keyRing, content, bytes.NewReader(signature), nil)
+	} else {
+		_, err = openpgp.CheckDetachedSignature(gv.keyRing, content, bytes.NewReader(signature), nil)
+	}
+	if err != nil {
+		return errorutils.CheckErrorf("invalid GPG signature: %s", err.Error())
+	}
+	return nil
+}
+
+func (gv *gpgVerifier) SignatureSuffixes() []string {
+	return []string{".asc", ".sig"}
+}
+
+// Verifies signatures created with a private key over the SHA-256 digest of the content, like the signatures
+// created by 'cosign sign-blob'. The signature may be base64 encoded.
+// Ed25519 signatures are created over the content itself.
+type publicKeyVerifier struct {
+	publicKey crypto.PublicKey
+}
+
+func (pkv *publicKeyVerifier) Verify(content io.Reader, signature []byte) error {
+	if decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature))); err == nil {
+		signature = decoded
+	}
+	var valid bool
+	switch publicKey := pkv.publicKey.(type) {
+	case ed25519.PublicKey:
+		message, err := io.ReadAll(content)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		valid = ed25519.Verify(publicKey, message, signature)
+	case *ecdsa.PublicKey:
+		digest, err := sha256Digest(content)
+		if err != nil {
+			return err
+		}
+		valid = ecdsa.VerifyASN1(publicKey, digest, signature)
+	case *rsa.PublicKey:
+		digest, err := sha256Digest(content)
+		if err != nil {
+			return err
+		}
+		valid = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest, signature) == nil ||
+			rsa.VerifyPSS(publicKey, crypto.SHA256, digest, signature, nil) == nil
+	}
+	if !valid {
+		return errorutils.CheckErrorf("invalid signature")
+	}
+	return nil
+}
+
+func (pkv *publicKeyVerifier) SignatureSuffixes() []string {
+	return []string{".sig"}
+}
+
+func sha256Digest(content io.Reader) ([]byte, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return hash.Sum(nil), nil
+}
@@ -0,0 +1,167 @@
+package verifydownload
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var signedContent = []byte("signed content")
+
+func TestPublicKeyVerifier(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	require.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "cosign.pub")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}), 0600))
+	digest := sha256.Sum256(signedContent)
+	signature, err := privateKey.Sign(rand.Reader, digest[:], crypto.SHA256)
+	require.NoError(t, err)
+
+	verifier, err := LoadVerifier(keyPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{".sig"}, verifier.SignatureSuffixes())
+	assert.NoError(t, verifier.Verify(bytes.NewReader(signedContent), signature))
+	assert.NoError(t, verifier.Verify(bytes.NewReader(signedContent), []byte(base64.StdEncoding.EncodeToString(signature)+"\n")))
+	assert.ErrorContains(t, verifier.Verify(strings.NewReader("modified content"), signature), "invalid signature")
+}
+
+func TestGpgVerifier(t *testing.T) {
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	require.NoError(t, err)
+	var publicKey bytes.Buffer
+	armorWriter, err := armor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(armorWriter))
+	require.NoError(t, armorWriter.Close())
+	keyPath := filepath.Join(t.TempDir(), "public.asc")
+	require.NoError(t, os.WriteFile(keyPath, publicKey.Bytes(), 0600))
+	var armoredSignature, binarySignature bytes.Buffer
+	require.NoError(t, openpgp.ArmoredDetachSign(&armoredSignature, entity, bytes.NewReader(signedContent), nil))
+	require.NoError(t, openpgp.DetachSign(&binarySignature, entity, bytes.NewReader(signedContent), nil))
+
+	verifier, err := LoadVerifier(keyPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{".asc", ".sig"}, verifier.SignatureSuffixes())
+	assert.NoError(t, verifier.Verify(bytes.NewReader(signedContent), armoredSignature.Bytes()))
+	assert.NoError(t, verifier.Verify(bytes.NewReader(signedContent), binarySignature.Bytes()))
+	assert.ErrorContains(t, verifier.Verify(strings.NewReader("modified content"), armoredSignature.Bytes()), "invalid GPG signature")
+}
+
+func TestChecksumManifest(t *testing.T) {
+	sha256a := strings.Repeat("a", 64)
+	manifest, err := parseChecksumManifest([]byte("# checksums\n" + sha256a + "  a.bin\n" + strings.Repeat("B", 64) + " *./b.bin\n\n"))
+	require.NoError(t, err)
+	assert.NoError(t, manifest.verify("a.bin", sha256a))
+	assert.NoError(t, manifest.verify("b.bin", strings.Repeat("b", 64)))
+	assert.ErrorContains(t, manifest.verify("a.bin", strings.Repeat("c", 64)), "doesn't match")
+	assert.ErrorContains(t, manifest.verify("c.bin", sha256a), "not listed")
+
+	_, err = parseChecksumManifest([]byte("abc a.bin\n"))
+	assert.ErrorContains(t, err, "line 1")
+}
+
+func TestCreateStagedSpec(t *testing.T) {
+	stagingDir := t.TempDir()
+	workingDir, err := os.Getwd()
+	require.NoError(t, err)
+	originalSpec := &spec.SpecFiles{Files: []spec.File{{Pattern: "repo/a/*", Target: "out/"}, {Pattern: "repo/b.bin", Target: "renamed.bin"}}}
+
+	stagedSpec, volumes, err := createStagedSpec(originalSpec, stagingDir)
+	require.NoError(t, err)
+	volume := filepath.VolumeName(workingDir)
+	assert.Equal(t, []string{volume, volume}, volumes)
+	assert.Equal(t, filepath.ToSlash(filepath.Join(stagingDir, "0", strings.TrimPrefix(workingDir, volume), "out"))+"/", stagedSpec.Files[0].Target)
+	assert.Equal(t, filepath.ToSlash(filepath.Join(stagingDir, "1", strings.TrimPrefix(workingDir, volume), "renamed.bin")), stagedSpec.Files[1].Target)
+	// The original spec is not modified.
+	assert.Equal(t, "out/", originalSpec.Files[0].Target)
+}
+
+func TestVerifiedDownloadFolder(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	require.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "cosign.pub")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}), 0600))
+	sign := func(content []byte) []byte {
+		digest := sha256.Sum256(content)
+		signature, err := privateKey.Sign(rand.Reader, digest[:], crypto.SHA256)
+		require.NoError(t, err)
+		return signature
+	}
+	contentChecksum := sha256.Sum256(signedContent)
+	manifest := []byte(hex.EncodeToString(contentChecksum[:]) + "  app.bin\n")
+	// The folder contains the payload, a signature of the payload, and the signed checksum manifest.
+	folder := map[string][]byte{
+		"app.bin":        signedContent,
+		"app.bin.sig":    sign(signedContent),
+		"SHA256SUMS":     manifest,
+		"SHA256SUMS.sig": sign(manifest),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/system/version" {
+			_, _ = w.Write([]byte(`{"version":"7.60.0"}`))
+			return
+		}
+		if r.Method == http.MethodPost && r.URL.Path == "/api/search/aql" {
+			var results []map[string]interface{}
+			for name, content := range folder {
+				results = append(results, map[string]interface{}{"repo": "repo", "path": "folder", "name": name, "type": "file", "size": len(content)})
+			}
+			response, err := json.Marshal(map[string]interface{}{"results": results})
+			require.NoError(t, err)
+			_, _ = w.Write(response)
+			return
+		}
+		content, ok := folder[strings.TrimPrefix(r.URL.Path, "/repo/folder/")]
+		if r.Method != http.MethodGet || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	verifier, err := LoadVerifier(keyPath)
+	require.NoError(t, err)
+	target := t.TempDir() + "/"
+	downloadCommand := generic.NewDownloadCommand()
+	downloadCommand.SetConfiguration(&utils.DownloadConfiguration{Threads: 1}).
+		SetBuildConfiguration(utils.NewBuildConfiguration("", "", "", "")).
+		SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).
+		SetSpec(&spec.SpecFiles{Files: []spec.File{{Pattern: "repo/folder/", Target: target, Flat: "true"}}})
+	verifiedDownloadCommand := NewVerifiedDownloadCommand(downloadCommand).SetVerifier(verifier).SetManifestName("SHA256SUMS")
+	require.NoError(t, verifiedDownloadCommand.Run())
+	// The signatures and the manifest aren't verified themselves, but are downloaded with the rest of the folder.
+	assert.Equal(t, len(folder), verifiedDownloadCommand.Result().SuccessCount())
+	for name, content := range folder {
+		downloaded, err := os.ReadFile(filepath.Join(target, name))
+		require.NoError(t, err)
+		assert.Equal(t, content, downloaded)
+	}
+}
@@ -0,0 +1,23 @@
+package archiveget
+
+var Usage = []string{"rt archive get [command options] <archive path>!<entry> [local path]"}
+
+func GetDescription() string {
+	return "Extract a single file from a zip archive in Artifactory, without downloading the whole archive."
+}
+
+func GetArguments() string {
+	return `	archive path
+		Path to a zip archive in Artifactory, in the following format: <repository name>/<repository path>.
+		Archives in formats based on zip, such as jar, war and ear, are supported as well.
+
+	entry
+		The path of the file inside the archive. Only the central directory of the archive and the compressed content
+		of the entry are downloaded, using HTTP range requests. The extracted content is verified against the entry's checksum.
+		For example: repo/libs/app.jar!META-INF/MANIFEST.MF
+
+	local path
+		[Optional] The local path to extract the file to. If the path is an existing directory or ends with a slash,
+		the file is extracted into it by its name. Use - to write the file to the standard output.
+		If not specified, the file is extracted to the current directory.`
+}
@@ -0,0 +1,18 @@
+package archivels
+
+var Usage = []string{"rt archive ls [command options] <archive path>[!<entries pattern>]"}
+
+func GetDescription() string {
+	return "List the entries of a zip archive in Artifactory, without downloading the archive."
+}
+
+func GetArguments() string {
+	return `	archive path
+		Path to a zip archive in Artifactory, in the following format: <repository name>/<repository path>.
+		Archives in formats based on zip, such as jar, war and ear, are supported as well.
+		Only the central directory at the end of the archive is downloaded, using HTTP range requests.
+
+	entries pattern
+		[Optional] Lists only the entries matching the pattern. The pattern may include wildcards.
+		For example: repo/libs/app.jar!META-INF/*`
+}
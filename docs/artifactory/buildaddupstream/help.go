@@ -0,0 +1,15 @@
+package buildaddupstream
+
+var Usage = []string{"rt bau [command options] <build name> <build number>"}
+
+func GetDescription() string {
+	return "Record published upstream builds, such as the builds of previous pipeline stages, in the build info. The build-promote command can then promote the build together with its upstream builds."
+}
+
+func GetArguments() string {
+	return `	build name
+		The current build name.
+
+	build number
+		The current build number.`
+}
@@ -0,0 +1,23 @@
+package builddiff
+
+var Usage = []string{"rt build-diff [command options] <build name> <build number 1> <build number 2>",
+	"rt build-diff [command options] <build name 1> <build number 1> <build name 2> <build number 2>"}
+
+func GetDescription() string {
+	return "List the modules, artifacts, dependencies, environment variables and VCS revisions which changed between two published builds."
+}
+
+func GetArguments() string {
+	return `	build name
+		Build name of both builds.
+
+	build number 1
+		Build number of the first build. Use LATEST for the latest build.
+
+	build name 2
+		Build name of the second build, if it differs from the name of the first build.
+
+	build number 2
+		Build number of the second build. Use LATEST for the latest build.
+		Modules and dependencies are matched by their IDs without the versions, so that a new version of a module or a dependency is shown as a version change.`
+}
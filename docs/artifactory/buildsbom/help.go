@@ -0,0 +1,15 @@
+package buildsbom
+
+var Usage = []string{"rt build-sbom [command options] <build name> <build number>"}
+
+func GetDescription() string {
+	return "Create a CycloneDX or SPDX SBOM of a published build, from the modules, artifacts and dependencies in its build info."
+}
+
+func GetArguments() string {
+	return `	build name
+		Build name.
+
+	build number
+		Build number. Use LATEST for the latest build.`
+}
@@ -0,0 +1,15 @@
+package buildshow
+
+var Usage = []string{"rt build-show [command options] <build name> <build number>"}
+
+func GetDescription() string {
+	return "Show the build info collected locally for a build, as it would be published by the build-publish command."
+}
+
+func GetArguments() string {
+	return `	build name
+		Build name.
+
+	build number
+		Build number.`
+}
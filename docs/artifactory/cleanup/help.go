@@ -0,0 +1,36 @@
+package cleanup
+
+import "github.com/jfrog/jfrog-cli/docs/common"
+
+var Usage = []string{"rt cleanup --policy=<policy path> [command options]"}
+
+const EnvVar string = common.JfrogCliFailNoOp
+
+func GetDescription() string {
+	return "Delete files according to a retention policy."
+}
+
+func GetArguments() string {
+	return `	policy
+		A YAML file with a list of rules. Each rule selects the files matching a pattern in its repositories, and deletes the files which
+		meet all of its criteria and aren't protected. A report of the files to delete is printed before they are deleted. For example:
+			rules:
+			  - name: snapshots
+			    repos: [libs-snapshot-local]
+			    pattern: com/acme/*
+			    keepLastVersions: 5
+			    notDownloadedInDays: 90
+			    protect:
+			      properties:
+			        release: "true"
+			      builds: true
+
+		keepLastVersions
+			Keeps the files of the last N versions of each artifact. The version of a file is the folder containing it,
+			and the artifact is the parent folder of the version. The versions are ordered by the creation time of their newest file.
+		notDownloadedInDays
+			Keeps the files which were downloaded, or created if never downloaded, in the last N days.
+		protect
+			Keeps the files with any of the properties, where a value of "*" matches any value,
+			and if builds is true, the files which are artifacts or dependencies of a build.`
+}
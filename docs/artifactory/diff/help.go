@@ -0,0 +1,19 @@
+package diff
+
+var Usage = []string{"rt diff [command options] <source pattern> <target pattern>",
+	"rt diff --source-build=<build name>/<build number> --target-build=<build name>/<build number> [command options]"}
+
+func GetDescription() string {
+	return "List the artifacts added, removed or changed between two repository paths or two builds."
+}
+
+func GetArguments() string {
+	return `	source pattern
+		Specifies the source path in Artifactory, in the following format: <repository name>/<repository path>. You can use wildcards to specify multiple artifacts.
+		Artifacts are compared by their path relative to the pattern's base path. Can be omitted if the --source-build option is used.
+
+	target pattern
+		Specifies the target path in Artifactory, in the following format: <repository name>/<repository path>. You can use wildcards to specify multiple artifacts.
+		Artifacts are compared by their path relative to the pattern's base path. Can be omitted if the --target-build option is used.
+		The artifacts of builds are compared by their names. Artifacts whose name is shared by other artifacts of the same build are compared by their modules and names.`
+}
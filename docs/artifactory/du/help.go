@@ -0,0 +1,13 @@
+package du
+
+var Usage = []string{"rt du [command options] <path>"}
+
+func GetDescription() string {
+	return "Report the total size and number of files of a repository path and of its sub-folders, sorted by size."
+}
+
+func GetArguments() string {
+	return `	path
+		Path of a folder or a repository in Artifactory, in the following format: <repository name>/<repository path>.
+		The totals of each folder include all the files in its sub-folders, also below the reported depth.`
+}
@@ -0,0 +1,14 @@
+package ls
+
+var Usage = []string{"rt ls [command options] <path>"}
+
+func GetDescription() string {
+	return "List the files and folders in a repository path, like the ls command of the local file system."
+}
+
+func GetArguments() string {
+	return `	path
+		Path in Artifactory, in the following format: <repository name>/<repository path>.
+		If the path is a file, only the file is listed. Folders are listed with a trailing slash.
+		Huge folders are listed page by page, and the output starts before the whole folder is fetched.`
+}
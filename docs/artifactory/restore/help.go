@@ -0,0 +1,19 @@
+package restore
+
+import "github.com/jfrog/jfrog-cli/docs/common"
+
+var Usage = []string{"rt restore [command options] <operation ID>"}
+
+const EnvVar string = common.JfrogCliFailNoOp
+
+func GetDescription() string {
+	return "Restore the artifacts of a delete or move operation, which was performed with the --backup-to option."
+}
+
+func GetArguments() string {
+	return `	operation ID
+		The ID of the operation, printed by the delete or move command.
+		Deleted artifacts are moved back from the backup repository to their original paths.
+		Moved artifacts are moved back from their target paths, or from the backup repository if they no longer exist in their target paths.
+		The recorded properties of the artifacts are set on them after they are restored.`
+}
@@ -3,7 +3,8 @@ package setprops
 import "github.com/jfrog/jfrog-cli/docs/common"
 
 var Usage = []string{"rt sp [command options] <artifacts pattern> <artifact properties>",
-	"rt sp <artifact properties> --spec=<File Spec path> [command options]"}
+	"rt sp <artifact properties> --spec=<File Spec path> [command options]",
+	"rt sp --manifest=<manifest path> [command options]"}
 
 const EnvVar string = common.JfrogCliFailNoOp
 
@@ -16,5 +17,15 @@ func GetArguments() string {
 		Artifacts that match the pattern will be set with the specified properties.
 
 	artifact properties
-		The list of properties, in the form of key1=value1;key2=value2,..., to be set on the matching artifacts.`
+		The list of properties, in the form of key1=value1;key2=value2,..., to be set on the matching artifacts.
+
+	manifest
+		A CSV or JSON file, setting different properties on specific artifacts.
+		The header of a CSV manifest contains a single target column, named path, sha1 or sha256, and the other columns are property keys.
+		Empty cells are skipped, and multiple values in a cell are separated by commas. For example:
+			path,triage.status,triage.owner
+			libs-release/app/1.0/app.jar,ignored,security-team
+		A JSON manifest is an array of objects, each with a path, sha1 or sha256 field, and a props object. For example:
+			[{"sha256": "<checksum>", "props": {"triage.status": "fixed", "cve": ["CVE-2023-1", "CVE-2023-2"]}}]
+		Rows with a checksum set the properties on all the artifacts with that checksum.`
 }
@@ -0,0 +1,17 @@
+package speclint
+
+var Usage = []string{"rt spec lint <spec path>... [command options]"}
+
+func GetDescription() string {
+	return "Validate File Specs against the File Spec schema, and check them for options which can't be used together."
+}
+
+func GetArguments() string {
+	return `	spec path
+		Paths to File Specs. A File Spec template or a YAML File Spec is rendered before it is checked.
+		In addition to the validation of 'jf rt spec validate', the file groups are checked for options which conflict,
+		such as 'aql' and 'pattern', options which require other options, such as 'sortOrder' without 'sortBy', invalid regular
+		expressions and target placeholders without matching parentheses in the pattern. Options which are used together
+		with no effect, such as 'flat' and 'explode', are reported as warnings.
+		The command fails if any of the File Specs has errors. Warnings don't fail the command.`
+}
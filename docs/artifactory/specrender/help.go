@@ -0,0 +1,22 @@
+package specrender
+
+var Usage = []string{"rt spec render <spec path> [command options]"}
+
+func GetDescription() string {
+	return "Print the File Spec as it is used by the commands, after expanding its template, includes and YAML format."
+}
+
+func GetArguments() string {
+	return `	spec path
+		Path to a File Spec. The File Spec may be a Go template, which is rendered with the spec vars as its data, and may be
+		written in YAML, if its extension is .yaml or .yml. The expanded File Spec is validated against the File Spec schema.
+		In addition to the built-in functions of Go templates, the following functions are available:
+
+		include
+			Renders another template, with a path relative to the including template. For example: {{ include "files.json" }}
+		split
+			Splits a list of values. For example: {{ range $i, $repo := split .repos "," }}{{ if $i }},{{ end }}...{{ end }}
+		join, quote, default, dict and indent
+			Join a list, quote a value as a JSON string, provide a default value, pass several values to an included template
+			and indent the lines of an included YAML template.`
+}
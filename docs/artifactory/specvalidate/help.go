@@ -0,0 +1,14 @@
+package specvalidate
+
+var Usage = []string{"rt spec validate <spec path>... [command options]"}
+
+func GetDescription() string {
+	return "Validate File Specs against the File Spec schema."
+}
+
+func GetArguments() string {
+	return `	spec path
+		Paths to File Specs. A File Spec template or a YAML File Spec is rendered before it is validated.
+		Each problem is reported with its line and column in the File Spec. For a File Spec template, the line and column
+		refer to the rendered template. The command fails if any of the File Specs has errors.`
+}
@@ -0,0 +1,14 @@
+package stat
+
+var Usage = []string{"rt stat [command options] <path>"}
+
+func GetDescription() string {
+	return "Show the metadata of an artifact: its checksums, properties, download statistics, the builds which produced and consumed it, and its Xray summary."
+}
+
+func GetArguments() string {
+	return `	path
+		Path to an artifact in Artifactory, in the following format: <repository name>/<repository path>.
+		If the path is a folder, only its storage details and properties are shown.
+		The Xray summary is shown if the Xray URL of the server is configured.`
+}
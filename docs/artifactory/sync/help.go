@@ -0,0 +1,21 @@
+package sync
+
+import "github.com/jfrog/jfrog-cli/docs/common"
+
+var Usage = []string{"rt sync [command options] <local directory> <repository path>"}
+
+var EnvVar = []string{common.JfrogCliFailNoOp, common.JfrogCliMaxBandwidth}
+
+func GetDescription() string {
+	return "Synchronize a local directory and a repository path in both directions."
+}
+
+func GetArguments() string {
+	return `	local directory
+		Specifies the local directory to synchronize.
+
+	repository path
+		Specifies the path in Artifactory to synchronize, in the following format: <repository name>/<repository path>.
+		Files which exist only locally are uploaded to this path, and files which exist only in Artifactory are downloaded to the local directory.
+		Files which exist on both sides with different checksums are handled according to the --policy option.`
+}
@@ -0,0 +1,13 @@
+package tree
+
+var Usage = []string{"rt tree [command options] <path>"}
+
+func GetDescription() string {
+	return "Print the files and folders under a repository path as a tree, with the total size and number of files of each folder."
+}
+
+func GetArguments() string {
+	return `	path
+		Path of a folder or a repository in Artifactory, in the following format: <repository name>/<repository path>.
+		The totals of each folder include the files below the depth limit.`
+}
@@ -5,7 +5,7 @@ import "github.com/jfrog/jfrog-cli/docs/common"
 var Usage = []string{"rt u [command options] <source pattern> <target pattern>",
 	"rt u --spec=<File Spec path> [command options]"}
 
-var EnvVar = []string{common.JfrogCliMinChecksumDeploySizeKb, common.JfrogCliFailNoOp}
+var EnvVar = []string{common.JfrogCliMinChecksumDeploySizeKb, common.JfrogCliFailNoOp, common.JfrogCliMaxBandwidth}
 
 func GetDescription() string {
 	return "Upload files."
@@ -16,6 +16,8 @@ func GetArguments() string {
 		Specifies the local file system path to artifacts which should be uploaded to Artifactory.
 		You can specify multiple artifacts by using wildcards or a regular expression as designated by the --regexp command option.
 		If you have specified that you are using regular expressions, then the first one used in the argument must be enclosed in parenthesis.
+		Set to "-" to stream the standard input to Artifactory. The target path must then be a file path, for example:
+		"tar czf - dir | jf rt u - repo-name/a/dir.tar.gz". The standard input can't be read again, so its upload is never retried.
 
 	target pattern
 		Specifies the target path in Artifactory in the following format: <repository name>/<repository path>.
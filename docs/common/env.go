@@ -75,6 +75,22 @@ const (
 		Minimum file size in KB for which JFrog CLI performs checksum deploy optimization.
 		Support with upload command`
 
+	JfrogCliDownloadCache = `	JFROG_CLI_DOWNLOAD_CACHE
+		[Default: false]
+		Set to true to keep downloaded files in a cache under $JFROG_CLI_HOME_DIR/cache, keyed by their SHA-256 checksum.
+		Files found in the cache are copied from it instead of being downloaded again.
+		Support by the following commands: download and plugin install`
+
+	JfrogCliDownloadCacheMaxSizeMb = `	JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE_MB
+		[Default: 1024]
+		The maximum size of the download cache in MB. When the cache exceeds this size, the least recently used files are evicted.`
+
+	JfrogCliMaxBandwidth = `	JFROG_CLI_MAX_BANDWIDTH
+		[Default: unlimited]
+		The maximum total transfer rate of a command, such as 20MB/s or 512KB/s.
+		The --limit-rate command option takes precedence over this variable.
+		Support by the following commands: download, upload and sync`
+
 	JfrogCliFailNoOp = `	JFROG_CLI_FAIL_NO_OP
 		[Default: false]
 		Set to true if you'd like the command to return exit code 2 in case of no files are affected.
@@ -119,6 +135,9 @@ func GetGlobalEnvVars() string {
 		JfrogCliReleasesRepo,
 		JfrogCliDependenciesDir,
 		JfrogCliMinChecksumDeploySizeKb,
+		JfrogCliDownloadCache,
+		JfrogCliDownloadCacheMaxSizeMb,
+		JfrogCliMaxBandwidth,
 		JfrogCliBuildUrl,
 		JfrogCliEnvExclude,
 		JfrogCliFailNoOp,
@@ -0,0 +1,7 @@
+package cacheclear
+
+var Usage = []string{"cache clear"}
+
+func GetDescription() string {
+	return "Remove all the files from the download cache."
+}
@@ -0,0 +1,11 @@
+package cacheprune
+
+import "github.com/jfrog/jfrog-cli/docs/common"
+
+var Usage = []string{"cache prune [command options]"}
+
+var EnvVar = []string{common.JfrogCliDownloadCacheMaxSizeMb}
+
+func GetDescription() string {
+	return "Evict the least recently used files from the download cache, until it does not exceed its maximum size."
+}
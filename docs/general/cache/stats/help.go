@@ -0,0 +1,11 @@
+package cachestats
+
+import "github.com/jfrog/jfrog-cli/docs/common"
+
+var Usage = []string{"cache stats"}
+
+var EnvVar = []string{common.JfrogCliDownloadCache, common.JfrogCliDownloadCacheMaxSizeMb}
+
+func GetDescription() string {
+	return "Show the location, size and number of files of the download cache."
+}
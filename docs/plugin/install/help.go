@@ -4,7 +4,7 @@ import "github.com/jfrog/jfrog-cli/docs/common"
 
 var Usage = []string{"plugin install <plugin name and version>"}
 
-var EnvVar = []string{common.JfrogCliPluginsServer, common.JfrogCliPluginsRepo}
+var EnvVar = []string{common.JfrogCliPluginsServer, common.JfrogCliPluginsRepo, common.JfrogCliDownloadCache}
 
 func GetDescription() string {
 	return "Install or upgrade a JFrog CLI plugin."
@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
+	"github.com/jfrog/jfrog-cli/docs/common"
+	cacheclear "github.com/jfrog/jfrog-cli/docs/general/cache/clear"
+	cacheprune "github.com/jfrog/jfrog-cli/docs/general/cache/prune"
+	cachestats "github.com/jfrog/jfrog-cli/docs/general/cache/stats"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/downloadcache"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+func GetCommands() []cli.Command {
+	return cliutils.GetSortedCommands(cli.CommandsByName{
+		{
+			Name:         "stats",
+			Usage:        cachestats.GetDescription(),
+			HelpName:     corecommon.CreateUsage("cache stats", cachestats.GetDescription(), cachestats.Usage),
+			ArgsUsage:    common.CreateEnvVars(cachestats.EnvVar...),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       statsCmd,
+		},
+		{
+			Name:         "prune",
+			Flags:        cliutils.GetCommandFlags(cliutils.CachePrune),
+			Usage:        cacheprune.GetDescription(),
+			HelpName:     corecommon.CreateUsage("cache prune", cacheprune.GetDescription(), cacheprune.Usage),
+			ArgsUsage:    common.CreateEnvVars(cacheprune.EnvVar...),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       pruneCmd,
+		},
+		{
+			Name:         "clear",
+			Usage:        cacheclear.GetDescription(),
+			HelpName:     corecommon.CreateUsage("cache clear", cacheclear.GetDescription(), cacheclear.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       clearCmd,
+		},
+	})
+}
+
+func statsCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	downloadCache, err := downloadcache.NewCache()
+	if err != nil {
+		return err
+	}
+	stats, err := downloadCache.Stats()
+	if err != nil {
+		return err
+	}
+	enabled, err := downloadcache.IsEnabled()
+	if err != nil {
+		return err
+	}
+	log.Output("Location:", stats.Dir)
+	log.Output("Enabled:", strconv.FormatBool(enabled))
+	log.Output("Files:", strconv.Itoa(stats.FilesCount))
+	log.Output("Size:", utils.ConvertIntToStorageSizeString(stats.TotalSize))
+	log.Output("Max size:", utils.ConvertIntToStorageSizeString(stats.MaxSize))
+	if stats.FilesCount > 0 {
+		log.Output("Least recently used:", stats.OldestAccess.Format(time.RFC3339))
+		log.Output("Most recently used:", stats.LatestAccess.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func pruneCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	downloadCache, err := downloadcache.NewCache()
+	if err != nil {
+		return err
+	}
+	maxSize := downloadCache.MaxSize()
+	if c.IsSet("max-size") {
+		maxSizeMb, err := strconv.ParseInt(c.String("max-size"), 10, 64)
+		if err != nil || maxSizeMb < 0 {
+			return errorutils.CheckErrorf("the value of the --max-size option must be a non-negative number, but got '%s'", c.String("max-size"))
+		}
+		maxSize = maxSizeMb * 1024 * 1024
+	}
+	evictedCount, freedSize, err := downloadCache.Prune(maxSize)
+	if err != nil {
+		return err
+	}
+	log.Info("Evicted", strconv.Itoa(evictedCount), "files from the download cache, freeing", utils.ConvertIntToStorageSizeString(freedSize)+".")
+	return nil
+}
+
+func clearCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	downloadCache, err := downloadcache.NewCache()
+	if err != nil {
+		return err
+	}
+	if err = downloadCache.Clear(); err != nil {
+		return err
+	}
+	log.Info("The download cache was cleared.")
+	return nil
+}
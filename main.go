@@ -23,6 +23,7 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli/docs/general/cisetup"
 	loginDocs "github.com/jfrog/jfrog-cli/docs/general/login"
+	"github.com/jfrog/jfrog-cli/general/cache"
 	cisetupcommand "github.com/jfrog/jfrog-cli/general/cisetup"
 	"github.com/jfrog/jfrog-cli/general/envsetup"
 	"github.com/jfrog/jfrog-cli/general/login"
@@ -218,6 +219,12 @@ func getCommands() []cli.Command {
 			Subcommands: config.GetCommands(),
 			Category:    otherCategory,
 		},
+		{
+			Name:        cliutils.CmdCache,
+			Usage:       "Download cache commands.",
+			Subcommands: cache.GetCommands(),
+			Category:    otherCategory,
+		},
 		{
 			Name:        cliutils.CmdProject,
 			Usage:       "Project commands.",
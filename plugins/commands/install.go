@@ -16,6 +16,7 @@ import (
 
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/downloadcache"
 	"github.com/jfrog/jfrog-cli/utils/progressbar"
 	"github.com/jfrog/jfrog-client-go/http/httpclient"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -270,6 +271,35 @@ func downloadFromArtifactory(downloadDetails *httpclient.DownloadFileDetails, ht
 	if err != nil {
 		return
 	}
+	downloadCache, err := downloadcache.GetDownloadCache()
+	if err != nil {
+		return
+	}
+	var sha256 string
+	if downloadCache != nil {
+		var details *fileutils.FileDetails
+		// The file may not exist in the registry. In that case, the download below returns the expected 404 response.
+		if details, response, err = client.GetRemoteFileDetails(downloadDetails.DownloadPath, httpDetails); err == nil {
+			sha256 = details.Checksum.Sha256
+			var found bool
+			found, err = downloadCache.Get(sha256, filepath.Join(downloadDetails.LocalPath, downloadDetails.LocalFileName))
+			if err != nil {
+				return
+			}
+			if found {
+				log.Info("Copied from the download cache: " + downloadDetails.FileName)
+				return
+			}
+		}
+	}
 	log.Info("Downloading: " + downloadDetails.FileName)
-	return client.DownloadFileWithProgress(downloadDetails, "", httpDetails, false, false, progressMgr)
+	response, err = client.DownloadFileWithProgress(downloadDetails, "", httpDetails, false, false, progressMgr)
+	if err != nil || downloadCache == nil || response.StatusCode != http.StatusOK {
+		return
+	}
+	if err = downloadCache.Put(sha256, filepath.Join(downloadDetails.LocalPath, downloadDetails.LocalFileName)); err != nil {
+		return
+	}
+	err = downloadCache.EvictIfNeeded()
+	return
 }
@@ -0,0 +1,34 @@
+package schema
+
+import (
+	_ "embed"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+//go:embed filespec-schema.json
+var fileSpecSchema []byte
+
+// Validates the content of a File Spec against the File Spec schema.
+func ValidateFileSpec(content []byte) error {
+	resultErrors, err := GetFileSpecErrors(content)
+	if err != nil || len(resultErrors) == 0 {
+		return err
+	}
+	var validationErrors []string
+	for _, resultError := range resultErrors {
+		validationErrors = append(validationErrors, resultError.String())
+	}
+	return errorutils.CheckErrorf("the File Spec doesn't match the File Spec schema:\n%s", strings.Join(validationErrors, "\n"))
+}
+
+// Returns the differences between the content of a File Spec and the File Spec schema.
+func GetFileSpecErrors(content []byte) ([]gojsonschema.ResultError, error) {
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(fileSpecSchema), gojsonschema.NewBytesLoader(content))
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed validating the File Spec: %s", err.Error())
+	}
+	return result.Errors(), nil
+}
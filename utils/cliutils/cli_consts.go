@@ -18,6 +18,7 @@ const (
 	CmdOptions        = "options"
 	CmdProject        = "project"
 	CmdPipelines      = "pl"
+	CmdCache          = "cache"
 
 	// Download
 	DownloadMinSplitKb    = 5120
@@ -25,9 +25,26 @@ const (
 	Copy                   = "copy"
 	Delete                 = "delete"
 	Properties             = "properties"
+	SetProps               = "set-props"
 	Search                 = "search"
+	Diff                   = "diff"
+	Restore                = "restore"
+	Cleanup                = "cleanup"
+	SpecRender             = "spec-render"
+	SpecValidate           = "spec-validate"
+	SpecLint               = "spec-lint"
+	ArchiveLs              = "archive-ls"
+	ArchiveGet             = "archive-get"
+	Stat                   = "stat"
+	Ls                     = "ls"
+	Tree                   = "tree"
+	Du                     = "du"
 	BuildPublish           = "build-publish"
+	BuildShow              = "build-show"
+	BuildSbom              = "build-sbom"
+	BuildDiff              = "build-diff"
 	BuildAppend            = "build-append"
+	BuildAddUpstream       = "build-add-upstream"
 	BuildScanLegacy        = "build-scan-legacy"
 	BuildPromote           = "build-promote"
 	BuildDiscard           = "build-discard"
@@ -72,6 +89,7 @@ const (
 	Poetry                 = "poetry"
 	Ping                   = "ping"
 	RtCurl                 = "rt-curl"
+	RtSync                 = "rt-sync"
 	TemplateConsumer       = "template-consumer"
 	RepoDelete             = "repo-delete"
 	ReplicationDelete      = "replication-delete"
@@ -124,6 +142,9 @@ const (
 	// Project commands keys
 	InitProject = "project-init"
 
+	// Cache commands keys
+	CachePrune = "cache-prune"
+
 	// TransferFiles commands keys
 	TransferFiles = "transfer-files"
 
@@ -141,6 +162,8 @@ const (
 	password    = "password"
 	accessToken = "access-token"
 	serverId    = "server-id"
+	// The server-id option of commands which can run against several servers.
+	serverIds = "server-ids"
 
 	passwordStdin    = "password-stdin"
 	accessTokenStdin = "access-token-stdin"
@@ -192,6 +215,9 @@ const (
 	quiet                   = "quiet"
 	bundle                  = "bundle"
 	publicGpgKey            = "gpg-key"
+	verifyKey               = "verify-key"
+	incremental             = "incremental"
+	verifyManifest          = "verify-manifest"
 	archiveEntries          = "archive-entries"
 	detailedSummary         = "detailed-summary"
 	archive                 = "archive"
@@ -200,6 +226,8 @@ const (
 	fromRt                  = "from-rt"
 	transitive              = "transitive"
 	Status                  = "status"
+	resume                  = "resume"
+	limitRate               = "limit-rate"
 
 	// Config flags
 	interactive   = "interactive"
@@ -220,6 +248,7 @@ const (
 	deb               = "deb"
 	symlinks          = "symlinks"
 	uploadAnt         = uploadPrefix + antFlag
+	checksumPlan      = "checksum-plan"
 
 	// Unique download flags
 	downloadPrefix       = "download-"
@@ -263,12 +292,63 @@ const (
 	searchExcludeProps = searchPrefix + excludeProps
 	count              = "count"
 	searchTransitive   = searchPrefix + transitive
+	searchFormat       = searchPrefix + "format"
+	searchFields       = "fields"
 
 	// Unique properties flags
 	propertiesPrefix  = "props-"
 	propsRecursive    = propertiesPrefix + recursive
 	propsProps        = propertiesPrefix + props
 	propsExcludeProps = propertiesPrefix + excludeProps
+	propsManifest     = "manifest"
+
+	// Unique delete and move flags
+	backupTo = "backup-to"
+
+	// Unique cleanup flags
+	cleanupPolicy = "cleanup-policy"
+
+	// Unique sync flags
+	syncPolicy = "policy"
+
+	// Unique diff flags
+	diffPrefix       = "diff-"
+	diffSourceBuild  = "source-build"
+	diffTargetBuild  = "target-build"
+	diffFormat       = diffPrefix + "format"
+	diffCompareProps = "compare-props"
+
+	// Unique spec validate and lint flags
+	specLintFormat = "spec-lint-format"
+
+	// Unique archive ls flags
+	archiveLsFormat = "archive-ls-format"
+
+	// Unique stat flags
+	statFormat = "stat-format"
+
+	// Unique ls and tree flags
+	lsLong      = "long"
+	lsRecursive = "ls-recursive"
+	lsSort      = "sort"
+	treeDepth   = "depth"
+
+	// Unique build-show flags
+	buildShowFormat = "build-show-format"
+
+	// Unique build-sbom flags
+	buildSbomFormat = "build-sbom-format"
+	buildSbomOutput = "build-sbom-output"
+	buildSbomDeploy = "build-sbom-deploy"
+
+	// Unique build-diff flags
+	buildDiffFormat = "build-diff-format"
+
+	// Unique du flags
+	duDepth    = "du-depth"
+	duFormat   = "du-format"
+	duSnapshot = "snapshot"
+	duCompare  = "compare"
 
 	// Unique go publish flags
 	goPublishExclusions = GoPublish + exclusions
@@ -277,6 +357,11 @@ const (
 	buildPublishPrefix = "bp-"
 	bpDryRun           = buildPublishPrefix + dryRun
 	bpDetailedSummary  = buildPublishPrefix + detailedSummary
+	bpSbom             = buildPublishPrefix + "sbom"
+	bpProvenance       = buildPublishPrefix + "provenance"
+	bpProvenanceKey    = buildPublishPrefix + "provenance-key"
+	bpFulcioUrl        = buildPublishPrefix + "fulcio-url"
+	bpRekorUrl         = buildPublishPrefix + "rekor-url"
 	envInclude         = "env-include"
 	envExclude         = "env-exclude"
 	buildUrl           = "build-url"
@@ -306,6 +391,10 @@ const (
 	includeDependencies = "include-dependencies"
 	copyFlag            = "copy"
 	failFast            = "fail-fast"
+	bprIncludeUpstream  = buildPromotePrefix + "include-upstream"
+
+	// Unique build-add-upstream flags
+	upstreamBuild = "upstream-build"
 
 	Async = "async"
 
@@ -499,6 +588,9 @@ const (
 	// *** Project Commands' flags ***
 	projectPath = "path"
 
+	// *** Cache Commands' flags ***
+	cacheMaxSize = "max-size"
+
 	// *** Completion Commands' flags ***
 	Completion = "completion"
 	Install    = "install"
@@ -573,6 +665,10 @@ var flagsMap = map[string]cli.Flag{
 		Name:  serverId,
 		Usage: "[Optional] Server ID configured using the config command.` `",
 	},
+	serverIds: cli.StringFlag{
+		Name:  serverId,
+		Usage: "[Optional] Server ID configured using the config command. To run the command against several servers concurrently, provide a comma-separated list of server IDs.` `",
+	},
 	passwordStdin: cli.BoolFlag{
 		Name:  passwordStdin,
 		Usage: "[Default: false] Set to true if you'd like to provide the password via stdin.` `",
@@ -624,7 +720,7 @@ var flagsMap = map[string]cli.Flag{
 	},
 	specVars: cli.StringFlag{
 		Name:  specVars,
-		Usage: "[Optional] List of variables in the form of \"key1=value1;key2=value2;...\" to be replaced in the File Spec. In the File Spec, the variables should be used as follows: ${key1}.` `",
+		Usage: "[Optional] List of variables in the form of \"key1=value1;key2=value2;...\" to be replaced in the File Spec. In the File Spec, the variables should be used as follows: ${key1}. In a File Spec template, the variables are also available as follows: {{ .key1 }}.` `",
 	},
 	buildName: cli.StringFlag{
 		Name:  buildName,
@@ -691,6 +787,18 @@ var flagsMap = map[string]cli.Flag{
 		Name:  publicGpgKey,
 		Usage: "[Optional] Path to the public GPG key file located on the file system, used to validate downloaded release bundles.` `",
 	},
+	incremental: cli.BoolFlag{
+		Name:  incremental,
+		Usage: "[Default: false] Set to true to download only the files which were created or modified in Artifactory since the last successful download of the same File Spec from the same server. The first run downloads all the matching files. Files which were deleted or modified locally since the last run are not downloaded again. When used with --sync-deletes, all the matching files are compared, as in a regular download.` `",
+	},
+	verifyKey: cli.StringFlag{
+		Name:  verifyKey,
+		Usage: "[Optional] Path to a GPG public key file or a PEM encoded public key file, used to verify the downloaded files against the detached signatures stored next to them in Artifactory (<file>.asc or <file>.sig). The files are moved to their local paths only if all of them were verified.` `",
+	},
+	verifyManifest: cli.StringFlag{
+		Name:  verifyManifest,
+		Usage: "[Optional] The name of a checksum manifest in the format of sha256sum, stored in the folder of the downloaded files in Artifactory. If set, the manifest is verified against its detached signature using the --verify-key, and the downloaded files are verified against the checksums in the manifest.` `",
+	},
 	archiveEntries: cli.StringFlag{
 		Name:  archiveEntries,
 		Usage: "[Optional] If specified, only archive artifacts containing entries matching this pattern are matched. You can use wildcards to specify multiple artifacts.` `",
@@ -699,6 +807,46 @@ var flagsMap = map[string]cli.Flag{
 		Name:  detailedSummary,
 		Usage: "[Default: false] Set to true to include a list of the affected files in the command summary.` `",
 	},
+	limitRate: cli.StringFlag{
+		Name:  limitRate,
+		Usage: "[Default: $JFROG_CLI_MAX_BANDWIDTH or unlimited] The maximum total transfer rate of the command, shared by all threads and split download chunks. For example: 20MB/s or 512KB/s.` `",
+	},
+	backupTo: cli.StringFlag{
+		Name:  backupTo,
+		Usage: "[Optional] A repository to back up the affected artifacts to, before they are changed. The artifacts, their original paths and properties are recorded as an operation, which can be restored using the 'jf rt restore' command.` `",
+	},
+	propsManifest: cli.StringFlag{
+		Name:  propsManifest,
+		Usage: "[Optional] Path to a CSV or JSON manifest, mapping artifact paths or checksums to the properties to set on them. If provided, no arguments should be sent.` `",
+	},
+	diffSourceBuild: cli.StringFlag{
+		Name:  diffSourceBuild,
+		Usage: "[Optional] Compare the artifacts of a build, in the form of <build name>/<build number>, instead of a source repository path. If the source pattern argument is also provided, it filters the build's artifacts.` `",
+	},
+	diffTargetBuild: cli.StringFlag{
+		Name:  diffTargetBuild,
+		Usage: "[Optional] Compare the artifacts of a build, in the form of <build name>/<build number>, instead of a target repository path. If the target pattern argument is also provided, it filters the build's artifacts.` `",
+	},
+	diffFormat: cli.StringFlag{
+		Name:  "format",
+		Usage: "[Default: table] Defines the output format of the command. Acceptable values are: table and json.` `",
+	},
+	diffCompareProps: cli.BoolTFlag{
+		Name:  diffCompareProps,
+		Usage: "[Default: true] Set to false to ignore the differences in the artifacts' properties.` `",
+	},
+	cleanupPolicy: cli.StringFlag{
+		Name:  "policy",
+		Usage: "[Mandatory] Path to a YAML file, declaring the retention policy rules.` `",
+	},
+	syncPolicy: cli.StringFlag{
+		Name:  syncPolicy,
+		Usage: "[Default: report] Determines how files that exist both locally and in Artifactory with different checksums are handled. Possible values: report, local-wins, remote-wins and newer-wins.` `",
+	},
+	resume: cli.BoolFlag{
+		Name:  resume,
+		Usage: "[Default: false] Set to true to record the transferred files in a journal under the JFrog CLI home directory. Running the same command again with this option skips the files that were already transferred, and continues split downloads from the last completed chunk.` `",
+	},
 	interactive: cli.BoolTFlag{
 		Name:  interactive,
 		Usage: "[Default: true, unless $CI is true] Set to false if you do not want the config command to be interactive. If true, the --url option becomes optional.` `",
@@ -759,7 +907,11 @@ var flagsMap = map[string]cli.Flag{
 	},
 	uploadArchive: cli.StringFlag{
 		Name:  archive,
-		Usage: "[Optional] Set to \"zip\" to deploy the files to Artifactory in a ZIP archive.` `",
+		Usage: "[Optional] Set to \"zip\", \"tar.gz\" or \"tar.zst\" to deploy the files to Artifactory in an archive of this type. tar.gz and tar.zst archives are streamed to Artifactory while they are created, without a temporary file.` `",
+	},
+	checksumPlan: cli.BoolFlag{
+		Name:  checksumPlan,
+		Usage: "[Default: false] Set to true to plan the upload before it starts. The SHA-256 checksums of the files are searched in Artifactory in batches, the projected bytes saved are reported, and every file which already exists in Artifactory is deployed by checksum, regardless of its size.` `",
 	},
 	syncDeletesQuiet: cli.BoolFlag{
 		Name:  quiet,
@@ -879,6 +1031,78 @@ var flagsMap = map[string]cli.Flag{
 		Name:  transitive,
 		Usage: "[Default: false] Set to true to look for artifacts also in remote repositories. The search will run on the first five remote repositories within the virtual repository. Available on Artifactory version 7.17.0 or higher.` `",
 	},
+	specLintFormat: cli.StringFlag{
+		Name:  "format",
+		Usage: "[Default: table] Defines the output format of the command. Acceptable values are: table and json.` `",
+	},
+	archiveLsFormat: cli.StringFlag{
+		Name:  "format",
+		Usage: "[Default: table] Defines the output format of the command. Acceptable values are: table and json.` `",
+	},
+	statFormat: cli.StringFlag{
+		Name:  "format",
+		Usage: "[Default: table] Defines the output format of the command. Acceptable values are: table and json.` `",
+	},
+	lsLong: cli.BoolFlag{
+		Name:  "long, l",
+		Usage: "[Default: false] Set to true to list the size, modification time and creator of each file and folder.` `",
+	},
+	lsRecursive: cli.BoolFlag{
+		Name:  "recursive, R",
+		Usage: "[Default: false] Set to true to list the files and folders in the sub-folders as well.` `",
+	},
+	lsSort: cli.StringFlag{
+		Name:  lsSort,
+		Usage: "[Default: name] The field to sort the list by. Acceptable values are: name, size, modified and created. Sizes and times are sorted from the largest and most recent.` `",
+	},
+	treeDepth: cli.StringFlag{
+		Name:  treeDepth,
+		Usage: "[Default: 0] The number of folder levels to print below the path. 0 prints all levels.` `",
+	},
+	buildShowFormat: cli.StringFlag{
+		Name:  "format",
+		Usage: "[Default: table] Defines the output format of the command. Acceptable values are: table and json.` `",
+	},
+	buildSbomFormat: cli.StringFlag{
+		Name:  "format",
+		Usage: "[Default: cyclonedx] The format of the SBOM. Acceptable values are: cyclonedx (CycloneDX 1.5) and spdx (SPDX 2.3).` `",
+	},
+	buildSbomOutput: cli.StringFlag{
+		Name:  "output",
+		Usage: "[Optional] Path to a local file to save the SBOM to. If neither this option nor --deploy is set, the SBOM is printed.` `",
+	},
+	buildSbomDeploy: cli.BoolFlag{
+		Name:  "deploy",
+		Usage: "[Default: false] Set to true to deploy the SBOM to the Artifactory folder of the build artifacts, with properties linking it to the build.` `",
+	},
+	buildDiffFormat: cli.StringFlag{
+		Name:  "format",
+		Usage: "[Default: table] Defines the output format of the command. Acceptable values are: table and json.` `",
+	},
+	duDepth: cli.StringFlag{
+		Name:  treeDepth,
+		Usage: "[Default: 1] The number of sub-folder levels to report below the path. 0 reports all levels.` `",
+	},
+	duFormat: cli.StringFlag{
+		Name:  "format",
+		Usage: "[Default: table] Defines the output format of the command. Acceptable values are: table, json and csv.` `",
+	},
+	duSnapshot: cli.StringFlag{
+		Name:  duSnapshot,
+		Usage: "[Optional] Path to a file to save the report to as a snapshot, so that later reports can be compared to it with the --compare option.` `",
+	},
+	duCompare: cli.StringFlag{
+		Name:  duCompare,
+		Usage: "[Optional] Path to a snapshot saved by a previous report of the same path and depth. If provided, the growth of each folder since the snapshot is reported.` `",
+	},
+	searchFormat: cli.StringFlag{
+		Name:  "format",
+		Usage: "[Default: json] Defines the output format of the command. Acceptable values are: json, jsonl, csv and table.` `",
+	},
+	searchFields: cli.StringFlag{
+		Name:  searchFields,
+		Usage: "[Optional] Comma-separated list of the result fields to print, in the form of \"path,size,sha256,props.build.name\". A single property can be selected by its key, with the 'props.' prefix.` `",
+	},
 	searchInclude: cli.StringFlag{
 		Name:  searchInclude,
 		Usage: fmt.Sprintf("[Optional] List of fields in the form of \"value1;value2;...\". Only the path and the fields that are specified will be returned. The fields must be part of the 'items' AQL domain. For the full supported items list, check %sjfrog-artifactory-documentation/artifactory-query-language` `", coreutils.JFrogHelpUrl),
@@ -911,6 +1135,26 @@ var flagsMap = map[string]cli.Flag{
 		Name:  detailedSummary,
 		Usage: "[Default: false] Set to true to get a command summary with details about the build info artifact.` `",
 	},
+	bpSbom: cli.StringFlag{
+		Name:  "sbom",
+		Usage: "[Optional] Set to cyclonedx or spdx to deploy an SBOM of the published build, in that format, to the Artifactory folder of the build artifacts. The SBOM is not deployed if the build has no artifacts.` `",
+	},
+	bpProvenance: cli.BoolFlag{
+		Name:  "provenance",
+		Usage: "[Default: false] Set to true to deploy a signed SLSA v1 provenance of the published build to the Artifactory folder of the build artifacts. The provenance is signed with the key provided by --provenance-key, or keylessly with a Sigstore certificate if no key is provided. Keyless signatures are recorded in the Rekor transparency log, and are deployed as Sigstore bundles.` `",
+	},
+	bpProvenanceKey: cli.StringFlag{
+		Name:  "provenance-key",
+		Usage: "[Optional] Path to a PEM file of an unencrypted ECDSA, Ed25519 or RSA private key, to sign the provenance with.` `",
+	},
+	bpFulcioUrl: cli.StringFlag{
+		Name:  "fulcio-url",
+		Usage: "[Default: https://fulcio.sigstore.dev] URL of the Fulcio certificate authority which issues the certificates of keyless provenance signatures.` `",
+	},
+	bpRekorUrl: cli.StringFlag{
+		Name:  "rekor-url",
+		Usage: "[Default: https://rekor.sigstore.dev] URL of the Rekor transparency log in which keyless provenance signatures are recorded.` `",
+	},
 	envInclude: cli.StringFlag{
 		Name:  envInclude,
 		Usage: "[Default: *] List of patterns in the form of \"value1;value2;...\" Only environment variables match those patterns will be included.` `",
@@ -979,6 +1223,14 @@ var flagsMap = map[string]cli.Flag{
 		Name:  props,
 		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". A list of properties to attach to the build artifacts.` `",
 	},
+	bprIncludeUpstream: cli.BoolFlag{
+		Name:  "include-upstream",
+		Usage: "[Default: false] If true, the upstream builds recorded by the build-add-upstream command are promoted as well, together with their own upstream builds.` `",
+	},
+	upstreamBuild: cli.StringFlag{
+		Name:  upstreamBuild,
+		Usage: "[Mandatory] The upstream build in the following format: <build name>/<build number>. Use LATEST as the build number for the latest published build. Multiple upstream builds can be separated by semicolons.` `",
+	},
 	targetDockerImage: cli.StringFlag{
 		Name:  "target-docker-image",
 		Usage: "[Optional] Docker target image name.` `",
@@ -1501,6 +1753,10 @@ var flagsMap = map[string]cli.Flag{
 		Name:  projectPath,
 		Usage: "[Default: ./] Full path to the code project.` `",
 	},
+	cacheMaxSize: cli.StringFlag{
+		Name:  cacheMaxSize,
+		Usage: "[Default: $JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE_MB or 1024] The maximum size of the cache in MB. The least recently used files are evicted until the cache does not exceed this size.` `",
+	},
 	Install: cli.BoolFlag{
 		Name:  Install,
 		Usage: "[Default: false] Set to true to install the completion script instead of printing it to the standard output.` `",
@@ -1628,11 +1884,11 @@ var commandFlags = map[string][]string{
 		deleteQuiet,
 	},
 	Upload: {
-		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath, uploadTargetProps,
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverIds, ClientCertPath, uploadTargetProps,
 		ClientCertKeyPath, specFlag, specVars, buildName, buildNumber, module, uploadExclusions, deb,
 		uploadRecursive, uploadFlat, uploadRegexp, retries, retryWaitTime, dryRun, uploadExplode, symlinks, includeDirs,
 		failNoOp, threads, uploadSyncDeletes, syncDeletesQuiet, InsecureTls, detailedSummary, project,
-		uploadAnt, uploadArchive,
+		uploadAnt, uploadArchive, resume, limitRate, checksumPlan,
 	},
 	Download: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
@@ -1640,13 +1896,13 @@ var commandFlags = map[string][]string{
 		sortOrder, limit, offset, downloadRecursive, downloadFlat, build, includeDeps, excludeArtifacts, minSplit, splitCount,
 		retries, retryWaitTime, dryRun, downloadExplode, bypassArchiveInspection, validateSymlinks, bundle, publicGpgKey, includeDirs,
 		downloadProps, downloadExcludeProps, failNoOp, threads, archiveEntries, downloadSyncDeletes, syncDeletesQuiet, InsecureTls, detailedSummary, project,
-		skipChecksum,
+		skipChecksum, resume, limitRate, verifyKey, verifyManifest, incremental,
 	},
 	Move: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset, moveRecursive,
 		moveFlat, dryRun, build, includeDeps, excludeArtifacts, moveProps, moveExcludeProps, failNoOp, threads, archiveEntries,
-		InsecureTls, retries, retryWaitTime, project,
+		InsecureTls, retries, retryWaitTime, project, backupTo,
 	},
 	Copy: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
@@ -1655,16 +1911,67 @@ var commandFlags = map[string][]string{
 		archiveEntries, InsecureTls, retries, retryWaitTime, project,
 	},
 	Delete: {
-		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverIds, ClientCertPath,
 		ClientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
 		deleteRecursive, dryRun, build, includeDeps, excludeArtifacts, deleteQuiet, deleteProps, deleteExcludeProps, failNoOp, threads, archiveEntries,
-		InsecureTls, retries, retryWaitTime, project,
+		InsecureTls, retries, retryWaitTime, project, backupTo,
 	},
 	Search: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
 		searchRecursive, build, includeDeps, excludeArtifacts, count, bundle, includeDirs, searchProps, searchExcludeProps, failNoOp, archiveEntries,
-		InsecureTls, searchTransitive, retries, retryWaitTime, project, searchInclude,
+		InsecureTls, searchTransitive, retries, retryWaitTime, project, searchInclude, searchFormat, searchFields,
+	},
+	RtSync: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, syncPolicy, dryRun, threads, retries, retryWaitTime, failNoOp, detailedSummary,
+		InsecureTls, limitRate,
+	},
+	Cleanup: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, cleanupPolicy, dryRun, deleteQuiet, failNoOp, threads, InsecureTls, retries, retryWaitTime,
+	},
+	SpecRender: {
+		specVars,
+	},
+	ArchiveLs: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, archiveLsFormat, InsecureTls, retries, retryWaitTime,
+	},
+	ArchiveGet: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, InsecureTls, retries, retryWaitTime,
+	},
+	Stat: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, statFormat, InsecureTls, retries, retryWaitTime,
+	},
+	Ls: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, lsLong, lsRecursive, lsSort, InsecureTls, retries, retryWaitTime,
+	},
+	Tree: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, treeDepth, InsecureTls, retries, retryWaitTime,
+	},
+	Du: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, duDepth, duFormat, duSnapshot, duCompare, InsecureTls, retries, retryWaitTime,
+	},
+	SpecValidate: {
+		specVars, specLintFormat,
+	},
+	SpecLint: {
+		specVars, specLintFormat,
+	},
+	Restore: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, failNoOp, threads, InsecureTls, retries, retryWaitTime,
+	},
+	Diff: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, diffSourceBuild, diffTargetBuild, diffFormat, diffCompareProps, InsecureTls,
+		retries, retryWaitTime,
 	},
 	Properties: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
@@ -1672,14 +1979,35 @@ var commandFlags = map[string][]string{
 		propsRecursive, build, includeDeps, excludeArtifacts, bundle, includeDirs, failNoOp, threads, archiveEntries, propsProps, propsExcludeProps,
 		InsecureTls, retries, retryWaitTime, project,
 	},
+	SetProps: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverIds, ClientCertPath,
+		ClientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
+		propsRecursive, build, includeDeps, excludeArtifacts, bundle, includeDirs, failNoOp, threads, archiveEntries, propsProps, propsExcludeProps,
+		InsecureTls, retries, retryWaitTime, project, propsManifest,
+	},
 	BuildPublish: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, buildUrl, bpDryRun,
-		envInclude, envExclude, InsecureTls, project, bpDetailedSummary,
+		envInclude, envExclude, InsecureTls, project, bpDetailedSummary, bpSbom, bpProvenance,
+		bpProvenanceKey, bpFulcioUrl, bpRekorUrl, retries, retryWaitTime,
+	},
+	BuildShow: {
+		buildUrl, envInclude, envExclude, project, buildShowFormat,
+	},
+	BuildSbom: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, project, buildSbomFormat, buildSbomOutput, buildSbomDeploy, InsecureTls, retries, retryWaitTime,
+	},
+	BuildDiff: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, project, buildDiffFormat, InsecureTls, retries, retryWaitTime,
 	},
 	BuildAppend: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, buildUrl, bpDryRun,
 		envInclude, envExclude, InsecureTls, project,
 	},
+	BuildAddUpstream: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, upstreamBuild, InsecureTls, project,
+	},
 	BuildAddDependencies: {
 		specFlag, specVars, uploadExclusions, badRecursive, badRegexp, badDryRun, project, badFromRt, serverId, badModule,
 	},
@@ -1702,7 +2030,7 @@ var commandFlags = map[string][]string{
 	},
 	BuildPromote: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, Status, comment,
-		sourceRepo, includeDependencies, copyFlag, failFast, bprDryRun, bprProps, InsecureTls, project,
+		sourceRepo, includeDependencies, copyFlag, failFast, bprDryRun, bprProps, bprIncludeUpstream, InsecureTls, project,
 	},
 	BuildDiscard: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, maxDays, maxBuilds,
@@ -1963,6 +2291,9 @@ var commandFlags = map[string][]string{
 	InitProject: {
 		projectPath, serverId,
 	},
+	CachePrune: {
+		cacheMaxSize,
+	},
 	// Completion commands
 	Completion: {
 		Install,
@@ -0,0 +1,144 @@
+package cliutils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	coreCommonCommands "github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+const serverIdsSeparator = ","
+
+// Returns the server IDs of the --server-id option.
+// Commands which can run against several servers accept a comma-separated list of server IDs.
+func GetServerIds(c *cli.Context) []string {
+	serverIds := c.String(serverId)
+	if serverIds == "" {
+		serverIds = os.Getenv(coreutils.ServerID)
+	}
+	var ids []string
+	for _, id := range strings.Split(serverIds, serverIdsSeparator) {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Returns the details of the Artifactory servers the command runs against.
+// If the --server-id option includes several servers, the details of each of them are taken from the config.
+// Otherwise, returns the details of a single server, like CreateArtifactoryDetailsByFlags.
+func CreateArtifactoryDetailsForServers(c *cli.Context) ([]*coreConfig.ServerDetails, error) {
+	serverIds := GetServerIds(c)
+	if len(serverIds) <= 1 {
+		serverDetails, err := CreateArtifactoryDetailsByFlags(c)
+		if err != nil {
+			return nil, err
+		}
+		return []*coreConfig.ServerDetails{serverDetails}, nil
+	}
+	flagsDetails, err := createServerDetailsFromFlags(c, Rt)
+	if err != nil {
+		return nil, err
+	}
+	if credentialsChanged(flagsDetails) {
+		return nil, errorutils.CheckErrorf("the connection details options cannot be used when the --server-id option includes several servers")
+	}
+	var serversDetails []*coreConfig.ServerDetails
+	for i, id := range serverIds {
+		for _, previousId := range serverIds[:i] {
+			if id == previousId {
+				return nil, errorutils.CheckErrorf("the server '%s' is included more than once in the --server-id option", id)
+			}
+		}
+		serverDetails, err := coreCommonCommands.GetConfig(id, false)
+		if err != nil {
+			return nil, err
+		}
+		if serverDetails.ArtifactoryUrl == "" {
+			return nil, errorutils.CheckErrorf("the Artifactory URL of the server '%s' isn't configured", id)
+		}
+		serversDetails = append(serversDetails, serverDetails)
+	}
+	return serversDetails, nil
+}
+
+// The result of a command which ran against one of the servers.
+type ServerResult struct {
+	ServerId string
+	Result   *commandUtils.Result
+	Err      error
+}
+
+// Runs a command against each of the servers concurrently, and returns the result of each server.
+func RunOnServers(serversDetails []*coreConfig.ServerDetails, run func(serverDetails *coreConfig.ServerDetails) (*commandUtils.Result, error)) []*ServerResult {
+	serverResults := make([]*ServerResult, len(serversDetails))
+	done := make(chan bool)
+	for i, serverDetails := range serversDetails {
+		go func(i int, serverDetails *coreConfig.ServerDetails) {
+			defer func() {
+				done <- true
+			}()
+			log.Info(fmt.Sprintf("[%s] Running the command against %s", serverDetails.ServerId, serverDetails.ArtifactoryUrl))
+			result, err := run(serverDetails)
+			if err != nil {
+				log.Error(fmt.Sprintf("[%s] %s", serverDetails.ServerId, err.Error()))
+			}
+			serverResults[i] = &ServerResult{ServerId: serverDetails.ServerId, Result: result, Err: err}
+		}(i, serverDetails)
+	}
+	for range serversDetails {
+		<-done
+	}
+	return serverResults
+}
+
+// Prints the summary of each of the servers the command ran against, like PrintCommandSummary prints it for a single server.
+// The summaries are printed after the command is done on all the servers, so that they aren't mixed with each other.
+// Returns an error if the command failed on any of the servers, with the exit code of the most severe failure.
+func PrintServersSummary(serverResults []*ServerResult, detailedSummary, failNoOp bool) error {
+	exitCode := coreutils.ExitCodeNoError
+	var failedServers []string
+	for _, serverResult := range serverResults {
+		log.Info(fmt.Sprintf("[%s] Summary:", serverResult.ServerId))
+		err := PrintCommandSummary(serverResult.Result, detailedSummary, false, failNoOp, serverResult.Err)
+		if err == nil {
+			continue
+		}
+		failedServers = append(failedServers, serverResult.ServerId)
+		var cliError coreutils.CliError
+		if errors.As(err, &cliError) && cliError.ExitCode == coreutils.ExitCodeFailNoOp {
+			// No files were affected on the server, and the fail-no-op option was set.
+			if exitCode != coreutils.ExitCodeError {
+				exitCode = coreutils.ExitCodeFailNoOp
+			}
+		} else {
+			exitCode = coreutils.ExitCodeError
+		}
+	}
+	if len(failedServers) == 0 {
+		return nil
+	}
+	return coreutils.CliError{ExitCode: exitCode, ErrorMsg: fmt.Sprintf("The command failed on %d of %d servers: %s", len(failedServers), len(serverResults), strings.Join(failedServers, ", "))}
+}
+
+// Returns a copy of the spec for a command which runs against one of the servers.
+// Commands may change their spec files while running, so the servers can't share the same spec.
+func CloneSpec(specFiles *spec.SpecFiles) *spec.SpecFiles {
+	clone := &spec.SpecFiles{Files: make([]spec.File, len(specFiles.Files))}
+	for i, file := range specFiles.Files {
+		file.Exclusions = append([]string(nil), file.Exclusions...)
+		file.SortBy = append([]string(nil), file.SortBy...)
+		clone.Files[i] = file
+	}
+	return clone
+}
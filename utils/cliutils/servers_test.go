@@ -0,0 +1,59 @@
+package cliutils
+
+import (
+	"errors"
+	"testing"
+
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunOnServers(t *testing.T) {
+	serversDetails := []*coreConfig.ServerDetails{{ServerId: "one"}, {ServerId: "two"}, {ServerId: "three"}}
+	serverResults := RunOnServers(serversDetails, func(serverDetails *coreConfig.ServerDetails) (*commandUtils.Result, error) {
+		result := new(commandUtils.Result)
+		switch serverDetails.ServerId {
+		case "one":
+			result.SetSuccessCount(2)
+			return result, nil
+		case "two":
+			result.SetSuccessCount(1)
+			result.SetFailCount(1)
+			return result, errors.New("failed")
+		default:
+			return result, nil
+		}
+	})
+	assert.Len(t, serverResults, 3)
+	assert.Equal(t, "one", serverResults[0].ServerId)
+	assert.Equal(t, 2, serverResults[0].Result.SuccessCount())
+	assert.EqualError(t, serverResults[1].Err, "failed")
+
+	// No files were affected on the third server, with fail-no-op.
+	err := PrintServersSummary(serverResults, false, true)
+	var cliError coreutils.CliError
+	if assert.ErrorAs(t, err, &cliError) {
+		assert.Equal(t, coreutils.ExitCodeError, cliError.ExitCode)
+		assert.Contains(t, cliError.ErrorMsg, "2 of 3 servers: two, three")
+	}
+	err = PrintServersSummary([]*ServerResult{serverResults[0], serverResults[2]}, false, true)
+	if assert.ErrorAs(t, err, &cliError) {
+		assert.Equal(t, coreutils.ExitCodeFailNoOp, cliError.ExitCode)
+	}
+	assert.NoError(t, PrintServersSummary([]*ServerResult{serverResults[0], serverResults[2]}, false, false))
+}
+
+func TestCloneSpec(t *testing.T) {
+	original := &spec.SpecFiles{Files: []spec.File{{Pattern: "a/*", Target: "repo/", Props: "k=v", Exclusions: []string{"*.tmp"}}}}
+	clone := CloneSpec(original)
+	assert.Equal(t, original, clone)
+	clone.Files[0].Props += ";sync.deletes.timestamp=1"
+	clone.Files[0].TargetProps = "k=v"
+	clone.Files[0].Exclusions[0] = "*.log"
+	assert.Equal(t, "k=v", original.Files[0].Props)
+	assert.Empty(t, original.Files[0].TargetProps)
+	assert.Equal(t, []string{"*.tmp"}, original.Files[0].Exclusions)
+}
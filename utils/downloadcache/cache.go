@@ -0,0 +1,328 @@
+package downloadcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// Env
+	DownloadCacheEnv        = "JFROG_CLI_DOWNLOAD_CACHE"
+	DownloadCacheMaxSizeEnv = "JFROG_CLI_DOWNLOAD_CACHE_MAX_SIZE_MB"
+
+	DefaultMaxSizeMb = 1024
+	cacheDirName     = "cache"
+	downloadsDirName = "downloads"
+	tempFileSuffix   = ".tmp"
+	// Temp files older than this duration were left by a process which was killed while populating the cache.
+	staleTempFileAge = time.Hour
+)
+
+// A content-addressed cache of downloaded files, shared by all the JFrog CLI processes running on the machine.
+// Each file is stored under its SHA-256 checksum, so identical files downloaded from different paths, repositories or
+// servers are kept once. The modification time of the cached files is updated whenever they are used, and the least
+// recently used files are evicted when the cache exceeds its maximum size.
+type Cache struct {
+	dir     string
+	maxSize int64
+}
+
+type Stats struct {
+	Dir          string
+	FilesCount   int
+	TotalSize    int64
+	MaxSize      int64
+	OldestAccess time.Time
+	LatestAccess time.Time
+}
+
+type cachedFile struct {
+	path       string
+	size       int64
+	lastAccess time.Time
+}
+
+// Returns true if the download cache is enabled by the JFROG_CLI_DOWNLOAD_CACHE environment variable.
+func IsEnabled() (bool, error) {
+	return clientutils.GetBoolEnvValue(DownloadCacheEnv, false)
+}
+
+// Returns the download cache, or nil if the cache is not enabled.
+func GetDownloadCache() (*Cache, error) {
+	enabled, err := IsEnabled()
+	if err != nil || !enabled {
+		return nil, err
+	}
+	return NewCache()
+}
+
+// Returns the download cache, regardless of whether it is enabled, so that it can be managed.
+func NewCache() (*Cache, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	maxSize, err := getMaxSize()
+	if err != nil {
+		return nil, err
+	}
+	return newCacheInDir(filepath.Join(homeDir, cacheDirName, downloadsDirName), maxSize), nil
+}
+
+func newCacheInDir(dir string, maxSize int64) *Cache {
+	return &Cache{dir: dir, maxSize: maxSize}
+}
+
+func getMaxSize() (int64, error) {
+	maxSizeMb := os.Getenv(DownloadCacheMaxSizeEnv)
+	if maxSizeMb == "" {
+		return DefaultMaxSizeMb * 1024 * 1024, nil
+	}
+	size, err := strconv.ParseInt(maxSizeMb, 10, 64)
+	if err != nil || size < 0 {
+		return 0, errorutils.CheckErrorf("the value of the %s environment variable must be a non-negative number, but got '%s'", DownloadCacheMaxSizeEnv, maxSizeMb)
+	}
+	return size * 1024 * 1024, nil
+}
+
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+func (c *Cache) MaxSize() int64 {
+	return c.maxSize
+}
+
+func (c *Cache) getCachedFilePath(sha256 string) string {
+	sha256 = strings.ToLower(sha256)
+	return filepath.Join(c.dir, sha256[:2], sha256)
+}
+
+func isValidSha256(checksum string) bool {
+	if len(checksum) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(checksum)
+	return err == nil
+}
+
+// Copies the cached file with the provided SHA-256 checksum to the target path.
+// Returns false if the file is not cached.
+func (c *Cache) Get(sha256, targetPath string) (found bool, err error) {
+	if !isValidSha256(sha256) {
+		return false, nil
+	}
+	cachedFilePath := c.getCachedFilePath(sha256)
+	source, err := os.Open(cachedFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(source.Close()))
+	}()
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(targetPath)); err != nil {
+		return
+	}
+	// Copy to a temp file next to the target, so that the target is never left partially written.
+	target, err := os.CreateTemp(filepath.Dir(targetPath), filepath.Base(targetPath)+".*"+tempFileSuffix)
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	_, err = io.Copy(target, source)
+	err = errors.Join(errorutils.CheckError(err), errorutils.CheckError(target.Close()))
+	if err == nil {
+		err = errorutils.CheckError(os.Rename(target.Name(), targetPath))
+	}
+	if err != nil {
+		return false, errors.Join(err, errorutils.CheckError(os.Remove(target.Name())))
+	}
+	now := time.Now()
+	if e := os.Chtimes(cachedFilePath, now, now); e != nil {
+		log.Debug("Couldn't update the last access time of the cached file", cachedFilePath+":", e.Error())
+	}
+	return true, nil
+}
+
+// Adds the file in the provided path to the cache.
+// The file is added only if its content matches the provided SHA-256 checksum.
+func (c *Cache) Put(sha256, sourcePath string) error {
+	if !isValidSha256(sha256) {
+		return nil
+	}
+	if exists, err := fileutils.IsFileExists(c.getCachedFilePath(sha256), false); err != nil || exists {
+		return err
+	}
+	return c.put(sha256, sourcePath)
+}
+
+// Adds the file in the provided path to the cache, under the SHA-256 checksum of its content.
+func (c *Cache) PutFile(sourcePath string) error {
+	return c.put("", sourcePath)
+}
+
+// Copies the file to the cache while calculating its checksum, and moves it to the path of the checksum.
+// If an expected checksum is provided, the file is added only if its content matches it.
+func (c *Cache) put(expectedSha256, sourcePath string) (err error) {
+	if err = fileutils.CreateDirIfNotExist(c.dir); err != nil {
+		return
+	}
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(source.Close()))
+	}()
+	// Another process may populate the same file concurrently, so the content is written to a unique temp file and then renamed.
+	target, err := os.CreateTemp(c.dir, "*"+tempFileSuffix)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	actualSha256, err := copyAndHash(target, source)
+	err = errors.Join(err, errorutils.CheckError(target.Close()))
+	if err == nil && expectedSha256 != "" && actualSha256 != strings.ToLower(expectedSha256) {
+		log.Debug("Skipping caching", sourcePath, "- its SHA-256 checksum", actualSha256, "does not match the expected checksum", expectedSha256)
+	} else if err == nil {
+		cachedFilePath := c.getCachedFilePath(actualSha256)
+		if err = fileutils.CreateDirIfNotExist(filepath.Dir(cachedFilePath)); err == nil {
+			if err = errorutils.CheckError(os.Rename(target.Name(), cachedFilePath)); err == nil {
+				return nil
+			}
+		}
+	}
+	return errors.Join(err, errorutils.CheckError(os.Remove(target.Name())))
+}
+
+func copyAndHash(target io.Writer, source io.Reader) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(target, hash), source); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Evicts the least recently used files, until the cache size does not exceed its maximum size.
+func (c *Cache) EvictIfNeeded() error {
+	_, _, err := c.Prune(c.maxSize)
+	return err
+}
+
+// Evicts the least recently used files, until the cache size does not exceed the provided size.
+// Returns the number of evicted files and the total size freed.
+func (c *Cache) Prune(maxSize int64) (evictedCount int, freedSize int64, err error) {
+	files, totalSize, err := c.listFiles()
+	if err != nil || totalSize <= maxSize {
+		return
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].lastAccess.Before(files[j].lastAccess)
+	})
+	for _, file := range files {
+		if totalSize <= maxSize {
+			break
+		}
+		// The file may have been evicted by another process already.
+		if e := os.Remove(file.path); e != nil && !os.IsNotExist(e) {
+			return evictedCount, freedSize, errorutils.CheckError(e)
+		}
+		log.Debug("Evicted from the download cache:", file.path)
+		totalSize -= file.size
+		freedSize += file.size
+		evictedCount++
+	}
+	return
+}
+
+func (c *Cache) Stats() (*Stats, error) {
+	files, totalSize, err := c.listFiles()
+	if err != nil {
+		return nil, err
+	}
+	stats := &Stats{Dir: c.dir, FilesCount: len(files), TotalSize: totalSize, MaxSize: c.maxSize}
+	for _, file := range files {
+		if stats.OldestAccess.IsZero() || file.lastAccess.Before(stats.OldestAccess) {
+			stats.OldestAccess = file.lastAccess
+		}
+		if file.lastAccess.After(stats.LatestAccess) {
+			stats.LatestAccess = file.lastAccess
+		}
+	}
+	return stats, nil
+}
+
+// Returns true if no files are cached.
+func (c *Cache) IsEmpty() (bool, error) {
+	empty := true
+	err := filepath.WalkDir(c.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), tempFileSuffix) {
+			return nil
+		}
+		empty = false
+		return fs.SkipAll
+	})
+	return empty, errorutils.CheckError(err)
+}
+
+// Removes all the cached files.
+func (c *Cache) Clear() error {
+	return errorutils.CheckError(os.RemoveAll(c.dir))
+}
+
+// Returns the cached files and their total size. Stale temp files are removed on the way.
+func (c *Cache) listFiles() (files []cachedFile, totalSize int64, err error) {
+	err = filepath.WalkDir(c.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		fileInfo, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if strings.HasSuffix(entry.Name(), tempFileSuffix) {
+			if time.Since(fileInfo.ModTime()) > staleTempFileAge {
+				log.Debug("Removing a stale temp file from the download cache:", path)
+				if e := os.Remove(path); e != nil && !os.IsNotExist(e) {
+					return e
+				}
+			}
+			return nil
+		}
+		files = append(files, cachedFile{path: path, size: fileInfo.Size(), lastAccess: fileInfo.ModTime()})
+		totalSize += fileInfo.Size()
+		return nil
+	})
+	return files, totalSize, errorutils.CheckError(err)
+}
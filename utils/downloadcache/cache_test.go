@@ -0,0 +1,117 @@
+package downloadcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+)
+
+func createFile(t *testing.T, dir, name, content string) (path, checksum string) {
+	path = filepath.Join(dir, name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	hash := sha256.Sum256([]byte(content))
+	return path, hex.EncodeToString(hash[:])
+}
+
+func TestPutAndGet(t *testing.T) {
+	sourceDir := t.TempDir()
+	cache := newCacheInDir(t.TempDir(), 1024)
+	path, checksum := createFile(t, sourceDir, "a.txt", "content")
+
+	found, err := cache.Get(checksum, filepath.Join(sourceDir, "target", "a.txt"))
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	assert.NoError(t, cache.Put(checksum, path))
+	target := filepath.Join(sourceDir, "target", "a.txt")
+	found, err = cache.Get(checksum, target)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	content, err := os.ReadFile(target)
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+
+	// A file which doesn't match its expected checksum should not be cached.
+	otherPath, _ := createFile(t, sourceDir, "b.txt", "other content")
+	_, wrongChecksum := createFile(t, sourceDir, "c.txt", "unexpected content")
+	assert.NoError(t, cache.Put(wrongChecksum, otherPath))
+	found, err = cache.Get(wrongChecksum, filepath.Join(sourceDir, "target", "b.txt"))
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	stats, err := cache.Stats()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.FilesCount)
+	assert.Equal(t, int64(len("content")), stats.TotalSize)
+
+	assert.NoError(t, cache.Clear())
+	stats, err = cache.Stats()
+	assert.NoError(t, err)
+	assert.Zero(t, stats.FilesCount)
+}
+
+func TestPrune(t *testing.T) {
+	sourceDir := t.TempDir()
+	cache := newCacheInDir(t.TempDir(), 20)
+	var checksums []string
+	for i, content := range []string{"0123456789", "abcdefghij", "ABCDEFGHIJ"} {
+		path, checksum := createFile(t, sourceDir, content, content)
+		assert.NoError(t, cache.Put(checksum, path))
+		// Make the files' access order deterministic.
+		accessTime := time.Now().Add(time.Duration(i-10) * time.Minute)
+		assert.NoError(t, os.Chtimes(cache.getCachedFilePath(checksum), accessTime, accessTime))
+		checksums = append(checksums, checksum)
+	}
+	// Using the first file makes the second one the least recently used.
+	found, err := cache.Get(checksums[0], filepath.Join(sourceDir, "target"))
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	assert.NoError(t, cache.EvictIfNeeded())
+	for i, expected := range []bool{true, false, true} {
+		_, err = os.Stat(cache.getCachedFilePath(checksums[i]))
+		assert.Equal(t, expected, err == nil)
+	}
+
+	evictedCount, freedSize, err := cache.Prune(0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, evictedCount)
+	assert.Equal(t, int64(20), freedSize)
+}
+
+func TestAddDownloadedFiles(t *testing.T) {
+	sourceDir := t.TempDir()
+	cache := newCacheInDir(t.TempDir(), 1024)
+	empty, err := cache.IsEmpty()
+	assert.NoError(t, err)
+	assert.True(t, empty)
+
+	downloadedPath, downloadedChecksum := createFile(t, sourceDir, "a.txt", "downloaded")
+	copiedPath, copiedChecksum := createFile(t, sourceDir, "b.txt", "copied")
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	assert.NoError(t, err)
+	writer.Write(clientutils.FileTransferDetails{SourcePath: "repo/a.txt", TargetPath: downloadedPath})
+	writer.Write(clientutils.FileTransferDetails{SourcePath: "repo/b.txt", TargetPath: copiedPath})
+	writer.Write(clientutils.FileTransferDetails{SourcePath: "repo/c.txt", TargetPath: filepath.Join(sourceDir, "missing.txt")})
+	assert.NoError(t, writer.Close())
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+
+	assert.NoError(t, cache.AddDownloadedFiles(reader, map[string]bool{copiedPath: true}))
+	_, err = os.Stat(cache.getCachedFilePath(downloadedChecksum))
+	assert.NoError(t, err)
+	_, err = os.Stat(cache.getCachedFilePath(copiedChecksum))
+	assert.True(t, os.IsNotExist(err))
+	empty, err = cache.IsEmpty()
+	assert.NoError(t, err)
+	assert.False(t, empty)
+}
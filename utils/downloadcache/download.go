@@ -0,0 +1,101 @@
+package downloadcache
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Copies the cached files matching the download spec to their local paths.
+// The download command then finds these files locally with the expected checksums, and doesn't download them again.
+// Returns the local paths of the copied files, which don't need to be added to the cache after the download.
+func (c *Cache) CopyCachedFiles(servicesManager artifactory.ArtifactoryServicesManager, downloadSpec *spec.SpecFiles) (copiedPaths map[string]bool, err error) {
+	log.Info("Looking for the files to download in the download cache...")
+	copiedPaths = make(map[string]bool)
+	for i := range downloadSpec.Files {
+		if err = c.copyCachedSpecFiles(servicesManager, &downloadSpec.Files[i], copiedPaths); err != nil {
+			return
+		}
+	}
+	log.Info("Found", strconv.Itoa(len(copiedPaths)), "files in the download cache.")
+	return
+}
+
+func (c *Cache) copyCachedSpecFiles(servicesManager artifactory.ArtifactoryServicesManager, file *spec.File, copiedPaths map[string]bool) (err error) {
+	searchParams, err := utils.GetSearchParams(file)
+	if err != nil {
+		return
+	}
+	flat, err := file.IsFlat(false)
+	if err != nil {
+		return
+	}
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		if item.Type == "folder" || item.Sha256 == "" {
+			continue
+		}
+		// The local path is resolved the same way the download command resolves it.
+		target, placeholdersUsed, err := clientutils.BuildTargetPath(file.Pattern, item.GetItemRelativePath(), file.Target, true)
+		if err != nil {
+			return err
+		}
+		localPath, localFileName := fileutils.GetLocalPathAndFile(item.Name, item.Path, target, flat, placeholdersUsed)
+		localFilePath := filepath.Join(localPath, localFileName)
+		isEqual, err := fileutils.IsEqualToLocalFile(localFilePath, item.Actual_Md5, item.Actual_Sha1)
+		if err != nil {
+			return err
+		}
+		if isEqual {
+			continue
+		}
+		found, err := c.Get(item.Sha256, localFilePath)
+		if err != nil {
+			return err
+		}
+		if found {
+			log.Debug("Copied", item.GetItemRelativePath(), "from the download cache to", localFilePath)
+			copiedPaths[localFilePath] = true
+		}
+	}
+	return reader.GetError()
+}
+
+// Adds the downloaded files to the cache, and evicts the least recently used files if the cache exceeds its maximum size.
+// The files are read from the transfer details of the download, so no additional search is needed.
+// Files which were copied from the cache, and files which are not regular files, such as symlinks, are skipped.
+func (c *Cache) AddDownloadedFiles(reader *content.ContentReader, copiedPaths map[string]bool) error {
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		if copiedPaths[transferDetails.TargetPath] {
+			continue
+		}
+		fileInfo, err := os.Lstat(transferDetails.TargetPath)
+		if err != nil || !fileInfo.Mode().IsRegular() {
+			continue
+		}
+		if err = c.PutFile(transferDetails.TargetPath); err != nil {
+			return err
+		}
+	}
+	if err := reader.GetError(); err != nil {
+		return err
+	}
+	reader.Reset()
+	return c.EvictIfNeeded()
+}
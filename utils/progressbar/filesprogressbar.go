@@ -9,6 +9,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
 	corelog "github.com/jfrog/jfrog-cli-core/v2/utils/log"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/progressbar"
@@ -38,12 +39,22 @@ type filesProgressBarManager struct {
 	tasksCount int64
 	// The log file
 	logFile *os.File
+	// A cumulative amount of transferred bytes, used to display the effective transfer rate
+	transferredBytes int64
+	// The bandwidth limit in bytes per second, 0 if unlimited
+	rateLimit int64
+	// The transfer rate calculated on the last sample
+	rate           int64
+	lastSampleTime time.Time
+	lastSampleSize int64
 }
 
 type progressBarUnit struct {
 	bar         *mpb.Bar
 	incrChannel chan int
 	description string
+	// The manager's cumulative amount of transferred bytes
+	transferredBytes *int64
 }
 
 type progressBar interface {
@@ -81,6 +92,7 @@ func (p *filesProgressBarManager) NewProgressReader(total int64, label, path str
 
 	// Add bar to bars array
 	unit := initNewBarUnit(newBar, path)
+	unit.transferredBytes = &p.transferredBytes
 	barId := len(p.bars) + 1
 	readerProgressBar := ReaderProgressBar{progressBarUnit: unit, Id: barId}
 	p.bars = append(p.bars, &readerProgressBar)
@@ -261,10 +273,34 @@ func (p *filesProgressBarManager) newGeneralProgressBar() {
 		mpb.AppendDecorators(
 			decor.Name(" Tasks: "),
 			decor.CountersNoUnit("%d/%d"),
+			decor.Any(p.decorateTransferRate),
 		),
 	)
 }
 
+const rateSampleInterval = time.Second
+
+// Displays the effective transfer rate, and the bandwidth limit if set.
+// Called by the progress bar on every refresh, the rate is recalculated once in a sample interval.
+func (p *filesProgressBarManager) decorateTransferRate(decor.Statistics) string {
+	now := time.Now()
+	transferredBytes := atomic.LoadInt64(&p.transferredBytes)
+	if p.lastSampleTime.IsZero() {
+		p.lastSampleTime, p.lastSampleSize = now, transferredBytes
+	} else if elapsed := now.Sub(p.lastSampleTime); elapsed >= rateSampleInterval {
+		p.rate = int64(float64(transferredBytes-p.lastSampleSize) / elapsed.Seconds())
+		p.lastSampleTime, p.lastSampleSize = now, transferredBytes
+	}
+	if transferredBytes == 0 {
+		return ""
+	}
+	rate := " | " + utils.ConvertIntToStorageSizeString(p.rate) + "/s"
+	if p.rateLimit > 0 {
+		rate += " (limit: " + utils.ConvertIntToStorageSizeString(p.rateLimit) + "/s)"
+	}
+	return rate
+}
+
 // Initializes a new progress bar for headline, with a spinner
 func (p *filesProgressBarManager) newHeadlineBar(headline string) {
 	p.barsWg.Add(1)
@@ -317,6 +353,17 @@ type CommandWithProgress interface {
 }
 
 func ExecWithProgress(cmd CommandWithProgress) (err error) {
+	return ExecWithRateLimitedProgress(cmd, 0)
+}
+
+// Executes the command with a progress bar, while limiting the total transfer rate of the command to the provided
+// bytes per second. If 0 is provided, the limit is taken from the JFROG_CLI_MAX_BANDWIDTH environment variable.
+func ExecWithRateLimitedProgress(cmd CommandWithProgress, bytesPerSecond int64) (err error) {
+	if bytesPerSecond <= 0 {
+		if bytesPerSecond, err = GetBandwidthLimit(""); err != nil {
+			return err
+		}
+	}
 	// Show log file path on all progress bars except 'setup' command
 	showLogFilePath := cmd.CommandName() != "setup"
 	// Init progress bar.
@@ -324,6 +371,10 @@ func ExecWithProgress(cmd CommandWithProgress) (err error) {
 	if err != nil {
 		return err
 	}
+	if bytesPerSecond > 0 {
+		// The rate is limited even if the progress bar can't be displayed.
+		progressBar = newRateLimitedProgressMgr(progressBar, bytesPerSecond)
+	}
 	if progressBar != nil {
 		cmd.SetProgress(progressBar)
 		defer func() {
@@ -0,0 +1,218 @@
+package progressbar
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+)
+
+const (
+	MaxBandwidthEnv = "JFROG_CLI_MAX_BANDWIDTH"
+	// The maximal portion of the rate limit read at once, so that the transfer is throttled smoothly.
+	maxReadsPerSecond = 10
+)
+
+var rateRegexp = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([KMG]?B?)(?:/S)?$`)
+
+// Parses a transfer rate, such as '20MB/s', '512KB/s' or '1048576', to bytes per second.
+// Units are binary (1KB is 1024 bytes). An empty rate is parsed to 0, which means unlimited.
+func ParseRate(rate string) (int64, error) {
+	if rate == "" {
+		return 0, nil
+	}
+	match := rateRegexp.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(rate)))
+	if match == nil {
+		return 0, errorutils.CheckErrorf("invalid transfer rate '%s'. The rate should be a number of bytes per second, optionally followed by a unit, such as '20MB/s' or '512KB/s'", rate)
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, errorutils.CheckError(err)
+	}
+	switch strings.TrimSuffix(match[2], "B") {
+	case "K":
+		value *= 1 << 10
+	case "M":
+		value *= 1 << 20
+	case "G":
+		value *= 1 << 30
+	}
+	return int64(value), nil
+}
+
+// Returns the bandwidth limit in bytes per second. The provided rate (usually taken from the --limit-rate option)
+// takes precedence over the JFROG_CLI_MAX_BANDWIDTH environment variable. 0 means unlimited.
+func GetBandwidthLimit(limitRate string) (int64, error) {
+	if limitRate == "" {
+		limitRate = os.Getenv(MaxBandwidthEnv)
+	}
+	return ParseRate(limitRate)
+}
+
+// Limits the total rate of all the readers sharing it, by delaying each read until the bytes it read are allowed.
+type rateLimiter struct {
+	bytesPerSecond int64
+	// The time at which the next read is allowed.
+	next  time.Time
+	mutex sync.Mutex
+}
+
+func newRateLimiter(bytesPerSecond int64) *rateLimiter {
+	return &rateLimiter{bytesPerSecond: bytesPerSecond}
+}
+
+func (rl *rateLimiter) maxReadSize() int {
+	if size := rl.bytesPerSecond / maxReadsPerSecond; size > 0 {
+		return int(size)
+	}
+	return 1
+}
+
+func (rl *rateLimiter) wait(n int) {
+	rl.mutex.Lock()
+	now := time.Now()
+	// Unused time isn't saved for later, so idle periods don't cause bursts.
+	if rl.next.Before(now) {
+		rl.next = now
+	}
+	delay := rl.next.Sub(now)
+	rl.next = rl.next.Add(time.Duration(int64(n) * int64(time.Second) / rl.bytesPerSecond))
+	rl.mutex.Unlock()
+	time.Sleep(delay)
+}
+
+type rateLimitedReader struct {
+	io.Reader
+	limiter *rateLimiter
+}
+
+func (r *rateLimitedReader) Read(p []byte) (n int, err error) {
+	if maxSize := r.limiter.maxReadSize(); len(p) > maxSize {
+		p = p[:maxSize]
+	}
+	n, err = r.Reader.Read(p)
+	if n > 0 {
+		r.limiter.wait(n)
+	}
+	return
+}
+
+func (r *rateLimitedReader) Close() error {
+	if closer, ok := r.Reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Wraps a progress manager, and limits the total rate of all the transfers reported to it.
+// The uploaded and downloaded content, including the chunks of split downloads, is read through the progress indicators,
+// so a single limiter shared by all of them enforces the limit across all the worker threads.
+// The wrapped progress manager may be nil, if the progress bar can't be displayed.
+type rateLimitedProgressMgr struct {
+	ioUtils.ProgressMgr
+	limiter *rateLimiter
+}
+
+func newRateLimitedProgressMgr(progressMgr ioUtils.ProgressMgr, bytesPerSecond int64) *rateLimitedProgressMgr {
+	if filesProgressMgr, ok := progressMgr.(*filesProgressBarManager); ok {
+		filesProgressMgr.rateLimit = bytesPerSecond
+	}
+	return &rateLimitedProgressMgr{ProgressMgr: progressMgr, limiter: newRateLimiter(bytesPerSecond)}
+}
+
+func (p *rateLimitedProgressMgr) NewProgressReader(total int64, label, path string) ioUtils.Progress {
+	if p.ProgressMgr == nil {
+		return &rateLimitedProgress{limiter: p.limiter}
+	}
+	return &rateLimitedProgress{Progress: p.ProgressMgr.NewProgressReader(total, label, path), limiter: p.limiter}
+}
+
+func (p *rateLimitedProgressMgr) GetProgress(id int) ioUtils.Progress {
+	if p.ProgressMgr == nil {
+		return &rateLimitedProgress{limiter: p.limiter}
+	}
+	return &rateLimitedProgress{Progress: p.ProgressMgr.GetProgress(id), limiter: p.limiter}
+}
+
+func (p *rateLimitedProgressMgr) SetProgressState(id int, state string) {
+	if p.ProgressMgr != nil {
+		p.ProgressMgr.SetProgressState(id, state)
+	}
+}
+
+func (p *rateLimitedProgressMgr) RemoveProgress(id int) {
+	if p.ProgressMgr != nil {
+		p.ProgressMgr.RemoveProgress(id)
+	}
+}
+
+func (p *rateLimitedProgressMgr) IncrementGeneralProgress() {
+	if p.ProgressMgr != nil {
+		p.ProgressMgr.IncrementGeneralProgress()
+	}
+}
+
+func (p *rateLimitedProgressMgr) Quit() error {
+	if p.ProgressMgr != nil {
+		return p.ProgressMgr.Quit()
+	}
+	return nil
+}
+
+func (p *rateLimitedProgressMgr) IncGeneralProgressTotalBy(n int64) {
+	if p.ProgressMgr != nil {
+		p.ProgressMgr.IncGeneralProgressTotalBy(n)
+	}
+}
+
+func (p *rateLimitedProgressMgr) SetHeadlineMsg(msg string) {
+	if p.ProgressMgr != nil {
+		p.ProgressMgr.SetHeadlineMsg(msg)
+	}
+}
+
+func (p *rateLimitedProgressMgr) ClearHeadlineMsg() {
+	if p.ProgressMgr != nil {
+		p.ProgressMgr.ClearHeadlineMsg()
+	}
+}
+
+func (p *rateLimitedProgressMgr) InitProgressReaders() {
+	if p.ProgressMgr != nil {
+		p.ProgressMgr.InitProgressReaders()
+	}
+}
+
+type rateLimitedProgress struct {
+	ioUtils.Progress
+	limiter *rateLimiter
+}
+
+func (p *rateLimitedProgress) ActionWithProgress(reader io.Reader) io.Reader {
+	if p.Progress != nil {
+		reader = p.Progress.ActionWithProgress(reader)
+	}
+	if reader == nil {
+		return nil
+	}
+	return &rateLimitedReader{Reader: reader, limiter: p.limiter}
+}
+
+func (p *rateLimitedProgress) Abort() {
+	if p.Progress != nil {
+		p.Progress.Abort()
+	}
+}
+
+func (p *rateLimitedProgress) GetId() int {
+	if p.Progress != nil {
+		return p.Progress.GetId()
+	}
+	return 0
+}
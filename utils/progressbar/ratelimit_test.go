@@ -0,0 +1,65 @@
+package progressbar
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		rate     string
+		expected int64
+	}{
+		{"", 0},
+		{"1024", 1024},
+		{"512KB/s", 512 * 1024},
+		{"20MB/s", 20 * 1024 * 1024},
+		{"1.5mb", 1536 * 1024},
+		{"2G/s", 2 * 1024 * 1024 * 1024},
+	}
+	for _, test := range tests {
+		t.Run(test.rate, func(t *testing.T) {
+			actual, err := ParseRate(test.rate)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+	for _, rate := range []string{"fast", "20TB/s", "-1MB/s", "MB/s"} {
+		_, err := ParseRate(rate)
+		assert.Error(t, err, rate)
+	}
+}
+
+func TestGetBandwidthLimit(t *testing.T) {
+	t.Setenv(MaxBandwidthEnv, "1KB/s")
+	limit, err := GetBandwidthLimit("")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1024), limit)
+	limit, err = GetBandwidthLimit("2KB/s")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2048), limit)
+}
+
+func TestRateLimitedProgressMgr(t *testing.T) {
+	// 400KB are read by 4 concurrent readers sharing a limit of 1MB/s, so it should take about 400 milliseconds.
+	progressMgr := newRateLimitedProgressMgr(nil, 1024*1024)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reader := progressMgr.NewProgressReader(100*1024, "Downloading", "path").ActionWithProgress(bytes.NewReader(make([]byte, 100*1024)))
+			read, err := io.Copy(io.Discard, reader)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(100*1024), read)
+		}()
+	}
+	wg.Wait()
+	assert.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond)
+}
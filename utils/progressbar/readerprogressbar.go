@@ -2,6 +2,7 @@ package progressbar
 
 import (
 	"io"
+	"sync/atomic"
 )
 
 type ReaderProgressBar struct {
@@ -59,6 +60,9 @@ func (pr *proxyReader) Read(p []byte) (n int, err error) {
 	n, err = pr.ReadCloser.Read(p)
 	if n > 0 && (err == nil || err == io.EOF) {
 		pr.incrChannel(n)
+		if pr.unit.transferredBytes != nil {
+			atomic.AddInt64(pr.unit.transferredBytes, int64(n))
+		}
 	}
 	return
 }
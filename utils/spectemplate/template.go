@@ -0,0 +1,205 @@
+package spectemplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/schema"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v2"
+)
+
+// Includes of includes are allowed, up to this depth. It stops circular includes.
+const maxIncludeDepth = 10
+
+// Reads a File Spec, which may be a template or a YAML file, and validates it against the File Spec schema if it was expanded.
+// The spec vars are substituted as ${key} in the File Spec, like in a regular File Spec, and are also the data of the template.
+func CreateSpecFromFile(specPath string, specVars map[string]string) (*spec.SpecFiles, error) {
+	content, expanded, err := Render(specPath, specVars)
+	if err != nil {
+		return nil, err
+	}
+	if expanded {
+		if err = schema.ValidateFileSpec(content); err != nil {
+			return nil, err
+		}
+	}
+	specFiles := new(spec.SpecFiles)
+	return specFiles, errorutils.CheckError(json.Unmarshal(content, specFiles))
+}
+
+// Renders the File Spec and returns it as JSON.
+// A File Spec containing template actions ("{{ ... }}") is rendered as a Go template, and a File Spec with a .yaml or .yml
+// extension is converted from YAML. Returns true if the File Spec was expanded in any of these ways.
+func Render(specPath string, specVars map[string]string) (content []byte, expanded bool, err error) {
+	if content, expanded, err = RenderTemplate(specPath, specVars); err != nil {
+		return
+	}
+	if IsYaml(specPath) {
+		content, err = YamlToJson(content)
+		return content, true, err
+	}
+	return
+}
+
+// Renders the File Spec if it is a template, without converting a YAML File Spec to JSON.
+// Returns true if the File Spec was rendered as a template.
+func RenderTemplate(specPath string, specVars map[string]string) ([]byte, bool, error) {
+	data := make(map[string]interface{}, len(specVars))
+	for key, value := range specVars {
+		data[key] = value
+	}
+	r := &renderer{specVars: specVars, rootData: data}
+	return r.renderFile(specPath, data, 0)
+}
+
+func IsYaml(path string) bool {
+	extension := strings.ToLower(filepath.Ext(path))
+	return extension == ".yaml" || extension == ".yml"
+}
+
+type renderer struct {
+	specVars map[string]string
+	rootData map[string]interface{}
+}
+
+func (r *renderer) renderFile(path string, data interface{}, depth int) ([]byte, bool, error) {
+	if depth > maxIncludeDepth {
+		return nil, false, errorutils.CheckErrorf("the includes are nested more than %d levels deep when including '%s'. Check for circular includes", maxIncludeDepth, path)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, errorutils.CheckError(err)
+	}
+	if len(r.specVars) > 0 {
+		content = coreutils.ReplaceVars(content, r.specVars)
+	}
+	if !bytes.Contains(content, []byte("{{")) {
+		return content, false, nil
+	}
+	tmpl, err := template.New(filepath.Base(path)).Option("missingkey=error").Funcs(r.funcs(path, depth)).Parse(string(content))
+	if err != nil {
+		return nil, false, errorutils.CheckErrorf("failed parsing the File Spec template '%s': %s", path, err.Error())
+	}
+	var rendered bytes.Buffer
+	if err = tmpl.Execute(&rendered, data); err != nil {
+		return nil, false, errorutils.CheckErrorf("failed rendering the File Spec template '%s': %s", path, err.Error())
+	}
+	return rendered.Bytes(), true, nil
+}
+
+// The functions available in File Spec templates, in addition to the built-in functions of Go templates.
+func (r *renderer) funcs(path string, depth int) template.FuncMap {
+	return template.FuncMap{
+		// Renders another template, with a path relative to the including template. The data of the included
+		// template is the data of the File Spec, unless provided.
+		"include": func(name string, data ...interface{}) (string, error) {
+			if !filepath.IsAbs(name) {
+				name = filepath.Join(filepath.Dir(path), name)
+			}
+			var includeData interface{} = r.rootData
+			if len(data) > 0 {
+				includeData = data[0]
+			}
+			content, _, err := r.renderFile(name, includeData, depth+1)
+			return string(content), err
+		},
+		// Splits a list of values, such as a spec var, ignoring empty values and the spaces around the values.
+		"split": func(list, separator string) []string {
+			var values []string
+			for _, value := range strings.Split(list, separator) {
+				if value = strings.TrimSpace(value); value != "" {
+					values = append(values, value)
+				}
+			}
+			return values
+		},
+		"join": func(values []string, separator string) string {
+			return strings.Join(values, separator)
+		},
+		// Returns the value as a quoted JSON string.
+		"quote": func(value interface{}) (string, error) {
+			quoted, err := json.Marshal(fmt.Sprint(value))
+			return string(quoted), errorutils.CheckError(err)
+		},
+		"default": func(defaultValue, value interface{}) interface{} {
+			if value == nil || value == "" {
+				return defaultValue
+			}
+			return value
+		},
+		// Creates a map from a list of key-value pairs, to pass several values to an included template.
+		"dict": func(pairs ...interface{}) (map[string]interface{}, error) {
+			if len(pairs)%2 != 0 {
+				return nil, errorutils.CheckErrorf("dict expects pairs of keys and values")
+			}
+			dict := make(map[string]interface{}, len(pairs)/2)
+			for i := 0; i < len(pairs); i += 2 {
+				dict[fmt.Sprint(pairs[i])] = pairs[i+1]
+			}
+			return dict, nil
+		},
+		// Indents all the lines of the text, to include a YAML template inside a nested YAML block.
+		"indent": func(spaces int, text string) string {
+			padding := strings.Repeat(" ", spaces)
+			return padding + strings.ReplaceAll(text, "\n", "\n"+padding)
+		},
+	}
+}
+
+// Converts a YAML File Spec to JSON.
+func YamlToJson(content []byte) ([]byte, error) {
+	var parsed interface{}
+	if err := yaml.Unmarshal(content, &parsed); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the YAML File Spec: %s", err.Error())
+	}
+	converted, err := toJsonCompatible(parsed)
+	if err != nil {
+		return nil, err
+	}
+	jsonContent, err := json.Marshal(converted)
+	return jsonContent, errorutils.CheckError(err)
+}
+
+// YAML maps are decoded with keys of any type, while JSON only supports string keys.
+func toJsonCompatible(value interface{}) (interface{}, error) {
+	switch typed := value.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			keyString, ok := key.(string)
+			if !ok {
+				return nil, errorutils.CheckErrorf("the YAML File Spec contains a non-string key: %v", key)
+			}
+			convertedItem, err := toJsonCompatible(item)
+			if err != nil {
+				return nil, err
+			}
+			converted[keyString] = convertedItem
+		}
+		return converted, nil
+	case []interface{}:
+		converted := make([]interface{}, len(typed))
+		for i, item := range typed {
+			convertedItem, err := toJsonCompatible(item)
+			if err != nil {
+				return nil, err
+			}
+			converted[i] = convertedItem
+		}
+		return converted, nil
+	case bool:
+		// The boolean options of File Specs are strings.
+		return strconv.FormatBool(typed), nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,76 @@
+package spectemplate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestCreateSpecFromTemplate(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "file.json", `{"pattern": "{{ .repo }}/{{ .path }}", "target": "${target}"{{ if .flat }}, "flat": "true"{{ end }}}`)
+	specPath := writeFile(t, dir, "spec.json", `{"files": [
+		{{- range $i, $repo := split .repos "," }}{{ if $i }},{{ end }}
+		{{ include "file.json" (dict "repo" $repo "path" $.path "flat" false) }}
+		{{- end }}
+	]}`)
+
+	specFiles, err := CreateSpecFromFile(specPath, map[string]string{"repos": "a-local, b-local,", "path": "*.zip", "target": "out/"})
+	require.NoError(t, err)
+	require.Len(t, specFiles.Files, 2)
+	assert.Equal(t, "a-local/*.zip", specFiles.Files[0].Pattern)
+	assert.Equal(t, "b-local/*.zip", specFiles.Files[1].Pattern)
+	assert.Equal(t, "out/", specFiles.Files[1].Target)
+	assert.Empty(t, specFiles.Files[1].Flat)
+
+	// A missing template variable fails the rendering.
+	_, err = CreateSpecFromFile(specPath, map[string]string{"repos": "a-local"})
+	assert.ErrorContains(t, err, "failed rendering the File Spec template")
+}
+
+func TestCreateSpecFromYaml(t *testing.T) {
+	dir := t.TempDir()
+	specPath := writeFile(t, dir, "spec.yaml", `files:
+{{- range split .repos "," }}
+  - pattern: {{ . }}/*.zip
+    flat: true
+    limit: 10
+{{- end }}
+`)
+
+	specFiles, err := CreateSpecFromFile(specPath, map[string]string{"repos": "a-local,b-local"})
+	require.NoError(t, err)
+	require.Len(t, specFiles.Files, 2)
+	assert.Equal(t, "b-local/*.zip", specFiles.Files[1].Pattern)
+	assert.Equal(t, "true", specFiles.Files[1].Flat)
+	assert.Equal(t, 10, specFiles.Files[1].Limit)
+}
+
+func TestCreateSpecSchemaValidation(t *testing.T) {
+	dir := t.TempDir()
+	specPath := writeFile(t, dir, "spec.yml", "files:\n  - pattern: a-local/*.zip\n    flat: maybe\n")
+	_, err := CreateSpecFromFile(specPath, nil)
+	assert.ErrorContains(t, err, "flat")
+
+	// A File Spec which isn't expanded is used as is, like before.
+	specPath = writeFile(t, dir, "spec.json", `{"files": [{"pattern": "a-local/*.zip", "flat": "maybe"}]}`)
+	specFiles, err := CreateSpecFromFile(specPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "maybe", specFiles.Files[0].Flat)
+}
+
+func TestCircularInclude(t *testing.T) {
+	dir := t.TempDir()
+	specPath := writeFile(t, dir, "spec.json", `{{ include "spec.json" }}`)
+	_, _, err := Render(specPath, nil)
+	assert.ErrorContains(t, err, "circular includes")
+}